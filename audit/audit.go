@@ -0,0 +1,308 @@
+// Package audit keeps the trail of the list and budget changes: who changed
+// what, when and with which previous values. The last change of the user
+// can be undone within the window, records older than the retention are pruned.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
+	entaudit "github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/audit"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+)
+
+// Kinds of the changes, nodes undo changes of their kind
+const (
+	KindShoplist = "shoplist"
+	KindBuget    = "buget"
+)
+
+const (
+	// DefaultWindow is the time the change can be undone
+	DefaultWindow = time.Minute * 10
+	// DefaultRetention is the time the records are kept
+	DefaultRetention = time.Hour * 24 * 90
+)
+
+// Log records events of the users and undoes them
+type Log struct {
+	ent     *ent.Client
+	storage bugetstorage.Storage
+	window  time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	undoing map[int]bool // index by user ID
+}
+
+// NewLog returns audit log, zero window means DefaultWindow
+func NewLog(e *ent.Client, storage bugetstorage.Storage, window time.Duration) *Log {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Log{
+		ent:     e,
+		storage: storage,
+		window:  window,
+		now:     time.Now,
+		undoing: map[int]bool{},
+	}
+}
+
+// Handle records the event, it is subscribed to the bus. Changes made while
+// the user undoes are not recorded.
+func (l *Log) Handle(event events.Event) {
+	var (
+		author events.Author
+		kind   string
+	)
+	switch e := event.(type) {
+	case events.ItemAdded:
+		author, kind = e.Author, KindShoplist
+	case events.ItemsRemoved:
+		author, kind = e.Author, KindShoplist
+	case events.ItemsCopied:
+		author, kind = e.Author, KindShoplist
+	case events.ItemCompleted:
+		author, kind = e.Author, KindShoplist
+	case events.ItemQuantityChanged:
+		author, kind = e.Author, KindShoplist
+	case events.ItemCategoryChanged:
+		author, kind = e.Author, KindShoplist
+	case events.ShoppingCreated:
+		// special shoppings are created on the first use
+		if e.Type != int(consts.ShoppingTypeDefault) {
+			return
+		}
+		author, kind = e.Author, KindShoplist
+	case events.NoteAdded:
+		author, kind = e.Author, KindBuget
+	default:
+		return
+	}
+	if author.UserID == 0 || l.isUndoing(author.UserID) {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("audit marshal event=%s error=%v\n", event.Name(), err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	err = l.ent.Audit.
+		Create().
+		SetUserID(author.UserID).
+		SetTelegramID(author.TelegramID).
+		SetComunityID(author.ComunityID).
+		SetKind(kind).
+		SetAction(event.Name()).
+		SetPayload(string(payload)).
+		SetCreated(l.now()).
+		Exec(ctx)
+	if err != nil {
+		log.Printf("audit record event=%s user_id=%d error=%v\n", event.Name(), author.UserID, err)
+	}
+}
+
+// Run prunes the records older than the retention every interval until the
+// context is done, zero retention means DefaultRetention
+func (l *Log) Run(ctx context.Context, interval, retention time.Duration) {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := l.Prune(l.now().Add(-retention)); err != nil {
+			log.Printf("audit prune error=%v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune deletes the records created before the time, it returns count of the
+// deleted records
+func (l *Log) Prune(before time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	return l.ent.Audit.
+		Delete().
+		Where(entaudit.CreatedLT(before)).
+		Exec(ctx)
+}
+
+// CanUndo reports whether the user has the change of the kind to undo
+func (l *Log) CanUndo(sessionItem *session.SessionItem, kind string) (bool, error) {
+	if sessionItem.User == nil {
+		return false, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
+	defer cancel()
+
+	record, err := l.last(ctx, sessionItem.User.ID, kind)
+	if err != nil {
+		return false, err
+	}
+	return record != nil, nil
+}
+
+// Undo reverts the last change of the kind made by the user within the window,
+// it returns false if there is nothing to undo or the change is already undone
+func (l *Log) Undo(sessionItem *session.SessionItem, kind string) (bool, error) {
+	if sessionItem.User == nil {
+		return false, nil
+	}
+	userID := sessionItem.User.ID
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	record, err := l.last(ctx, userID, kind)
+	if err != nil {
+		return false, fmt.Errorf("audit last change: %w", err)
+	}
+	if record == nil {
+		return false, nil
+	}
+
+	l.setUndoing(userID, true)
+	defer l.setUndoing(userID, false)
+
+	if err := l.revert(ctx, sessionItem, record); err != nil {
+		// the changed target is removed, so the change can't be undone anymore
+		if errors.Is(err, consts.ErrNotFound) || errors.Is(err, sql.ErrNoRows) {
+			if markErr := l.markUndone(ctx, record.ID); markErr != nil {
+				log.Printf("audit mark undone id=%d error=%v\n", record.ID, markErr)
+			}
+			return false, fmt.Errorf("audit undo %s: %v: %w", record.Action, err, consts.ErrNotFound)
+		}
+		return false, fmt.Errorf("audit undo %s: %w", record.Action, err)
+	}
+
+	if err := l.markUndone(ctx, record.ID); err != nil {
+		return false, fmt.Errorf("audit mark undone: %w", err)
+	}
+	return true, nil
+}
+
+// revert makes the change opposite to the recorded one
+func (l *Log) revert(ctx context.Context, sessionItem *session.SessionItem, record *ent.Audit) error {
+	switch record.Action {
+	case events.ItemAdded{}.Name():
+		added := events.ItemAdded{}
+		if err := json.Unmarshal([]byte(record.Payload), &added); err != nil {
+			return err
+		}
+		return sessionItem.SListAPI.RemoveItems(added.ItemIDs)
+	case events.ItemsRemoved{}.Name():
+		removed := events.ItemsRemoved{}
+		if err := json.Unmarshal([]byte(record.Payload), &removed); err != nil {
+			return err
+		}
+		return sessionItem.SListAPI.RestoreItems(removed.ShoppingID, removed.Items)
+	case events.ItemsCopied{}.Name():
+		copied := events.ItemsCopied{}
+		if err := json.Unmarshal([]byte(record.Payload), &copied); err != nil {
+			return err
+		}
+		return sessionItem.SListAPI.UncopyItems(copied)
+	case events.ItemCompleted{}.Name():
+		completed := events.ItemCompleted{}
+		if err := json.Unmarshal([]byte(record.Payload), &completed); err != nil {
+			return err
+		}
+		return sessionItem.SListAPI.SetItemComplete(completed.ItemID, !completed.Complete)
+	case events.ItemQuantityChanged{}.Name():
+		changed := events.ItemQuantityChanged{}
+		if err := json.Unmarshal([]byte(record.Payload), &changed); err != nil {
+			return err
+		}
+		return sessionItem.SListAPI.SetItemQuantity(changed.ItemID, changed.Previous)
+	case events.ItemCategoryChanged{}.Name():
+		changed := events.ItemCategoryChanged{}
+		if err := json.Unmarshal([]byte(record.Payload), &changed); err != nil {
+			return err
+		}
+		return sessionItem.SListAPI.SetItemCategory(changed.ItemID, changed.Previous)
+	case events.ShoppingCreated{}.Name():
+		created := events.ShoppingCreated{}
+		if err := json.Unmarshal([]byte(record.Payload), &created); err != nil {
+			return err
+		}
+		return sessionItem.SListAPI.RemoveShopping(created.ShoppingID)
+	case events.NoteAdded{}.Name():
+		added := events.NoteAdded{}
+		if err := json.Unmarshal([]byte(record.Payload), &added); err != nil {
+			return err
+		}
+		return l.storage.RevertNote(ctx, sessionItem.User.ComunityID, added.NoteID)
+	}
+	return fmt.Errorf("unknown action %q", record.Action)
+}
+
+// last returns the last change of the user within the window or nil if it is
+// undone. Only the last change is undone: items restored by undo get new IDs,
+// so the previous changes can't be reverted by their records.
+func (l *Log) last(ctx context.Context, userID int, kind string) (*ent.Audit, error) {
+	record, err := l.ent.Audit.
+		Query().
+		Where(
+			entaudit.UserIDEQ(userID),
+			entaudit.KindEQ(kind),
+			entaudit.CreatedGTE(l.now().Add(-l.window)),
+		).
+		Order(ent.Desc(entaudit.FieldCreated), ent.Desc(entaudit.FieldID)).
+		First(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case record.Undone:
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (l *Log) markUndone(ctx context.Context, ID int) error {
+	return l.ent.Audit.
+		UpdateOneID(ID).
+		SetUndone(true).
+		Exec(ctx)
+}
+
+func (l *Log) isUndoing(userID int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.undoing[userID]
+}
+
+func (l *Log) setUndoing(userID int, undoing bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if undoing {
+		l.undoing[userID] = true
+		return
+	}
+	delete(l.undoing, userID)
+}
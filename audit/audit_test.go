@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/enttest"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	"github.com/Frosin/shoplist-telegram-bot/shoplist"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndo(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:audit?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	now := time.Now()
+	auditLog := NewLog(e, bugetstorage.Storage{}, time.Minute)
+	auditLog.now = func() time.Time { return now }
+	bus := events.NewBus()
+	bus.Subscribe(auditLog.Handle)
+
+	usr, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "user")
+	require.NoError(t, err)
+	api := shoplist.NewShoplistAPI(e, usr.Token)
+	api.SetEvents(bus)
+	sessionItem := &session.SessionItem{User: usr, SListAPI: api}
+
+	// special shoppings are not recorded
+	_, err = api.AddShoppingWithType(now, consts.ChecklistWord, consts.ShoppingTypeCheckList)
+	require.NoError(t, err)
+	canUndo, err := auditLog.CanUndo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.False(t, canUndo)
+
+	shoppingID, err := api.AddShoppingWithType(now, "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	canUndo, err = auditLog.CanUndo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.True(t, canUndo)
+
+	// changes of other kinds are not undone
+	undone, err := auditLog.Undo(sessionItem, KindBuget)
+	require.NoError(t, err)
+	require.False(t, undone)
+
+	// the change can't be undone after the window
+	auditLog.now = func() time.Time { return now.Add(time.Minute * 2) }
+	canUndo, err = auditLog.CanUndo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.False(t, canUndo)
+
+	auditLog.now = func() time.Time { return now }
+	undone, err = auditLog.Undo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.True(t, undone)
+	_, err = api.GetShopping(shoppingID)
+	require.ErrorIs(t, err, consts.ErrNotFound)
+
+	undone, err = auditLog.Undo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.False(t, undone)
+
+	// session without user has nothing to undo
+	canUndo, err = auditLog.CanUndo(&session.SessionItem{}, KindShoplist)
+	require.NoError(t, err)
+	require.False(t, canUndo)
+}
+
+func TestUndoRemovedItems(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:auditremoved?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	auditLog := NewLog(e, bugetstorage.Storage{}, time.Minute)
+	bus := events.NewBus()
+	bus.Subscribe(auditLog.Handle)
+
+	usr, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "user")
+	require.NoError(t, err)
+	api := shoplist.NewShoplistAPI(e, usr.Token)
+	api.SetEvents(bus)
+	sessionItem := &session.SessionItem{User: usr, SListAPI: api}
+
+	shoppingID, err := api.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, api.AddItem(shoppingID, "молоко 2л"))
+	items, err := api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	_, err = e.Item.
+		UpdateOneID(items[0].ID).
+		SetCategoryID(3).
+		SetComplete(true).
+		Save(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, api.RemoveItems([]int{items[0].ID}))
+
+	// the item is restored as it was
+	undone, err := auditLog.Undo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.True(t, undone)
+	restored, err := api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	require.NotEqual(t, items[0].ID, restored[0].ID)
+	require.Equal(t, items[0].ProductName, restored[0].ProductName)
+	require.Equal(t, 2, restored[0].Quantity)
+	require.Equal(t, items[0].Unit, restored[0].Unit)
+	require.Equal(t, 3, restored[0].CategoryID)
+	require.True(t, restored[0].Complete)
+}
+
+func TestUndoMovedItems(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:auditmoved?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	auditLog := NewLog(e, bugetstorage.Storage{}, time.Minute)
+	bus := events.NewBus()
+	bus.Subscribe(auditLog.Handle)
+
+	usr, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "user")
+	require.NoError(t, err)
+	api := shoplist.NewShoplistAPI(e, usr.Token)
+	api.SetEvents(bus)
+	sessionItem := &session.SessionItem{User: usr, SListAPI: api}
+
+	fromID, err := api.AddShoppingWithType(time.Now(), consts.CurrentlistWord, consts.ShoppingTypeCurrentList)
+	require.NoError(t, err)
+	_, err = api.AddItems(fromID, []string{"milk", "bread"})
+	require.NoError(t, err)
+	fromItems, err := api.GetShoppingItems(fromID)
+	require.NoError(t, err)
+	shoppingID, err := api.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, api.AddItem(shoppingID, "milk"))
+	_, err = api.MoveItems(fromID, shoppingID, []int{fromItems[0].ID, fromItems[1].ID})
+	require.NoError(t, err)
+
+	// the whole move is undone
+	undone, err := auditLog.Undo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.True(t, undone)
+	items, err := api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, 1, items[0].Quantity)
+	restored, err := api.GetShoppingItems(fromID)
+	require.NoError(t, err)
+	require.Len(t, restored, 2)
+}
+
+func TestPrune(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:auditprune?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	now := time.Now()
+	auditLog := NewLog(e, bugetstorage.Storage{}, time.Minute)
+	bus := events.NewBus()
+	bus.Subscribe(auditLog.Handle)
+
+	usr, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "user")
+	require.NoError(t, err)
+	api := shoplist.NewShoplistAPI(e, usr.Token)
+	api.SetEvents(bus)
+	sessionItem := &session.SessionItem{User: usr, SListAPI: api}
+
+	auditLog.now = func() time.Time { return now.Add(-time.Hour * 2) }
+	shoppingID, err := api.AddShoppingWithType(now, "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	auditLog.now = func() time.Time { return now }
+	require.NoError(t, api.AddItem(shoppingID, "milk"))
+	require.NoError(t, api.AddItem(shoppingID, "bread"))
+
+	// the whole history is kept, only the last change can be undone
+	count, err := e.Audit.Query().Count(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	undone, err := auditLog.Undo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.True(t, undone)
+	undone, err = auditLog.Undo(sessionItem, KindShoplist)
+	require.NoError(t, err)
+	require.False(t, undone)
+
+	// records older than the retention are pruned
+	pruned, err := auditLog.Prune(now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+	count, err = e.Audit.Query().Count(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
@@ -6,7 +6,8 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
@@ -48,27 +49,35 @@ type Note struct {
 	Created    int64
 }
 
+// Storage keeps buget of the one community, getters check caller community,
+// writes publish events to the bus
 type Storage struct {
-	db     *sqlx.DB
-	dumper *helpers.Dumper
+	db        *sqlx.DB
+	bus       *events.Bus
+	community string
 }
 
-func NewStorage(dumpFn helpers.DumpFn) (Storage, error) {
+func NewStorage(bus *events.Bus) (Storage, error) {
 	bugetPath := viper.GetString("SHOPLIST-BOT_BUGETPATH")
 	db, err := sqlx.Connect("sqlite3", bugetPath)
 	if err != nil {
 		return Storage{}, err
 	}
 
-	dumper := helpers.NewDumper(dumpFn, nil)
-	dumper.Start()
-
 	return Storage{
-		db:     db,
-		dumper: dumper,
+		db:        db,
+		bus:       bus,
+		community: viper.GetString("SHOPLIST-BUDGET_COMMUNITY"),
 	}, nil
 }
 
+func (s Storage) checkCommunity(community string) error {
+	if s.community == "" || community != s.community {
+		return fmt.Errorf("buget belongs to other community: %w", consts.ErrForbidden)
+	}
+	return nil
+}
+
 func (s Storage) InsertBuget(ctx context.Context, title string) error {
 	q, args, err := squirrel.
 		Insert(bugetDB).
@@ -92,11 +101,17 @@ func (s Storage) InsertBuget(ctx context.Context, title string) error {
 		return err
 	}
 
-	s.dumper.ScheduleUpdate()
+	s.bus.Publish(events.BugetCreated{
+		Community: s.community,
+		Title:     title,
+	})
 	return nil
 }
 
-func (s Storage) GetBuget(ctx context.Context, ID int) (Buget, error) {
+func (s Storage) GetBuget(ctx context.Context, community string, ID int) (Buget, error) {
+	if err := s.checkCommunity(community); err != nil {
+		return Buget{}, err
+	}
 	q, args, err := squirrel.
 		Select("id", "title", "created").
 		From(bugetDB).
@@ -116,7 +131,10 @@ func (s Storage) GetBuget(ctx context.Context, ID int) (Buget, error) {
 	return buget, nil
 }
 
-func (s Storage) GetLastBugets(ctx context.Context, num uint64) ([]Buget, error) {
+func (s Storage) GetLastBugets(ctx context.Context, community string, num uint64) ([]Buget, error) {
+	if err := s.checkCommunity(community); err != nil {
+		return nil, err
+	}
 	q, args, err := squirrel.
 		Select("id", "title", "created").
 		From(bugetDB).
@@ -173,7 +191,13 @@ func (s Storage) InsertCategory(ctx context.Context, category Category) error {
 		return err
 	}
 
-	s.dumper.ScheduleUpdate()
+	s.bus.Publish(events.CategoryCreated{
+		Community: s.community,
+		BugetID:   category.BugetID,
+		Title:     category.Title,
+		Current:   category.Current,
+		Target:    category.Target,
+	})
 	return nil
 }
 
@@ -211,7 +235,13 @@ func (s Storage) InsertFund(ctx context.Context, category Category) error {
 		return err
 	}
 
-	s.dumper.ScheduleUpdate()
+	s.bus.Publish(events.CategoryCreated{
+		Community: s.community,
+		BugetID:   fundsBudgetID,
+		Title:     category.Title,
+		Current:   category.Current,
+		Fund:      true,
+	})
 	return nil
 }
 
@@ -240,7 +270,11 @@ func (s Storage) UpdateCategory(ctx context.Context, categoryID int, sum int) er
 		return err
 	}
 
-	s.dumper.ScheduleUpdate()
+	s.bus.Publish(events.CategoryUpdated{
+		Community:  s.community,
+		CategoryID: categoryID,
+		Current:    sum,
+	})
 	return nil
 }
 
@@ -248,7 +282,10 @@ func (s Storage) UpdateFund(ctx context.Context, categoryID int, sum int) error
 	return s.UpdateCategory(ctx, categoryID, sum)
 }
 
-func (s Storage) GetBugetCategories(ctx context.Context, bugetID int) ([]Category, error) {
+func (s Storage) GetBugetCategories(ctx context.Context, community string, bugetID int) ([]Category, error) {
+	if err := s.checkCommunity(community); err != nil {
+		return nil, err
+	}
 	q, args, err := squirrel.
 		Select("id", "buget_id", "title", "current", "target").
 		From(categoryDB).
@@ -278,7 +315,10 @@ func (s Storage) GetBugetCategories(ctx context.Context, bugetID int) ([]Categor
 	return categories, nil
 }
 
-func (s Storage) GetFunds(ctx context.Context) ([]Category, error) {
+func (s Storage) GetFunds(ctx context.Context, community string) ([]Category, error) {
+	if err := s.checkCommunity(community); err != nil {
+		return nil, err
+	}
 	q, args, err := squirrel.
 		Select("id", "buget_id", "title", "current").
 		From(categoryDB).
@@ -307,7 +347,22 @@ func (s Storage) GetFunds(ctx context.Context) ([]Category, error) {
 	return categories, nil
 }
 
-func (s Storage) GetCategory(ctx context.Context, ID int) (Category, error) {
+// GetCategory returns buget category, funds are not returned
+func (s Storage) GetCategory(ctx context.Context, community string, ID int) (Category, error) {
+	category, err := s.getCategory(ctx, community, ID)
+	if err != nil {
+		return Category{}, err
+	}
+	if category.BugetID == fundsBudgetID {
+		return Category{}, sql.ErrNoRows
+	}
+	return category, nil
+}
+
+func (s Storage) getCategory(ctx context.Context, community string, ID int) (Category, error) {
+	if err := s.checkCommunity(community); err != nil {
+		return Category{}, err
+	}
 	q, args, err := squirrel.
 		Select("id", "buget_id", "title", "current", "target").
 		From(categoryDB).
@@ -330,8 +385,16 @@ func (s Storage) GetCategory(ctx context.Context, ID int) (Category, error) {
 	return category, nil
 }
 
-func (s Storage) GetFund(ctx context.Context, ID int) (Category, error) {
-	return s.GetCategory(ctx, ID)
+// GetFund returns fund, it is the category of the funds buget
+func (s Storage) GetFund(ctx context.Context, community string, ID int) (Category, error) {
+	fund, err := s.getCategory(ctx, community, ID)
+	if err != nil {
+		return Category{}, err
+	}
+	if fund.BugetID != fundsBudgetID {
+		return Category{}, sql.ErrNoRows
+	}
+	return fund, nil
 }
 
 func (s Storage) InsertNote(ctx context.Context, note Note) error {
@@ -359,7 +422,11 @@ func (s Storage) InsertNote(ctx context.Context, note Note) error {
 		return err
 	}
 	defer func() { _ = tx.Rollback() }()
-	_, err = tx.Exec(q, args...)
+	res, err := tx.Exec(q, args...)
+	if err != nil {
+		return err
+	}
+	noteID, err := res.LastInsertId()
 	if err != nil {
 		return err
 	}
@@ -368,11 +435,90 @@ func (s Storage) InsertNote(ctx context.Context, note Note) error {
 		return err
 	}
 
-	s.dumper.ScheduleUpdate()
+	author, _ := events.AuthorFrom(ctx)
+	s.bus.Publish(events.NoteAdded{
+		Author:     author,
+		Community:  s.community,
+		NoteID:     int(noteID),
+		CategoryID: note.CategoryID,
+		Sum:        note.Sum,
+		Title:      note.Title,
+	})
 	return nil
 }
 
-func (s Storage) GetCategoryNotes(ctx context.Context, categoryID int) ([]Note, error) {
+// RevertNote removes the note and subtracts its sum from the category or the fund
+func (s Storage) RevertNote(ctx context.Context, community string, noteID int) error {
+	if err := s.checkCommunity(community); err != nil {
+		return err
+	}
+	q, args, err := squirrel.
+		Select("id", "category_id", "title", "sum", "created").
+		From(noteDB).
+		Where(squirrel.Eq{"id": noteID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, &txOptions)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	note := Note{}
+	err = tx.QueryRowContext(ctx, q, args...).Scan(
+		&note.ID, &note.CategoryID,
+		&note.Title, &note.Sum, &note.Created,
+	)
+	if err != nil {
+		return err
+	}
+
+	q, args, err = squirrel.
+		Delete(noteDB).
+		Where(squirrel.Eq{"id": noteID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+
+	q, args, err = squirrel.
+		Update(categoryDB).
+		Set("current", squirrel.Expr("current - ?", note.Sum)).
+		Where(squirrel.Eq{"id": note.CategoryID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.bus.Publish(events.NoteRemoved{
+		Community:  s.community,
+		NoteID:     note.ID,
+		CategoryID: note.CategoryID,
+		Sum:        note.Sum,
+	})
+	return nil
+}
+
+func (s Storage) GetCategoryNotes(ctx context.Context, community string, categoryID int) ([]Note, error) {
+	if err := s.checkCommunity(community); err != nil {
+		return nil, err
+	}
 	q, args, err := squirrel.
 		Select("id", "category_id", "title", "sum", "created").
 		From(noteDB).
@@ -401,7 +547,10 @@ func (s Storage) GetCategoryNotes(ctx context.Context, categoryID int) ([]Note,
 	return notes, nil
 }
 
-func (s Storage) GetBugetNotes(ctx context.Context, bugetID int) ([]Note, error) {
+func (s Storage) GetBugetNotes(ctx context.Context, community string, bugetID int) ([]Note, error) {
+	if err := s.checkCommunity(community); err != nil {
+		return nil, err
+	}
 
 	subQ := fmt.Sprintf("category_id in (select id from %s where buget_id=%d)", categoryDB, bugetID)
 
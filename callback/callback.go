@@ -0,0 +1,171 @@
+package callback
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	// MaxLen is the telegram limit of callback data in bytes
+	MaxLen = 64
+	// Version is the current format version, it is the first field of encoded data,
+	// as example: "1:checklist:sel:12:45"
+	Version = "1"
+
+	sep = ":"
+)
+
+// Operations which are common for the nodes
+const (
+	OpStart            = "start"
+	OpShow             = "show"
+	OpSelect           = "sel"
+	OpSelectAll        = "all"
+	OpDelete           = "del"
+	OpCopy             = "copy"
+	OpAddFromCurrent   = "fromcur"
+	OpAddFromChecklist = "fromcheck"
+	OpUndo             = "undo"
+	OpComplete         = "done"
+	OpClearCompleted   = "clear"
+	OpHideCompleted    = "hide"
+	OpSelectMode       = "selmode"
+	OpIncrease         = "inc"
+	OpDecrease         = "dec"
+	OpCategory         = "cat"
+	OpSetCategory      = "setcat"
+	OpQuickAdd         = "quick"
+	OpRestock          = "restock"
+)
+
+var (
+	// operation is a word
+	patternOp = regexp.MustCompile(`^[a-z]+$`)
+
+	ErrTooLong = errors.New("callback data is too long")
+	ErrInvalid = errors.New("invalid callback data")
+)
+
+// Data is the pressed button payload: node, its operation and operation arguments
+type Data struct {
+	Node string
+	Op   string
+	Args []string
+}
+
+func New(node, op string, args ...string) Data {
+	return Data{
+		Node: node,
+		Op:   op,
+		Args: args,
+	}
+}
+
+// Encode returns callback data for the button,
+// it fails if signed data doesn't fit telegram limit or fields contain separator
+func (d Data) Encode() (string, error) {
+	if err := d.validate(); err != nil {
+		return "", err
+	}
+
+	encoded := strings.Join([]string{Version, d.Node, d.Command()}, sep)
+	if len(encoded) > MaxLen-SignatureLen {
+		return "", fmt.Errorf("%w: %d bytes %q", ErrTooLong, len(encoded), encoded)
+	}
+	return encoded, nil
+}
+
+// MustEncode is like Encode but panics if data can't be encoded,
+// it is used for keyboards, which data is fully controlled by the bot
+func (d Data) MustEncode() string {
+	encoded, err := d.Encode()
+	if err != nil {
+		panic(fmt.Sprintf("callback: %v", err))
+	}
+	return encoded
+}
+
+// Command returns data without node, it is kept in the session as current data
+func (d Data) Command() string {
+	return strings.Join(append([]string{d.Op}, d.Args...), sep)
+}
+
+// Arg returns argument by index or empty string
+func (d Data) Arg(i int) string {
+	if i < 0 || i >= len(d.Args) {
+		return ""
+	}
+	return d.Args[i]
+}
+
+// IntArg returns numeric argument by index
+func (d Data) IntArg(i int) (int, error) {
+	if i < 0 || i >= len(d.Args) {
+		return 0, fmt.Errorf("%w: no argument %d in %q", ErrInvalid, i, d.Command())
+	}
+	value, err := strconv.Atoi(d.Args[i])
+	if err != nil {
+		return 0, fmt.Errorf("%w: argument %d in %q: %v", ErrInvalid, i, d.Command(), err)
+	}
+	return value, nil
+}
+
+func (d Data) validate() error {
+	if d.Node == "" {
+		return fmt.Errorf("%w: empty node", ErrInvalid)
+	}
+	if !patternOp.MatchString(d.Op) {
+		return fmt.Errorf("%w: operation %q", ErrInvalid, d.Op)
+	}
+	for _, field := range append([]string{d.Node, d.Op}, d.Args...) {
+		if strings.Contains(field, sep) {
+			return fmt.Errorf("%w: field %q contains %q", ErrInvalid, field, sep)
+		}
+	}
+	return nil
+}
+
+// Decode parses button callback data. Buttons of the previous format are not
+// signed, so they are rejected by the signer and are not decoded here.
+func Decode(data string) (Data, error) {
+	if len(data) > MaxLen {
+		return Data{}, fmt.Errorf("%w: %d bytes", ErrTooLong, len(data))
+	}
+	if !strings.HasPrefix(data, Version+sep) {
+		return Data{}, fmt.Errorf("%w: version of %q", ErrInvalid, data)
+	}
+
+	fields := strings.SplitN(strings.TrimPrefix(data, Version+sep), sep, 2)
+	if len(fields) != 2 {
+		return Data{}, fmt.Errorf("%w: %q", ErrInvalid, data)
+	}
+	return ParseCommand(fields[0], fields[1])
+}
+
+// ParseCommand parses node command which is returned by Command,
+// as example: "sel:12:45" or "start"
+func ParseCommand(node, command string) (Data, error) {
+	fields := strings.Split(command, sep)
+	d := Data{
+		Node: node,
+		Op:   fields[0],
+	}
+	if len(fields) > 1 {
+		d.Args = fields[1:]
+	}
+
+	if err := d.validate(); err != nil {
+		return Data{}, err
+	}
+	return d, nil
+}
+
+// Button returns inline keyboard button with encoded data
+func Button(text string, d Data) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(text, d.MustEncode())
+}
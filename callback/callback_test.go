@@ -0,0 +1,190 @@
+package callback_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		data   callback.Data
+		expStr string
+		expErr error
+	}{
+		{
+			name:   "start",
+			data:   callback.New("checklist", callback.OpStart),
+			expStr: "1:checklist:start",
+		},
+		{
+			name:   "select item",
+			data:   callback.New("shoppingitems", callback.OpSelect, "2147483647", "2147483647"),
+			expStr: "1:shoppingitems:sel:2147483647:2147483647",
+		},
+		{
+			name:   "too long",
+			data:   callback.New("shoppingitems", callback.OpSelect, strings.Repeat("1", 50)),
+			expErr: callback.ErrTooLong,
+		},
+		{
+			name:   "separator in argument",
+			data:   callback.New("calendar", callback.OpShow, "12:00"),
+			expErr: callback.ErrInvalid,
+		},
+		{
+			name:   "empty node",
+			data:   callback.New("", callback.OpStart),
+			expErr: callback.ErrInvalid,
+		},
+		{
+			name:   "not a word operation",
+			data:   callback.New("checklist", "12"),
+			expErr: callback.ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			str, err := test.data.Encode()
+			if test.expErr != nil {
+				require.True(t, errors.Is(err, test.expErr), err)
+				require.Panics(t, func() { test.data.MustEncode() })
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expStr, str)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		data    string
+		expData callback.Data
+		expErr  bool
+	}{
+		{data: "1:checklist:start", expData: callback.New("checklist", callback.OpStart)},
+		{data: "1:checklist:sel:12:45", expData: callback.New("checklist", callback.OpSelect, "12", "45")},
+		{data: "1:calendar:show:m2020-05", expData: callback.New("calendar", callback.OpShow, "m2020-05")},
+		// malformed
+		{data: "", expErr: true},
+		{data: "0", expErr: true},
+		// unsigned buttons of the previous format
+		{data: "checklist_start", expErr: true},
+		{data: "checklist_12i45", expErr: true},
+		{data: "checklist", expErr: true},
+		{data: "_start", expErr: true},
+		{data: "checklist_", expErr: true},
+		{data: "checklist_12?", expErr: true},
+		{data: "1:checklist", expErr: true},
+		{data: "1::start", expErr: true},
+		{data: "1:checklist:12", expErr: true},
+		{data: "1:checklist:sel:" + strings.Repeat("1", 64), expErr: true},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.data, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := callback.Decode(test.data)
+			if test.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expData, data)
+		})
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		node    string
+		command string
+		expData callback.Data
+		expErr  bool
+	}{
+		{node: "checklist", command: "sel:12:45", expData: callback.New("checklist", callback.OpSelect, "12", "45")},
+		{node: "checklist", command: "start", expData: callback.New("checklist", callback.OpStart)},
+		{node: "settings", command: "leave", expData: callback.New("settings", "leave")},
+		// malformed
+		{node: "", command: "start", expErr: true},
+		{node: "checklist", command: "", expErr: true},
+		{node: "checklist", command: "12?", expErr: true},
+		// previous format of the stored sessions
+		{node: "checklist", command: "12i45", expErr: true},
+		{node: "shoppingitems", command: "12^", expErr: true},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.node+"/"+test.command, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := callback.ParseCommand(test.node, test.command)
+			if test.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expData, data)
+		})
+	}
+}
+
+func TestIntArg(t *testing.T) {
+	data := callback.New("checklist", callback.OpSelect, "12", "x")
+
+	id, err := data.IntArg(0)
+	require.NoError(t, err)
+	require.Equal(t, 12, id)
+
+	_, err = data.IntArg(1)
+	require.True(t, errors.Is(err, callback.ErrInvalid))
+	_, err = data.IntArg(2)
+	require.True(t, errors.Is(err, callback.ErrInvalid))
+	require.Equal(t, "", data.Arg(2))
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, seed := range []string{
+		"1:checklist:start",
+		"1:checklist:sel:12:45",
+		"1:settings:leave",
+		"0",
+		"_",
+		"1:",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		data, err := callback.Decode(s)
+		if err != nil {
+			return
+		}
+
+		// decoded data is encoded to the same data
+		encoded, err := data.Encode()
+		if errors.Is(err, callback.ErrTooLong) {
+			return
+		}
+		require.NoError(t, err)
+
+		decoded, err := callback.Decode(encoded)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	})
+}
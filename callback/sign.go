@@ -0,0 +1,79 @@
+package callback
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	// signature is the truncated hmac of the user ID and the payload
+	signatureBytes = 8
+	// SignatureLen is the length of the signature field with separator,
+	// encoded data leaves room for it
+	SignatureLen = len(sep) + (signatureBytes*8+5)/6
+)
+
+var (
+	ErrBadSignature = errors.New("bad callback data signature")
+)
+
+// Signer binds callback data to the telegram user, so a user can't send
+// data which the bot has not sent to this user, e.g. with IDs of other community items
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key []byte) *Signer {
+	return &Signer{
+		key: key,
+	}
+}
+
+// Sign appends signature to the encoded data
+func (s *Signer) Sign(userID int64, data string) string {
+	return data + sep + s.signature(userID, data)
+}
+
+// Verify checks signature and returns data without it
+func (s *Signer) Verify(userID int64, signed string) (string, error) {
+	index := strings.LastIndex(signed, sep)
+	if index < 0 {
+		return "", ErrBadSignature
+	}
+	data, signature := signed[:index], signed[index+len(sep):]
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(userID, data))) {
+		return "", ErrBadSignature
+	}
+	return data, nil
+}
+
+// SignKeyboard signs callback data of all keyboard buttons
+func (s *Signer) SignKeyboard(userID int64, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	if keyboard == nil {
+		return
+	}
+	for _, row := range keyboard.InlineKeyboard {
+		for i := range row {
+			if row[i].CallbackData == nil {
+				continue
+			}
+			signed := s.Sign(userID, *row[i].CallbackData)
+			row[i].CallbackData = &signed
+		}
+	}
+}
+
+func (s *Signer) signature(userID int64, data string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(strconv.FormatInt(userID, 10)))
+	mac.Write([]byte(sep))
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureBytes])
+}
@@ -0,0 +1,59 @@
+package callback_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer := callback.NewSigner([]byte("secret"))
+	data := callback.New("shoppingitems", callback.OpSelect, "2147483647", "2147483647").MustEncode()
+
+	signed := signer.Sign(1, data)
+	require.True(t, len(signed) <= callback.MaxLen, signed)
+	require.Equal(t, len(data)+callback.SignatureLen, len(signed))
+
+	verified, err := signer.Verify(1, signed)
+	require.NoError(t, err)
+	require.Equal(t, data, verified)
+
+	// other user
+	_, err = signer.Verify(2, signed)
+	require.Equal(t, callback.ErrBadSignature, err)
+
+	// forged ID with the signature of other data
+	forged := strings.Replace(signed, "2147483647", "2147483646", 1)
+	_, err = signer.Verify(1, forged)
+	require.Equal(t, callback.ErrBadSignature, err)
+
+	// other key
+	_, err = callback.NewSigner([]byte("other")).Verify(1, signed)
+	require.Equal(t, callback.ErrBadSignature, err)
+
+	// unsigned data
+	_, err = signer.Verify(1, "checklist_12i45")
+	require.Equal(t, callback.ErrBadSignature, err)
+}
+
+func TestSignKeyboard(t *testing.T) {
+	signer := callback.NewSigner([]byte("secret"))
+	data := callback.New("checklist", callback.OpStart).MustEncode()
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("list", data),
+			tgbotapi.NewInlineKeyboardButtonURL("site", "https://example.com"),
+		),
+	)
+	signer.SignKeyboard(1, &keyboard)
+	signer.SignKeyboard(1, nil)
+
+	verified, err := signer.Verify(1, *keyboard.InlineKeyboard[0][0].CallbackData)
+	require.NoError(t, err)
+	require.Equal(t, data, verified)
+	require.Nil(t, keyboard.InlineKeyboard[0][1].CallbackData)
+}
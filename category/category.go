@@ -0,0 +1,114 @@
+// Package category assigns the default store aisle category to the product
+// by the keyword dictionary, e.g. "молоко" is in the dairy category.
+package category
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+)
+
+// Default is the category every community starts with
+type Default struct {
+	Key   string
+	Name  i18n.Key
+	Emoji string
+	// Keywords are the beginnings of the product name words
+	Keywords []string
+}
+
+// Defaults are the default categories in the aisle order
+var Defaults = []Default{
+	{
+		Key:   "vegetables",
+		Name:  i18n.CategoryVegetables,
+		Emoji: "🥦",
+		Keywords: []string{
+			"овощ", "фрукт", "картош", "картоф", "морков", "лук", "чеснок", "помидор", "томат",
+			"огур", "капуст", "перец", "свекл", "кабач", "яблок", "банан", "апельсин", "мандарин",
+			"лимон", "груш", "виноград", "зелен", "укроп", "петруш", "салат", "ягод",
+			"vegetable", "fruit", "potato", "carrot", "onion", "garlic", "tomato", "cucumber",
+			"cabbage", "pepper", "apple", "banana", "orange", "lemon", "pear", "grape", "lettuce",
+		},
+	},
+	{
+		Key:      "bakery",
+		Name:     i18n.CategoryBakery,
+		Emoji:    "🍞",
+		Keywords: []string{"хлеб", "батон", "булк", "булоч", "багет", "лаваш", "bread", "bun", "baguette", "loaf"},
+	},
+	{
+		Key:   "dairy",
+		Name:  i18n.CategoryDairy,
+		Emoji: "🥛",
+		Keywords: []string{
+			"молок", "кефир", "сметан", "творог", "сыр", "йогурт", "ряженк", "сливк", "яйц",
+			"milk", "kefir", "cheese", "yogurt", "yoghurt", "butter", "cream", "egg",
+		},
+	},
+	{
+		Key:   "meat",
+		Name:  i18n.CategoryMeat,
+		Emoji: "🥩",
+		Keywords: []string{
+			"мяс", "куриц", "курин", "говяд", "свинин", "индейк", "фарш", "колбас", "сосис",
+			"ветчин", "рыб", "лосос", "сельд", "креветк",
+			"meat", "chicken", "beef", "pork", "turkey", "sausage", "ham", "bacon", "fish", "salmon",
+		},
+	},
+	{
+		Key:   "grocery",
+		Name:  i18n.CategoryGrocery,
+		Emoji: "🥫",
+		Keywords: []string{
+			"круп", "рис", "гречк", "овсян", "макарон", "спагетти", "мук", "сахар", "соль", "чай",
+			"кофе", "масло", "консерв", "соус", "кетчуп", "майонез",
+			"rice", "pasta", "spaghetti", "flour", "sugar", "salt", "tea", "coffee", "oil", "sauce",
+		},
+	},
+	{
+		Key:      "drinks",
+		Name:     i18n.CategoryDrinks,
+		Emoji:    "🥤",
+		Keywords: []string{"вод", "сок", "пив", "вин", "лимонад", "газировк", "water", "juice", "beer", "wine", "soda"},
+	},
+	{
+		Key:      "sweets",
+		Name:     i18n.CategorySweets,
+		Emoji:    "🍫",
+		Keywords: []string{"шоколад", "конфет", "печень", "торт", "пирож", "мороже", "chocolate", "candy", "cookie", "cake", "ice"},
+	},
+	{
+		Key:   "household",
+		Name:  i18n.CategoryHousehold,
+		Emoji: "🧴",
+		Keywords: []string{
+			"порош", "мыл", "шампун", "гель", "средств", "губк", "бумаг", "салфет", "пакет", "фольг",
+			"soap", "shampoo", "detergent", "sponge", "paper", "napkin", "bags", "foil",
+		},
+	},
+}
+
+// Normalize returns the product name the categories are remembered by
+func Normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// Match returns key of the default category of the product or empty string,
+// words of the name are matched in order, the first matched word wins
+func Match(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for _, category := range Defaults {
+			for _, keyword := range category.Keywords {
+				if strings.HasPrefix(word, keyword) {
+					return category.Key
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package category
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"молоко", "dairy"},
+		{"Яйца", "dairy"},
+		{"картошка молодая", "vegetables"},
+		{"хлеб бородинский", "bakery"},
+		{"стиральный порошок", "household"},
+		{"Chicken wings", "meat"},
+		{"green tea", "grocery"},
+		{"лампочка", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, Match(tt.name), tt.name)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "молоко топлёное", Normalize("  Молоко   Топлёное "))
+}
@@ -0,0 +1,212 @@
+// Package console is the local text frontend, it drives logic without telegram
+package console
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	helpText = "Введите сообщение или номер кнопки, Ctrl+D для выхода"
+	prompt   = "> "
+)
+
+// HandleFunc processes one update
+type HandleFunc func(update tgbotapi.Update)
+
+// Console implements telegram.Sender, it prints messages as text with numbered
+// buttons and turns typed lines into updates of the single user
+type Console struct {
+	out    io.Writer
+	userID int
+
+	mu        sync.Mutex
+	messageID int
+	updateID  int
+	queryID   int
+	// buttons of the last printed keyboard and its message
+	buttons         []tgbotapi.InlineKeyboardButton
+	buttonMessageID int
+}
+
+func New(out io.Writer, userID int) *Console {
+	return &Console{
+		out:    out,
+		userID: userID,
+	}
+}
+
+// Run reads input lines until EOF, a line with the button number presses the button
+func (c *Console) Run(in io.Reader, handle HandleFunc) error {
+	fmt.Fprintln(c.out, helpText)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(c.out, prompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(c.out)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		handle(c.update(line))
+	}
+}
+
+// update returns callback update for the button number, message update otherwise
+func (c *Console) update(line string) tgbotapi.Update {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	from := &tgbotapi.User{ID: c.userID, FirstName: "console", UserName: "console"}
+	chat := &tgbotapi.Chat{ID: int64(c.userID), Type: "private"}
+
+	c.updateID++
+	update := tgbotapi.Update{UpdateID: c.updateID}
+
+	if number, err := strconv.Atoi(line); err == nil && number > 0 && number <= len(c.buttons) {
+		button := c.buttons[number-1]
+		c.queryID++
+		update.CallbackQuery = &tgbotapi.CallbackQuery{
+			ID:      strconv.Itoa(c.queryID),
+			From:    from,
+			Message: &tgbotapi.Message{MessageID: c.buttonMessageID, Chat: chat},
+			Data:    *button.CallbackData,
+		}
+		return update
+	}
+
+	c.messageID++
+	update.Message = &tgbotapi.Message{
+		MessageID: c.messageID,
+		From:      from,
+		Chat:      chat,
+		Text:      line,
+	}
+	return update
+}
+
+func (c *Console) Send(chattable tgbotapi.Chattable) (tgbotapi.Message, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch config := chattable.(type) {
+	case tgbotapi.MessageConfig:
+		c.messageID++
+		var keyboard *tgbotapi.InlineKeyboardMarkup
+		switch markup := config.ReplyMarkup.(type) {
+		case tgbotapi.InlineKeyboardMarkup:
+			keyboard = &markup
+		case *tgbotapi.InlineKeyboardMarkup:
+			keyboard = markup
+		}
+		c.print(config.ChatID, c.messageID, "", config.Text, keyboard)
+		return c.message(config.ChatID, c.messageID, config.Text), nil
+	case tgbotapi.EditMessageTextConfig:
+		c.print(config.ChatID, config.MessageID, "изменено", config.Text, config.ReplyMarkup)
+		return c.message(config.ChatID, config.MessageID, config.Text), nil
+	case tgbotapi.PhotoConfig:
+		c.messageID++
+		c.print(config.ChatID, c.messageID, "фото", config.Caption, nil)
+		return c.message(config.ChatID, c.messageID, config.Caption), nil
+	case tgbotapi.DocumentConfig:
+		c.messageID++
+		c.print(config.ChatID, c.messageID, "документ "+fileName(config.File), config.Caption, nil)
+		return c.message(config.ChatID, c.messageID, config.Caption), nil
+	default:
+		return tgbotapi.Message{}, fmt.Errorf("console: unsupported %T", chattable)
+	}
+}
+
+func (c *Console) AnswerCallbackQuery(config tgbotapi.CallbackConfig) (tgbotapi.APIResponse, error) {
+	if config.Text == "" {
+		return tgbotapi.APIResponse{Ok: true}, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kind := "уведомление"
+	if config.ShowAlert {
+		kind = "предупреждение"
+	}
+	fmt.Fprintf(c.out, "[%s] %s\n", kind, config.Text)
+	return tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func (c *Console) DeleteMessage(config tgbotapi.DeleteMessageConfig) (tgbotapi.APIResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintf(c.out, "[#%d удалено]\n", config.MessageID)
+	if config.MessageID == c.buttonMessageID {
+		c.buttons = nil
+	}
+	return tgbotapi.APIResponse{Ok: true}, nil
+}
+
+// print must be called with locked console, keyboard of the user chat
+// becomes the one the button numbers refer to
+func (c *Console) print(chatID int64, messageID int, note, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	header := fmt.Sprintf("#%d", messageID)
+	if note != "" {
+		header += " " + note
+	}
+	if chatID != int64(c.userID) {
+		header += fmt.Sprintf(" (чат %d)", chatID)
+	}
+	fmt.Fprintf(c.out, "\n[%s]\n%s\n", header, text)
+
+	if chatID != int64(c.userID) {
+		return
+	}
+	if keyboard == nil {
+		if messageID == c.buttonMessageID {
+			c.buttons = nil
+		}
+		return
+	}
+	c.buttons = nil
+	c.buttonMessageID = messageID
+	for _, row := range keyboard.InlineKeyboard {
+		texts := []string{}
+		for _, button := range row {
+			if button.CallbackData == nil {
+				continue
+			}
+			c.buttons = append(c.buttons, button)
+			texts = append(texts, fmt.Sprintf("[%d] %s", len(c.buttons), button.Text))
+		}
+		if len(texts) > 0 {
+			fmt.Fprintln(c.out, strings.Join(texts, "  "))
+		}
+	}
+}
+
+func (c *Console) message(chatID int64, messageID int, text string) tgbotapi.Message {
+	return tgbotapi.Message{
+		MessageID: messageID,
+		Chat:      &tgbotapi.Chat{ID: chatID, Type: "private"},
+		Text:      text,
+	}
+}
+
+func fileName(file interface{}) string {
+	switch f := file.(type) {
+	case tgbotapi.FileBytes:
+		return f.Name
+	case tgbotapi.FileReader:
+		return f.Name
+	case string:
+		return f
+	}
+	return ""
+}
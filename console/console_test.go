@@ -0,0 +1,49 @@
+package console_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/console"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsole(t *testing.T) {
+	out := &bytes.Buffer{}
+	c := console.New(out, 7)
+
+	updates := []tgbotapi.Update{}
+	handle := func(update tgbotapi.Update) {
+		updates = append(updates, update)
+		if update.Message == nil {
+			return
+		}
+		msg := tgbotapi.NewMessage(7, "menu")
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("first", "data1"),
+				tgbotapi.NewInlineKeyboardButtonData("second", "data2"),
+			),
+		)
+		_, err := c.Send(msg)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, c.Run(strings.NewReader("/start\n\n2\n3\n"), handle))
+	require.Len(t, updates, 3)
+
+	require.Equal(t, "/start", updates[0].Message.Text)
+
+	// number of the button presses it
+	require.NotNil(t, updates[1].CallbackQuery)
+	require.Equal(t, "data2", updates[1].CallbackQuery.Data)
+	require.Equal(t, 7, updates[1].CallbackQuery.From.ID)
+	require.Equal(t, 2, updates[1].CallbackQuery.Message.MessageID)
+
+	// there is no third button
+	require.Equal(t, "3", updates[2].Message.Text)
+
+	require.Contains(t, out.String(), "[1] first  [2] second")
+}
@@ -3,29 +3,19 @@ package consts
 import (
 	"errors"
 	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 )
 
 type ShoppingType int
-type OperationType string
 
 const (
 	ReadTimeout  = 15 * time.Second
 	WriteTimeout = 20 * time.Second
 
-	StartText      = "Время сессии истекло"
-	MenuText       = "Меню"
 	AfterStartText = "\xE2\x9C\x8C"
 
-	FirstPageStart     = "firstpage_start"
-	CalendarStart      = "calendar_start"
-	SettingsStart      = "settings_start"
-	ChecklistStart     = "checklist_start"
-	CurrentListStart   = "currentlist_start"
-	BugetStart         = "buget_start"
-	BugetCategoryStart = "bugetcategory_start"
-	FundsStart         = "funds_start"
-	IOTStart           = "iot_start"
-
+	FirstpageWord     = "firstpage"
 	CalendarWord      = "calendar"
 	DayshoppingsWord  = "dayshoppings"
 	ShoppingitemsWord = "shoppingitems"
@@ -38,32 +28,31 @@ const (
 	FundWord          = "fund"
 	IOTWord           = "iot"
 
-	Start = "start"
+	Start = callback.OpStart
 
 	DateLayout = "2006-01-02"
 
-	ListItemSymbol              = "i"
-	ListOperationLimit          = 3
-	ListStartRemoveSymbol       = "!"
-	ListStartCopySymbol         = "&"
-	ListStartSelectAllSymbol    = "*"
-	ListStartAddFromCurrentList = "^"
-	ListStartAddFromChecklist   = "#"
-
 	ShoppingTypeDefault     ShoppingType = 0
 	ShoppingTypeCheckList   ShoppingType = 1
 	ShoppingTypeCurrentList ShoppingType = 2
+)
 
-	TypeOperationDelete           OperationType = "delete"
-	TypeOperationCopy             OperationType = "copy"
-	TypeOperationSelectAll        OperationType = "selectAll"
-	TypeOperationAddFromCurrent   OperationType = "addFromCurrent"
-	TypeOperationAddFromChecklist OperationType = "addFromChecklist"
+var (
+	FirstPageStart     = callback.New(FirstpageWord, Start).MustEncode()
+	CalendarStart      = callback.New(CalendarWord, Start).MustEncode()
+	SettingsStart      = callback.New(SettingsWord, Start).MustEncode()
+	ChecklistStart     = callback.New(ChecklistWord, Start).MustEncode()
+	CurrentListStart   = callback.New(CurrentlistWord, Start).MustEncode()
+	BugetStart         = callback.New(BugetWord, Start).MustEncode()
+	BugetCategoryStart = callback.New(BugetCategoryWord, Start).MustEncode()
+	FundsStart         = callback.New(FundsWord, Start).MustEncode()
+	IOTStart           = callback.New(IOTWord, Start).MustEncode()
 )
 
 var (
 	ErrUnknownCommand = errors.New("unknown command")
 	ErrNotFound       = errors.New("not found")
+	ErrForbidden      = errors.New("forbidden")
 
 	ErrGetUsersBadStatus = errors.New("bad status get user response")
 )
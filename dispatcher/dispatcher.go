@@ -0,0 +1,187 @@
+package dispatcher
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	DefaultMaxWorkers = 16
+	DefaultQueueLen   = 32
+	// DefaultWait is the time the callback waits for the room in the full queue
+	DefaultWait = time.Second * 5
+)
+
+// HandleFunc processes one update
+type HandleFunc func(update tgbotapi.Update)
+
+type queue struct {
+	updates chan tgbotapi.Update
+	pending int
+}
+
+// Dispatcher keeps updates of each user in order on their own queue,
+// different users are processed in parallel by limited number of workers
+type Dispatcher struct {
+	handle   HandleFunc
+	busy     HandleFunc
+	queueLen int
+	wait     time.Duration
+	workers  chan struct{}
+
+	mu     sync.Mutex
+	queues map[int]*queue
+	wg     sync.WaitGroup
+}
+
+// New returns dispatcher, zero maxWorkers and queueLen mean defaults
+func New(handle HandleFunc, maxWorkers, queueLen int) *Dispatcher {
+	if maxWorkers <= 0 {
+		maxWorkers = DefaultMaxWorkers
+	}
+	if queueLen <= 0 {
+		queueLen = DefaultQueueLen
+	}
+
+	return &Dispatcher{
+		handle:   handle,
+		queueLen: queueLen,
+		wait:     DefaultWait,
+		workers:  make(chan struct{}, maxWorkers),
+		queues:   map[int]*queue{},
+	}
+}
+
+// SetBusyFunc sets function which replies to the update dropped because
+// the queue of its user is full, e.g. answers the callback query
+func (d *Dispatcher) SetBusyFunc(busy HandleFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.busy = busy
+}
+
+// SetWait sets the time the callback waits for the room in the full queue,
+// zero wait means DefaultWait
+func (d *Dispatcher) SetWait(wait time.Duration) {
+	if wait <= 0 {
+		wait = DefaultWait
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.wait = wait
+}
+
+// Dispatch puts update to the queue of its user. Message is dropped if the
+// user queue is full, so one flooding user doesn't stall updates of the others.
+// Callback changes the keyboard the user sees, so it waits for the room up to
+// the wait time and only then is dropped.
+func (d *Dispatcher) Dispatch(update tgbotapi.Update) {
+	userID := UserID(update)
+
+	d.mu.Lock()
+	q, ok := d.queues[userID]
+	if !ok {
+		q = &queue{
+			updates: make(chan tgbotapi.Update, d.queueLen),
+		}
+		d.queues[userID] = q
+		d.wg.Add(1)
+		go d.work(userID, q)
+	}
+	select {
+	case q.updates <- update:
+		q.pending++
+		d.mu.Unlock()
+		return
+	default:
+	}
+	busy, wait := d.busy, d.wait
+	if update.CallbackQuery != nil {
+		// pending update keeps the worker of the queue
+		q.pending++
+	}
+	d.mu.Unlock()
+
+	if update.CallbackQuery != nil && d.waitRoom(q, update, wait) {
+		return
+	}
+
+	log.Printf("dispatcher: queue of user %d is full, update %d is dropped", userID, update.UpdateID)
+	if busy != nil {
+		busy(update)
+	}
+}
+
+// waitRoom puts the update counted as pending to the queue, it returns false
+// if the queue is still full after the wait
+func (d *Dispatcher) waitRoom(q *queue, update tgbotapi.Update, wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case q.updates <- update:
+		return true
+	case <-timer.C:
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// the worker may have emptied the queue right at the timeout
+	select {
+	case q.updates <- update:
+		return true
+	default:
+	}
+	// the queue is full, so the worker has other updates to handle
+	q.pending--
+	return false
+}
+
+// Wait blocks until all dispatched updates are processed
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// work handles user updates one by one and exits when the queue is empty
+func (d *Dispatcher) work(userID int, q *queue) {
+	defer d.wg.Done()
+
+	d.workers <- struct{}{}
+	defer func() { <-d.workers }()
+
+	for {
+		update := <-q.updates
+		d.handleSafe(update)
+
+		d.mu.Lock()
+		q.pending--
+		if q.pending == 0 {
+			delete(d.queues, userID)
+			d.mu.Unlock()
+			return
+		}
+		d.mu.Unlock()
+	}
+}
+
+func (d *Dispatcher) handleSafe(update tgbotapi.Update) {
+	defer func() {
+		if v := recover(); v != nil {
+			log.Printf("dispatcher: update %d panic: %v", update.UpdateID, v)
+		}
+	}()
+	d.handle(update)
+}
+
+// UserID returns telegram ID of the update sender
+func UserID(update tgbotapi.Update) int {
+	switch {
+	case update.CallbackQuery != nil && update.CallbackQuery.From != nil:
+		return update.CallbackQuery.From.ID
+	case update.Message != nil && update.Message.From != nil:
+		return update.Message.From.ID
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+package dispatcher_test
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/dispatcher"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+const testNode = "checklist"
+
+func newCallback(updateID, userID int, data string) tgbotapi.Update {
+	return tgbotapi.Update{
+		UpdateID: updateID,
+		CallbackQuery: &tgbotapi.CallbackQuery{
+			From: &tgbotapi.User{ID: userID},
+			Data: data,
+		},
+	}
+}
+
+func newMessage(updateID, userID int, text string) tgbotapi.Update {
+	return tgbotapi.Update{
+		UpdateID: updateID,
+		Message: &tgbotapi.Message{
+			From: &tgbotapi.User{ID: userID},
+			Text: text,
+		},
+	}
+}
+
+// toggle applies list item callback to the session like list nodes do
+func toggle(item *session.SessionItem, update tgbotapi.Update) {
+	itemID, _ := strconv.Atoi(update.CallbackQuery.Data)
+	item.State(testNode).ToggleSelected(itemID)
+	item.CurrentData = update.CallbackQuery.Data
+	item.LastMsgID = update.UpdateID
+}
+
+func TestDispatcherUserOrder(t *testing.T) {
+	const (
+		users     = 5
+		callbacks = 50
+	)
+
+	// unsynchronized sessions, only one worker of the user may touch it
+	sessions := map[int]*session.SessionItem{}
+	expected := map[int]*session.SessionItem{}
+	for userID := 1; userID <= users; userID++ {
+		sessions[userID] = &session.SessionItem{}
+		expected[userID] = &session.SessionItem{}
+	}
+
+	d := dispatcher.New(func(update tgbotapi.Update) {
+		time.Sleep(time.Duration(rand.Intn(300)) * time.Microsecond)
+		toggle(sessions[dispatcher.UserID(update)], update)
+	}, 3, callbacks)
+
+	updateID := 0
+	for i := 0; i < callbacks; i++ {
+		for userID := 1; userID <= users; userID++ {
+			updateID++
+			update := newCallback(updateID, userID, strconv.Itoa(rand.Intn(5)))
+			toggle(expected[userID], update)
+			d.Dispatch(update)
+		}
+	}
+	d.Wait()
+
+	for userID := 1; userID <= users; userID++ {
+		require.Equal(t, expected[userID].State(testNode).Selected, sessions[userID].State(testNode).Selected)
+		require.Equal(t, expected[userID].CurrentData, sessions[userID].CurrentData)
+		require.Equal(t, expected[userID].LastMsgID, sessions[userID].LastMsgID)
+	}
+}
+
+func TestDispatcherMaxWorkers(t *testing.T) {
+	const maxWorkers = 2
+
+	var (
+		inFlight, maxInFlight int32
+		mu                    sync.Mutex
+		handled               = map[int]int{}
+	)
+
+	d := dispatcher.New(func(update tgbotapi.Update) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if cur <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		handled[dispatcher.UserID(update)]++
+		mu.Unlock()
+	}, maxWorkers, 10)
+
+	for i := 0; i < 10; i++ {
+		for userID := 1; userID <= 6; userID++ {
+			d.Dispatch(newCallback(i, userID, "1"))
+		}
+	}
+	d.Wait()
+
+	require.LessOrEqual(t, maxInFlight, int32(maxWorkers))
+	require.Len(t, handled, 6)
+	for _, count := range handled {
+		require.Equal(t, 10, count)
+	}
+}
+
+func TestDispatcherRecover(t *testing.T) {
+	var handled int32
+	d := dispatcher.New(func(update tgbotapi.Update) {
+		if update.UpdateID == 1 {
+			panic("bad update")
+		}
+		atomic.AddInt32(&handled, 1)
+	}, 0, 0)
+
+	d.Dispatch(newCallback(1, 1, "1"))
+	d.Dispatch(newCallback(2, 1, "1"))
+	d.Wait()
+
+	require.Equal(t, int32(1), handled)
+}
+
+func TestDispatcherBusy(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled int32
+	d := dispatcher.New(func(update tgbotapi.Update) {
+		if update.UpdateID == 1 {
+			close(started)
+			<-release
+		}
+		atomic.AddInt32(&handled, 1)
+	}, 0, 1)
+	d.SetWait(time.Millisecond * 50)
+	var mu sync.Mutex
+	busy := []int{}
+	d.SetBusyFunc(func(update tgbotapi.Update) {
+		mu.Lock()
+		defer mu.Unlock()
+		busy = append(busy, update.UpdateID)
+	})
+	busyIDs := func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int{}, busy...)
+	}
+
+	// the first update is handled, the second one waits in the full queue,
+	// the callback is dropped after the wait and the message at once
+	d.Dispatch(newCallback(1, 1, "1"))
+	<-started
+	d.Dispatch(newCallback(2, 1, "1"))
+	d.Dispatch(newCallback(3, 1, "1"))
+	require.Equal(t, []int{3}, busyIDs())
+	d.Dispatch(newMessage(4, 1, "milk"))
+	require.Equal(t, []int{3, 4}, busyIDs())
+
+	// other users are not stalled by the full queue
+	done := make(chan struct{})
+	go func() {
+		d.Dispatch(newCallback(5, 2, "1"))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatch of the other user is blocked")
+	}
+
+	// the callback waits for the room in the queue
+	d.SetWait(time.Second * 5)
+	done = make(chan struct{})
+	go func() {
+		d.Dispatch(newCallback(6, 1, "1"))
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("callback is not waiting for the room")
+	case <-time.After(time.Millisecond * 20):
+	}
+	close(release)
+	<-done
+	d.Wait()
+	require.Equal(t, int32(4), atomic.LoadInt32(&handled))
+	require.Equal(t, []int{3, 4}, busyIDs())
+}
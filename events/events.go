@@ -0,0 +1,236 @@
+// Package events is the in-process bus of the domain events, write paths
+// publish events and side effects subscribe to them
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Event is the domain event, name is used e.g. as metrics label
+type Event interface {
+	Name() string
+}
+
+// Handler handles published events, it skips events of other types
+type Handler func(event Event)
+
+// Bus calls subscribers synchronously in order of subscription,
+// nil bus drops events
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// Author is the user who made the change
+type Author struct {
+	// UserID is the ent ID of the user
+	UserID     int
+	TelegramID int64
+	UserName   string
+	ComunityID string
+}
+
+type authorKey struct{}
+
+// WithAuthor returns context with the author of the changes, it is used by
+// the storages which don't know the user
+func WithAuthor(ctx context.Context, author Author) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// AuthorFrom returns the author of the changes from the context
+func AuthorFrom(ctx context.Context) (Author, bool) {
+	author, ok := ctx.Value(authorKey{}).(Author)
+	return author, ok
+}
+
+// ItemAdded is published after the items are added to the shopping
+type ItemAdded struct {
+	Author
+	ShoppingID int
+	Shop       string
+	Date       time.Time
+	ItemIDs    []int
+	Names      []string
+}
+
+func (ItemAdded) Name() string { return "item_added" }
+
+// ItemsRemoved is published for every shopping the items are removed from
+type ItemsRemoved struct {
+	Author
+	ShoppingID int
+	ItemIDs    []int
+	// Items are the fields of the removed items to restore them
+	Items []RemovedItem
+}
+
+// RemovedItem is the removed item as it was stored
+type RemovedItem struct {
+	ProductName string
+	Quantity    int
+	Unit        string
+	CategoryID  int
+	Complete    bool
+}
+
+func (ItemsRemoved) Name() string { return "items_removed" }
+
+// ItemsCopied is published after the items are copied to the shopping in one
+// batch, moved items are removed from their shopping in the same batch
+type ItemsCopied struct {
+	Author
+	ShoppingID int
+	Shop       string
+	Date       time.Time
+	// ItemIDs are the new items, Names are all the copied ones
+	ItemIDs []int
+	Names   []string
+	// Changed are the items the copied quantities are summed to
+	Changed []QuantityChange
+	// FromShoppingID and Removed are set if the items are moved
+	FromShoppingID int
+	Removed        []RemovedItem
+}
+
+// QuantityChange is the item with its quantity before the change
+type QuantityChange struct {
+	ItemID   int
+	Previous int
+}
+
+func (ItemsCopied) Name() string { return "items_copied" }
+
+// ItemCompleted is published after the item is marked as bought or not bought
+type ItemCompleted struct {
+	Author
+	ShoppingID  int
+	ItemID      int
+	ProductName string
+	Complete    bool
+}
+
+func (ItemCompleted) Name() string { return "item_completed" }
+
+// ItemQuantityChanged is published after the quantity of the item is changed,
+// e.g. the same item is added again
+type ItemQuantityChanged struct {
+	Author
+	ShoppingID  int
+	ItemID      int
+	ProductName string
+	Quantity    int
+	Previous    int
+}
+
+func (ItemQuantityChanged) Name() string { return "item_quantity_changed" }
+
+// ItemCategoryChanged is published after the category of the item is chosen
+type ItemCategoryChanged struct {
+	Author
+	ShoppingID  int
+	ItemID      int
+	ProductName string
+	CategoryID  int
+	Previous    int
+}
+
+func (ItemCategoryChanged) Name() string { return "item_category_changed" }
+
+// ShoppingCreated is published after the shopping is created,
+// special shoppings are created with their types
+type ShoppingCreated struct {
+	Author
+	ShoppingID int
+	Shop       string
+	Date       time.Time
+	Type       int
+}
+
+func (ShoppingCreated) Name() string { return "shopping_created" }
+
+// CommunityJoined is published after the user changes community,
+// leaving the group joins the new own community
+type CommunityJoined struct {
+	Author
+}
+
+func (CommunityJoined) Name() string { return "community_joined" }
+
+// BugetCreated is published after the buget is created
+type BugetCreated struct {
+	Community string
+	Title     string
+}
+
+func (BugetCreated) Name() string { return "buget_created" }
+
+// CategoryCreated is published after the buget category or the fund is created
+type CategoryCreated struct {
+	Community string
+	BugetID   int
+	Title     string
+	Current   int64
+	Target    int64
+	Fund      bool
+}
+
+func (CategoryCreated) Name() string { return "category_created" }
+
+// CategoryUpdated is published after the current sum of the category or the fund is changed
+type CategoryUpdated struct {
+	Community  string
+	CategoryID int
+	Current    int
+}
+
+func (CategoryUpdated) Name() string { return "category_updated" }
+
+// NoteAdded is published after the spending note is added to the category
+type NoteAdded struct {
+	// Author is empty if the context of the change has no author
+	Author
+	Community  string
+	NoteID     int
+	CategoryID int
+	Sum        int
+	Title      string
+}
+
+func (NoteAdded) Name() string { return "note_added" }
+
+// NoteRemoved is published after the note is removed and its sum is
+// subtracted from the category
+type NoteRemoved struct {
+	Community  string
+	NoteID     int
+	CategoryID int
+	Sum        int
+}
+
+func (NoteRemoved) Name() string { return "note_removed" }
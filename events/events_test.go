@@ -0,0 +1,29 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	bus := events.NewBus()
+	names := []string{}
+	bus.Subscribe(func(event events.Event) {
+		names = append(names, "first "+event.Name())
+	})
+	bus.Subscribe(func(event events.Event) {
+		if _, ok := event.(events.NoteAdded); ok {
+			names = append(names, "second "+event.Name())
+		}
+	})
+
+	bus.Publish(events.NoteAdded{Sum: 100})
+	bus.Publish(events.BugetCreated{Title: "June"})
+	require.Equal(t, []string{"first note_added", "second note_added", "first buget_created"}, names)
+
+	// nil bus drops events
+	var noBus *events.Bus
+	noBus.Publish(events.NoteAdded{})
+}
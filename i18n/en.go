@@ -0,0 +1,129 @@
+package i18n
+
+var en = map[Key]string{
+	MenuButton:     "⬅ Menu",
+	BackButton:     "⬅ Back",
+	RemoveButton:   "Delete",
+	SessionExpired: "Session has expired",
+	ReopenButton:   "↻ Open again",
+	InvalidButton:  "The button is outdated, open the menu: /start",
+	InternalError:  "Something went wrong. Please try again.",
+	Busy:           "Too many requests, please wait a moment.",
+	NotFound:       "Not found, maybe it has been deleted.",
+	AccessDenied:   "Access denied.",
+	RetryButton:    "🔄 Retry",
+	UserAddedItem:  "User %s(%v) added '%s' to '%s'(%s)",
+	UserAddedItems: "User %s(%v) added %d items to '%s'(%s): %s",
+	MoreItems:      "and %d more",
+	LanguageName:   "English",
+	UndoButton:     "↶ Undo",
+	Undone:         "Undone",
+	NothingToUndo:  "Nothing to undo",
+
+	CategoryVegetables: "Fruit and vegetables",
+	CategoryBakery:     "Bakery",
+	CategoryDairy:      "Dairy",
+	CategoryMeat:       "Meat and fish",
+	CategoryGrocery:    "Grocery",
+	CategoryDrinks:     "Drinks",
+	CategorySweets:     "Sweets",
+	CategoryHousehold:  "Household",
+	ChooseCategory:     "Choose the category of «%s»",
+	NoCategoryButton:   "No category",
+	SearchFound:        "Found for «%s»:",
+	SearchNotFound:     "Nothing found for «%s»",
+	RestockTitle:       "💡 Time to buy: %s",
+
+	monthNames:   "January,February,March,April,May,June,July,August,September,October,November,December",
+	weekdayNames: "MO,TU,WE,TH,FR,SA,SU",
+
+	HelpTitle:        "Available commands:",
+	UnknownCommand:   "Unknown command /%s.",
+	CommandHelp:      "Command list",
+	CommandStart:     "Main menu",
+	CommandList:      "Current list",
+	CommandAdd:       "Add item to the current list: /add milk",
+	CommandChecklist: "Checklist",
+	CommandBudget:    "Budget",
+
+	FirstPageTitle:    "Main menu",
+	CurrentListButton: "Current list",
+	ChecklistButton:   "Checklist",
+	SettingsButton:    "Settings",
+	CalendarButton:    "Calendar",
+	BudgetButton:      "Budget",
+	FundsButton:       "Funds",
+
+	ChecklistInput:      "Checklist. Enter an item to add",
+	ChecklistEmpty:      "Checklist is empty yet. Enter an item name to add it.",
+	SelectAllButton:     "Select all",
+	CopyToCurrentButton: "To current list",
+	ItemsCopied:         "Items are copied.",
+	NoNewItems:          "No new items to add. ",
+	CurrentListInput:    "Current list. Enter an item to add",
+	CurrentListEmpty:    "Current list is empty yet. Enter an item name to add it.",
+
+	CalendarTitle:          "Calendar",
+	ToCalendarButton:       "⬅ Back to calendar",
+	DayShoppingsTitle:      "Shoppings of the day. Enter a place name to add one.",
+	DayShoppingsEmpty:      "No shoppings this day. Enter a place name to add one.",
+	BackToButton:           "⬅ Back to ",
+	ItemsInput:             "Enter an item to add",
+	ItemsEmpty:             "Item list is empty yet. Enter an item name to add it.",
+	AddFromCurrentButton:   "↑ from current",
+	AddFromChecklistButton: "↑ from checklist",
+	RemoveSelectedButton:   "⊗ selected",
+	SelectItemsInput:       "Select items to delete",
+	SelectModeButton:       "☑ Select",
+	CancelSelectButton:     "✖ Cancel selection",
+	HideCompletedButton:    "🙈 Hide bought",
+	ShowCompletedButton:    "👁 Bought (%d)",
+	ClearCompletedButton:   "🧹 Clear bought",
+
+	SettingsInfo:     "Bot version: \n%sCurrent user ID: \"%v\".",
+	NoCommunity:      "You are not in a group, enter a member ID to join the group",
+	InYourGroup:      "Your group: %s, you can make shared shopping lists",
+	NoUsername:       "no username",
+	LeaveGroupButton: "Leave group",
+	LeaveSuccess:     "<You have left the group>",
+	AlreadyInGroup:   "<You are already in a group>",
+	InvalidUserID:    "<Member ID must consist of digits>",
+	UserNotFound:     "<Member not found. Check the ID>",
+	JoinGroupSuccess: "<You have joined the group>",
+	LanguageButton:   "🌐 %s",
+	LanguageChanged:  "<Language is changed>",
+
+	NotifyInfo:            "Group notifications: %s",
+	NotifyImmediate:       "immediate",
+	NotifyDigestEvery:     "digest every %d min",
+	NotifyDigestAt:        "digest at %s",
+	NotifyOff:             "off",
+	NotifyImmediateButton: "🔔 Immediate",
+	NotifyDigestButton:    "🗞 Digest",
+	NotifyOffButton:       "🔕 Off",
+	DigestMinutesButton:   "%d min",
+	DigestTimeHint:        "Enter time for the daily digest, e.g. 20:00, or time with your time zone, e.g. 20:00 Europe/London",
+	InvalidDigestTime:     "<Time must be in HH:MM format>",
+	InvalidDigestZone:     "<Unknown time zone, e.g. Europe/London>",
+	NotifyChanged:         "<Notifications are changed>",
+	DigestTitle:           "🗞 Added to the lists:",
+	DigestShopping:        "%s (%s):",
+	DigestItem:            "• %s — %s",
+
+	BudgetText: `Budget: '%s', spent: %d%%, left %d
+	Add a category: "25000 groceries"
+	Add a budget: "!June"`,
+	BudgetCategoryButton: "%d. %s (%d%%), left: %d",
+	EmptyCategories:      "No categories to show",
+	CategoryText:         "Category: %s spent %d%% (%d/%d):\n",
+	CategoryNote:         "%d) %s -> %d - %s\n",
+	NoFunds:              "No money left in the category!",
+	Overspend:            "🤬 Slow down! Overspent by %d day|🤬 Slow down! Overspent by %d days",
+	FundsText: `Virtual funds, total: %d,
+	Add a fund: "25000 gifts fund"`,
+	FundButton:  "%d. %s, left: %d",
+	EmptyFunds:  "No funds to show",
+	FundText:    "Fund: %s balance (%d):\n",
+	FundNote:    "%d) %s -> %s%d - %s\n",
+	IOTNoValues: "No new values",
+}
@@ -0,0 +1,131 @@
+// Package i18n is the message catalog of the bot texts
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lang is the language of the bundle
+type Lang string
+
+// Key is the message key in the bundles
+type Key string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	// Default is used for the users without telegram language
+	Default = RU
+
+	// pluralSep separates plural forms of the message
+	pluralSep = "|"
+)
+
+var (
+	// Langs are the languages with bundles, the first is the default one
+	Langs = []Lang{RU, EN}
+
+	bundles = map[Lang]map[Key]string{
+		RU: ru,
+		EN: en,
+	}
+)
+
+// T returns message of the language, args format it.
+// Missing message is taken from the default bundle.
+func T(lang Lang, key Key, args ...interface{}) string {
+	text := lookup(lang, key)
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// N returns plural form of the message for the count,
+// the count is the first format arg
+func N(lang Lang, key Key, count int64, args ...interface{}) string {
+	forms := strings.Split(lookup(lang, key), pluralSep)
+
+	form := pluralForm(lang, count)
+	if form >= len(forms) {
+		form = len(forms) - 1
+	}
+	return fmt.Sprintf(forms[form], append([]interface{}{count}, args...)...)
+}
+
+// Month returns month name
+func Month(lang Lang, month time.Month) string {
+	names := strings.Split(lookup(lang, monthNames), ",")
+	if month < time.January || int(month) > len(names) {
+		return month.String()
+	}
+	return names[month-1]
+}
+
+// Weekdays returns short names of the days of week, from monday
+func Weekdays(lang Lang) []string {
+	return strings.Split(lookup(lang, weekdayNames), ",")
+}
+
+// Parse returns language with the bundle by its code
+func Parse(code string) (Lang, bool) {
+	for _, lang := range Langs {
+		if string(lang) == code {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Pick returns the chosen language if it is set, otherwise the language
+// by telegram language_code, e.g. "ru" or "en-US"
+func Pick(chosen, telegramCode string) Lang {
+	if lang, ok := Parse(chosen); ok {
+		return lang
+	}
+	if telegramCode == "" {
+		return Default
+	}
+	code := strings.ToLower(strings.SplitN(telegramCode, "-", 2)[0])
+	if lang, ok := Parse(code); ok {
+		return lang
+	}
+	return EN
+}
+
+func lookup(lang Lang, key Key) string {
+	if text, ok := bundles[lang][key]; ok {
+		return text
+	}
+	if text, ok := bundles[Default][key]; ok {
+		return text
+	}
+	return string(key)
+}
+
+// pluralForm returns the index of the plural form: russian has one, few and many
+// forms, english has one and other
+func pluralForm(lang Lang, count int64) int {
+	if count < 0 {
+		count = -count
+	}
+	switch lang {
+	case RU:
+		switch {
+		case count%10 == 1 && count%100 != 11:
+			return 0
+		case count%10 >= 2 && count%10 <= 4 && (count%100 < 12 || count%100 > 14):
+			return 1
+		default:
+			return 2
+		}
+	default:
+		if count == 1 {
+			return 0
+		}
+		return 1
+	}
+}
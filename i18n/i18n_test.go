@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundles(t *testing.T) {
+	t.Parallel()
+
+	for _, lang := range Langs {
+		for key, text := range bundles[Default] {
+			translated, ok := bundles[lang][key]
+			require.True(t, ok, "%s: %s is missing", lang, key)
+			// plural forms count differs, so args of the first form are compared
+			require.Equal(t, formatArgs(text), formatArgs(translated),
+				"%s: %s has other format args", lang, key)
+		}
+		require.Len(t, bundles[lang], len(bundles[Default]), "%s has unknown keys", lang)
+	}
+}
+
+func formatArgs(text string) int {
+	return strings.Count(strings.Split(text, pluralSep)[0], "%")
+}
+
+func TestN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lang  Lang
+		count int64
+		exp   string
+	}{
+		{lang: RU, count: 1, exp: "🤬 Тормозни! Перерасход на 1 день"},
+		{lang: RU, count: 4, exp: "🤬 Тормозни! Перерасход на 4 дня"},
+		{lang: RU, count: 5, exp: "🤬 Тормозни! Перерасход на 5 дней"},
+		{lang: RU, count: 11, exp: "🤬 Тормозни! Перерасход на 11 дней"},
+		{lang: RU, count: 21, exp: "🤬 Тормозни! Перерасход на 21 день"},
+		{lang: RU, count: 22, exp: "🤬 Тормозни! Перерасход на 22 дня"},
+		{lang: EN, count: 1, exp: "🤬 Slow down! Overspent by 1 day"},
+		{lang: EN, count: 3, exp: "🤬 Slow down! Overspent by 3 days"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.exp, N(test.lang, Overspend, test.count))
+	}
+}
+
+func TestPick(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		chosen, code string
+		exp          Lang
+	}{
+		{name: "chosen", chosen: "en", code: "ru", exp: EN},
+		{name: "telegram", code: "en-US", exp: EN},
+		{name: "no language", exp: Default},
+		{name: "unknown", code: "de", exp: EN},
+		{name: "unknown chosen", chosen: "de", code: "ru", exp: RU},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.exp, Pick(test.chosen, test.code))
+		})
+	}
+}
+
+func TestMonth(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "Март", Month(RU, time.March))
+	require.Equal(t, "March", Month(EN, time.March))
+	require.Len(t, Weekdays(EN), 7)
+	require.Equal(t, "⬅ Меню", T("", MenuButton))
+}
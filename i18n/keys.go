@@ -0,0 +1,148 @@
+package i18n
+
+// common
+const (
+	MenuButton     Key = "menu.button"
+	BackButton     Key = "back.button"
+	RemoveButton   Key = "remove.button"
+	SessionExpired Key = "session.expired"
+	ReopenButton   Key = "reopen.button"
+	InvalidButton  Key = "invalid.button"
+	InternalError  Key = "error.internal"
+	Busy           Key = "error.busy"
+	NotFound       Key = "error.notfound"
+	AccessDenied   Key = "error.forbidden"
+	RetryButton    Key = "retry.button"
+	UserAddedItem  Key = "user.added.item"
+	UserAddedItems Key = "user.added.items"
+	MoreItems      Key = "more.items"
+	LanguageName   Key = "language.name"
+	UndoButton     Key = "undo.button"
+	Undone         Key = "undo.done"
+	NothingToUndo  Key = "undo.nothing"
+
+	CategoryVegetables Key = "category.vegetables"
+	CategoryBakery     Key = "category.bakery"
+	CategoryDairy      Key = "category.dairy"
+	CategoryMeat       Key = "category.meat"
+	CategoryGrocery    Key = "category.grocery"
+	CategoryDrinks     Key = "category.drinks"
+	CategorySweets     Key = "category.sweets"
+	CategoryHousehold  Key = "category.household"
+	ChooseCategory     Key = "category.choose"
+	NoCategoryButton   Key = "category.none.button"
+	SearchFound        Key = "search.found"
+	SearchNotFound     Key = "search.notfound"
+	RestockTitle       Key = "restock.title"
+
+	monthNames   Key = "month.names"
+	weekdayNames Key = "weekday.names"
+)
+
+// commands
+const (
+	HelpTitle        Key = "help.title"
+	UnknownCommand   Key = "help.unknown"
+	CommandHelp      Key = "command.help"
+	CommandStart     Key = "command.start"
+	CommandList      Key = "command.list"
+	CommandAdd       Key = "command.add"
+	CommandChecklist Key = "command.checklist"
+	CommandBudget    Key = "command.budget"
+)
+
+// firstpage
+const (
+	FirstPageTitle    Key = "firstpage.title"
+	CurrentListButton Key = "firstpage.currentlist"
+	ChecklistButton   Key = "firstpage.checklist"
+	SettingsButton    Key = "firstpage.settings"
+	CalendarButton    Key = "firstpage.calendar"
+	BudgetButton      Key = "firstpage.budget"
+	FundsButton       Key = "firstpage.funds"
+)
+
+// checklist and currentlist
+const (
+	ChecklistInput      Key = "checklist.input"
+	ChecklistEmpty      Key = "checklist.empty"
+	SelectAllButton     Key = "checklist.selectall"
+	CopyToCurrentButton Key = "checklist.copy"
+	ItemsCopied         Key = "checklist.copied"
+	NoNewItems          Key = "checklist.nonew"
+	CurrentListInput    Key = "currentlist.input"
+	CurrentListEmpty    Key = "currentlist.empty"
+)
+
+// calendar, dayshoppings and shoppingitems
+const (
+	CalendarTitle          Key = "calendar.title"
+	ToCalendarButton       Key = "dayshoppings.calendar"
+	DayShoppingsTitle      Key = "dayshoppings.title"
+	DayShoppingsEmpty      Key = "dayshoppings.empty"
+	BackToButton           Key = "shoppingitems.back"
+	ItemsInput             Key = "shoppingitems.input"
+	ItemsEmpty             Key = "shoppingitems.empty"
+	AddFromCurrentButton   Key = "shoppingitems.fromcurrent"
+	AddFromChecklistButton Key = "shoppingitems.fromchecklist"
+	RemoveSelectedButton   Key = "shoppingitems.remove"
+	SelectItemsInput       Key = "shoppingitems.select.input"
+	SelectModeButton       Key = "shoppingitems.select"
+	CancelSelectButton     Key = "shoppingitems.select.cancel"
+	HideCompletedButton    Key = "shoppingitems.completed.hide"
+	ShowCompletedButton    Key = "shoppingitems.completed.show"
+	ClearCompletedButton   Key = "shoppingitems.completed.clear"
+)
+
+// settings
+const (
+	SettingsInfo     Key = "settings.info"
+	NoCommunity      Key = "settings.nocommunity"
+	InYourGroup      Key = "settings.group"
+	NoUsername       Key = "settings.nousername"
+	LeaveGroupButton Key = "settings.leave"
+	LeaveSuccess     Key = "settings.leave.success"
+	AlreadyInGroup   Key = "settings.already"
+	InvalidUserID    Key = "settings.invalidid"
+	UserNotFound     Key = "settings.usernotfound"
+	JoinGroupSuccess Key = "settings.join.success"
+	LanguageButton   Key = "settings.language"
+	LanguageChanged  Key = "settings.language.changed"
+)
+
+// notifications
+const (
+	NotifyInfo            Key = "notify.info"
+	NotifyImmediate       Key = "notify.immediate"
+	NotifyDigestEvery     Key = "notify.digest.every"
+	NotifyDigestAt        Key = "notify.digest.at"
+	NotifyOff             Key = "notify.off"
+	NotifyImmediateButton Key = "notify.immediate.button"
+	NotifyDigestButton    Key = "notify.digest.button"
+	NotifyOffButton       Key = "notify.off.button"
+	DigestMinutesButton   Key = "notify.minutes.button"
+	DigestTimeHint        Key = "notify.time.hint"
+	InvalidDigestTime     Key = "notify.time.invalid"
+	InvalidDigestZone     Key = "notify.zone.invalid"
+	NotifyChanged         Key = "notify.changed"
+	DigestTitle           Key = "digest.title"
+	DigestShopping        Key = "digest.shopping"
+	DigestItem            Key = "digest.item"
+)
+
+// budget and funds
+const (
+	BudgetText           Key = "budget.text"
+	BudgetCategoryButton Key = "budget.category"
+	EmptyCategories      Key = "budget.empty"
+	CategoryText         Key = "category.text"
+	CategoryNote         Key = "category.note"
+	NoFunds              Key = "category.nofunds"
+	Overspend            Key = "category.overspend"
+	FundsText            Key = "funds.text"
+	FundButton           Key = "funds.fund"
+	EmptyFunds           Key = "funds.empty"
+	FundText             Key = "fund.text"
+	FundNote             Key = "fund.note"
+	IOTNoValues          Key = "iot.novalues"
+)
@@ -0,0 +1,129 @@
+package i18n
+
+var ru = map[Key]string{
+	MenuButton:     "⬅ Меню",
+	BackButton:     "⬅ Назад",
+	RemoveButton:   "Удалить",
+	SessionExpired: "Время сессии истекло",
+	ReopenButton:   "↻ Открыть снова",
+	InvalidButton:  "Кнопка устарела, откройте меню: /start",
+	InternalError:  "Что-то пошло не так. Попробуйте ещё раз.",
+	Busy:           "Слишком много запросов, подождите немного.",
+	NotFound:       "Не найдено, возможно, уже удалено.",
+	AccessDenied:   "Нет доступа.",
+	RetryButton:    "🔄 Повторить",
+	UserAddedItem:  "Пользователь %s(%v) добавил '%s' в '%s'(%s)",
+	UserAddedItems: "Пользователь %s(%v) добавил товары (%d) в '%s'(%s): %s",
+	MoreItems:      "и ещё %d",
+	LanguageName:   "Русский",
+	UndoButton:     "↶ Отменить",
+	Undone:         "Отменено",
+	NothingToUndo:  "Нечего отменять",
+
+	CategoryVegetables: "Овощи и фрукты",
+	CategoryBakery:     "Хлеб",
+	CategoryDairy:      "Молочка",
+	CategoryMeat:       "Мясо и рыба",
+	CategoryGrocery:    "Бакалея",
+	CategoryDrinks:     "Напитки",
+	CategorySweets:     "Сладкое",
+	CategoryHousehold:  "Бытовая химия",
+	ChooseCategory:     "Выберите категорию для «%s»",
+	NoCategoryButton:   "Без категории",
+	SearchFound:        "Найдено по «%s»:",
+	SearchNotFound:     "Ничего не найдено по «%s»",
+	RestockTitle:       "💡 Пора купить: %s",
+
+	monthNames:   "Январь,Февраль,Март,Апрель,Май,Июнь,Июль,Август,Сентябрь,Октябрь,Ноябрь,Декабрь",
+	weekdayNames: "ПН,ВТ,СР,ЧТ,ПТ,СБ,ВС",
+
+	HelpTitle:        "Доступные команды:",
+	UnknownCommand:   "Неизвестная команда /%s.",
+	CommandHelp:      "Список команд",
+	CommandStart:     "Главное меню",
+	CommandList:      "Текущий список",
+	CommandAdd:       "Добавить товар в текущий список: /add молоко",
+	CommandChecklist: "Чек-лист",
+	CommandBudget:    "Бюджет",
+
+	FirstPageTitle:    "Главное меню",
+	CurrentListButton: "Текущий список",
+	ChecklistButton:   "Чек-лист",
+	SettingsButton:    "Настройки",
+	CalendarButton:    "Календарь",
+	BudgetButton:      "Бюджет",
+	FundsButton:       "Фонды",
+
+	ChecklistInput:      "Чек-лист. Введите товар для добавления",
+	ChecklistEmpty:      "Чек-лист пока что пуст. Для добавления введите название товара.",
+	SelectAllButton:     "Выделить все",
+	CopyToCurrentButton: "В текущий список",
+	ItemsCopied:         "Товары скопированы.",
+	NoNewItems:          "Нет новых товаров для добавления. ",
+	CurrentListInput:    "Текущий список. Введите товар для добавления",
+	CurrentListEmpty:    "Текущий список пока что пуст. Для добавления введите название товара.",
+
+	CalendarTitle:          "Календарь",
+	ToCalendarButton:       "⬅ Смотреть календарь",
+	DayShoppingsTitle:      "Покупки в этот день. Для добавления введите название места.",
+	DayShoppingsEmpty:      "Покупок в этот день нет. Для добавления введите название места.",
+	BackToButton:           "⬅ Вернуться к ",
+	ItemsInput:             "Введите товар для добавления",
+	ItemsEmpty:             "Список товаров пока что пуст. Для добавления введите название товара.",
+	AddFromCurrentButton:   "↑ из текущего",
+	AddFromChecklistButton: "↑ из чек-листа",
+	RemoveSelectedButton:   "⊗ выбранные",
+	SelectItemsInput:       "Выберите товары для удаления",
+	SelectModeButton:       "☑ Выбрать",
+	CancelSelectButton:     "✖ Отменить выбор",
+	HideCompletedButton:    "🙈 Скрыть купленные",
+	ShowCompletedButton:    "👁 Купленные (%d)",
+	ClearCompletedButton:   "🧹 Убрать купленные",
+
+	SettingsInfo:     "Версия бота: \n%sID текущего пользователя: \"%v\".",
+	NoCommunity:      "Вы не состоите в группе, для вступления в группу, введите ID участника",
+	InYourGroup:      "В вашей группе: %s, вы можете создавать общие списки покупок",
+	NoUsername:       "без имени",
+	LeaveGroupButton: "Покинуть группу",
+	LeaveSuccess:     "<Вы вышли из группы>",
+	AlreadyInGroup:   "<Вы уже состоите в группе>",
+	InvalidUserID:    "<ID участника должен состоять из цифр>",
+	UserNotFound:     "<Участник не найден. Проверьте корректность ID>",
+	JoinGroupSuccess: "<Вы вступили в группу>",
+	LanguageButton:   "🌐 %s",
+	LanguageChanged:  "<Язык изменён>",
+
+	NotifyInfo:            "Уведомления группы: %s",
+	NotifyImmediate:       "сразу",
+	NotifyDigestEvery:     "дайджест каждые %d мин.",
+	NotifyDigestAt:        "дайджест в %s",
+	NotifyOff:             "выключены",
+	NotifyImmediateButton: "🔔 Сразу",
+	NotifyDigestButton:    "🗞 Дайджест",
+	NotifyOffButton:       "🔕 Выкл.",
+	DigestMinutesButton:   "%d мин.",
+	DigestTimeHint:        "Для ежедневного дайджеста введите время, например 20:00, или время с вашим часовым поясом, например 20:00 Europe/Moscow",
+	InvalidDigestTime:     "<Время должно быть в формате ЧЧ:ММ>",
+	InvalidDigestZone:     "<Неизвестный часовой пояс, например Europe/Moscow>",
+	NotifyChanged:         "<Уведомления изменены>",
+	DigestTitle:           "🗞 Добавлено в списки:",
+	DigestShopping:        "%s (%s):",
+	DigestItem:            "• %s — %s",
+
+	BudgetText: `Бюджет: '%s', освоение: %d%%, остаток %d
+	Пример добавления категории: "25000 продукты"
+	Пример добавления бюджета: "!Июнь"`,
+	BudgetCategoryButton: "%d. %s (%d%%), ост: %dр.",
+	EmptyCategories:      "Нет категорий для отображения",
+	CategoryText:         "Категория: %s освоение %d%% (%d/%d):\n",
+	CategoryNote:         "%d) %s -> %dр. - %s\n",
+	NoFunds:              "В категории не осталось средств!",
+	Overspend:            "🤬 Тормозни! Перерасход на %d день|🤬 Тормозни! Перерасход на %d дня|🤬 Тормозни! Перерасход на %d дней",
+	FundsText: `Виртуальные фонды, всего: %d,
+	Пример добавления фонда: "25000 фонд подарков"`,
+	FundButton:  "%d. %s, ост: %dр.",
+	EmptyFunds:  "Нет фондов для отображения",
+	FundText:    "Фонд: %s состояние (%dр):\n",
+	FundNote:    "%d) %s -> %s%dр. - %s\n",
+	IOTNoValues: "Нет новых значений",
+}
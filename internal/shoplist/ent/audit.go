@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/audit"
+)
+
+// Audit is the model entity for the Audit schema.
+type Audit struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id,omitempty"`
+	// TelegramID holds the value of the "telegram_id" field.
+	TelegramID int64 `json:"telegram_id,omitempty"`
+	// ComunityID holds the value of the "comunity_id" field.
+	ComunityID string `json:"comunity_id,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind string `json:"kind,omitempty"`
+	// Action holds the value of the "action" field.
+	Action string `json:"action,omitempty"`
+	// Payload holds the value of the "payload" field.
+	Payload string `json:"payload,omitempty"`
+	// Created holds the value of the "created" field.
+	Created time.Time `json:"created,omitempty"`
+	// Undone holds the value of the "undone" field.
+	Undone bool `json:"undone,omitempty"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Audit) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case audit.FieldUndone:
+			values[i] = new(sql.NullBool)
+		case audit.FieldID, audit.FieldUserID, audit.FieldTelegramID:
+			values[i] = new(sql.NullInt64)
+		case audit.FieldComunityID, audit.FieldKind, audit.FieldAction, audit.FieldPayload:
+			values[i] = new(sql.NullString)
+		case audit.FieldCreated:
+			values[i] = new(sql.NullTime)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type Audit", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Audit fields.
+func (a *Audit) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case audit.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			a.ID = int(value.Int64)
+		case audit.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				a.UserID = int(value.Int64)
+			}
+		case audit.FieldTelegramID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field telegram_id", values[i])
+			} else if value.Valid {
+				a.TelegramID = value.Int64
+			}
+		case audit.FieldComunityID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field comunity_id", values[i])
+			} else if value.Valid {
+				a.ComunityID = value.String
+			}
+		case audit.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				a.Kind = value.String
+			}
+		case audit.FieldAction:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field action", values[i])
+			} else if value.Valid {
+				a.Action = value.String
+			}
+		case audit.FieldPayload:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field payload", values[i])
+			} else if value.Valid {
+				a.Payload = value.String
+			}
+		case audit.FieldCreated:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created", values[i])
+			} else if value.Valid {
+				a.Created = value.Time
+			}
+		case audit.FieldUndone:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field undone", values[i])
+			} else if value.Valid {
+				a.Undone = value.Bool
+			}
+		}
+	}
+	return nil
+}
+
+// Update returns a builder for updating this Audit.
+// Note that you need to call Audit.Unwrap() before calling this method if this Audit
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (a *Audit) Update() *AuditUpdateOne {
+	return (&AuditClient{config: a.config}).UpdateOne(a)
+}
+
+// Unwrap unwraps the Audit entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (a *Audit) Unwrap() *Audit {
+	_tx, ok := a.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Audit is not a transactional entity")
+	}
+	a.config.driver = _tx.drv
+	return a
+}
+
+// String implements the fmt.Stringer.
+func (a *Audit) String() string {
+	var builder strings.Builder
+	builder.WriteString("Audit(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", a.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", a.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("telegram_id=")
+	builder.WriteString(fmt.Sprintf("%v", a.TelegramID))
+	builder.WriteString(", ")
+	builder.WriteString("comunity_id=")
+	builder.WriteString(a.ComunityID)
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(a.Kind)
+	builder.WriteString(", ")
+	builder.WriteString("action=")
+	builder.WriteString(a.Action)
+	builder.WriteString(", ")
+	builder.WriteString("payload=")
+	builder.WriteString(a.Payload)
+	builder.WriteString(", ")
+	builder.WriteString("created=")
+	builder.WriteString(a.Created.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("undone=")
+	builder.WriteString(fmt.Sprintf("%v", a.Undone))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Audits is a parsable slice of Audit.
+type Audits []*Audit
+
+func (a Audits) config(cfg config) {
+	for _i := range a {
+		a[_i].config = cfg
+	}
+}
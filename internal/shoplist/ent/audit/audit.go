@@ -0,0 +1,66 @@
+// Code generated by ent, DO NOT EDIT.
+
+package audit
+
+import (
+	"time"
+)
+
+const (
+	// Label holds the string label denoting the audit type in the database.
+	Label = "audit"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldTelegramID holds the string denoting the telegram_id field in the database.
+	FieldTelegramID = "telegram_id"
+	// FieldComunityID holds the string denoting the comunity_id field in the database.
+	FieldComunityID = "comunity_id"
+	// FieldKind holds the string denoting the kind field in the database.
+	FieldKind = "kind"
+	// FieldAction holds the string denoting the action field in the database.
+	FieldAction = "action"
+	// FieldPayload holds the string denoting the payload field in the database.
+	FieldPayload = "payload"
+	// FieldCreated holds the string denoting the created field in the database.
+	FieldCreated = "created"
+	// FieldUndone holds the string denoting the undone field in the database.
+	FieldUndone = "undone"
+	// Table holds the table name of the audit in the database.
+	Table = "audits"
+)
+
+// Columns holds all SQL columns for audit fields.
+var Columns = []string{
+	FieldID,
+	FieldUserID,
+	FieldTelegramID,
+	FieldComunityID,
+	FieldKind,
+	FieldAction,
+	FieldPayload,
+	FieldCreated,
+	FieldUndone,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// KindValidator is a validator for the "kind" field. It is called by the builders before save.
+	KindValidator func(string) error
+	// ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	ActionValidator func(string) error
+	// DefaultCreated holds the default value on creation for the "created" field.
+	DefaultCreated func() time.Time
+	// DefaultUndone holds the default value on creation for the "undone" field.
+	DefaultUndone bool
+)
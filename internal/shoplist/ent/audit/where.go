@@ -0,0 +1,771 @@
+// Code generated by ent, DO NOT EDIT.
+
+package audit
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUserID), v))
+	})
+}
+
+// TelegramID applies equality check predicate on the "telegram_id" field. It's identical to TelegramIDEQ.
+func TelegramID(v int64) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTelegramID), v))
+	})
+}
+
+// ComunityID applies equality check predicate on the "comunity_id" field. It's identical to ComunityIDEQ.
+func ComunityID(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldComunityID), v))
+	})
+}
+
+// Kind applies equality check predicate on the "kind" field. It's identical to KindEQ.
+func Kind(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldKind), v))
+	})
+}
+
+// Action applies equality check predicate on the "action" field. It's identical to ActionEQ.
+func Action(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAction), v))
+	})
+}
+
+// Payload applies equality check predicate on the "payload" field. It's identical to PayloadEQ.
+func Payload(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPayload), v))
+	})
+}
+
+// Created applies equality check predicate on the "created" field. It's identical to CreatedEQ.
+func Created(v time.Time) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreated), v))
+	})
+}
+
+// Undone applies equality check predicate on the "undone" field. It's identical to UndoneEQ.
+func Undone(v bool) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUndone), v))
+	})
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...int) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldUserID), v...))
+	})
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...int) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldUserID), v...))
+	})
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v int) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldUserID), v))
+	})
+}
+
+// TelegramIDEQ applies the EQ predicate on the "telegram_id" field.
+func TelegramIDEQ(v int64) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTelegramID), v))
+	})
+}
+
+// TelegramIDNEQ applies the NEQ predicate on the "telegram_id" field.
+func TelegramIDNEQ(v int64) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldTelegramID), v))
+	})
+}
+
+// TelegramIDIn applies the In predicate on the "telegram_id" field.
+func TelegramIDIn(vs ...int64) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldTelegramID), v...))
+	})
+}
+
+// TelegramIDNotIn applies the NotIn predicate on the "telegram_id" field.
+func TelegramIDNotIn(vs ...int64) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldTelegramID), v...))
+	})
+}
+
+// TelegramIDGT applies the GT predicate on the "telegram_id" field.
+func TelegramIDGT(v int64) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldTelegramID), v))
+	})
+}
+
+// TelegramIDGTE applies the GTE predicate on the "telegram_id" field.
+func TelegramIDGTE(v int64) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldTelegramID), v))
+	})
+}
+
+// TelegramIDLT applies the LT predicate on the "telegram_id" field.
+func TelegramIDLT(v int64) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldTelegramID), v))
+	})
+}
+
+// TelegramIDLTE applies the LTE predicate on the "telegram_id" field.
+func TelegramIDLTE(v int64) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldTelegramID), v))
+	})
+}
+
+// ComunityIDEQ applies the EQ predicate on the "comunity_id" field.
+func ComunityIDEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDNEQ applies the NEQ predicate on the "comunity_id" field.
+func ComunityIDNEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDIn applies the In predicate on the "comunity_id" field.
+func ComunityIDIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldComunityID), v...))
+	})
+}
+
+// ComunityIDNotIn applies the NotIn predicate on the "comunity_id" field.
+func ComunityIDNotIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldComunityID), v...))
+	})
+}
+
+// ComunityIDGT applies the GT predicate on the "comunity_id" field.
+func ComunityIDGT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDGTE applies the GTE predicate on the "comunity_id" field.
+func ComunityIDGTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDLT applies the LT predicate on the "comunity_id" field.
+func ComunityIDLT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDLTE applies the LTE predicate on the "comunity_id" field.
+func ComunityIDLTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDContains applies the Contains predicate on the "comunity_id" field.
+func ComunityIDContains(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDHasPrefix applies the HasPrefix predicate on the "comunity_id" field.
+func ComunityIDHasPrefix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDHasSuffix applies the HasSuffix predicate on the "comunity_id" field.
+func ComunityIDHasSuffix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDEqualFold applies the EqualFold predicate on the "comunity_id" field.
+func ComunityIDEqualFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDContainsFold applies the ContainsFold predicate on the "comunity_id" field.
+func ComunityIDContainsFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldComunityID), v))
+	})
+}
+
+// KindEQ applies the EQ predicate on the "kind" field.
+func KindEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldKind), v))
+	})
+}
+
+// KindNEQ applies the NEQ predicate on the "kind" field.
+func KindNEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldKind), v))
+	})
+}
+
+// KindIn applies the In predicate on the "kind" field.
+func KindIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldKind), v...))
+	})
+}
+
+// KindNotIn applies the NotIn predicate on the "kind" field.
+func KindNotIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldKind), v...))
+	})
+}
+
+// KindGT applies the GT predicate on the "kind" field.
+func KindGT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldKind), v))
+	})
+}
+
+// KindGTE applies the GTE predicate on the "kind" field.
+func KindGTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldKind), v))
+	})
+}
+
+// KindLT applies the LT predicate on the "kind" field.
+func KindLT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldKind), v))
+	})
+}
+
+// KindLTE applies the LTE predicate on the "kind" field.
+func KindLTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldKind), v))
+	})
+}
+
+// KindContains applies the Contains predicate on the "kind" field.
+func KindContains(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldKind), v))
+	})
+}
+
+// KindHasPrefix applies the HasPrefix predicate on the "kind" field.
+func KindHasPrefix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldKind), v))
+	})
+}
+
+// KindHasSuffix applies the HasSuffix predicate on the "kind" field.
+func KindHasSuffix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldKind), v))
+	})
+}
+
+// KindEqualFold applies the EqualFold predicate on the "kind" field.
+func KindEqualFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldKind), v))
+	})
+}
+
+// KindContainsFold applies the ContainsFold predicate on the "kind" field.
+func KindContainsFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldKind), v))
+	})
+}
+
+// ActionEQ applies the EQ predicate on the "action" field.
+func ActionEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAction), v))
+	})
+}
+
+// ActionNEQ applies the NEQ predicate on the "action" field.
+func ActionNEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAction), v))
+	})
+}
+
+// ActionIn applies the In predicate on the "action" field.
+func ActionIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldAction), v...))
+	})
+}
+
+// ActionNotIn applies the NotIn predicate on the "action" field.
+func ActionNotIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldAction), v...))
+	})
+}
+
+// ActionGT applies the GT predicate on the "action" field.
+func ActionGT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAction), v))
+	})
+}
+
+// ActionGTE applies the GTE predicate on the "action" field.
+func ActionGTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAction), v))
+	})
+}
+
+// ActionLT applies the LT predicate on the "action" field.
+func ActionLT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAction), v))
+	})
+}
+
+// ActionLTE applies the LTE predicate on the "action" field.
+func ActionLTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAction), v))
+	})
+}
+
+// ActionContains applies the Contains predicate on the "action" field.
+func ActionContains(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAction), v))
+	})
+}
+
+// ActionHasPrefix applies the HasPrefix predicate on the "action" field.
+func ActionHasPrefix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAction), v))
+	})
+}
+
+// ActionHasSuffix applies the HasSuffix predicate on the "action" field.
+func ActionHasSuffix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAction), v))
+	})
+}
+
+// ActionEqualFold applies the EqualFold predicate on the "action" field.
+func ActionEqualFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAction), v))
+	})
+}
+
+// ActionContainsFold applies the ContainsFold predicate on the "action" field.
+func ActionContainsFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAction), v))
+	})
+}
+
+// PayloadEQ applies the EQ predicate on the "payload" field.
+func PayloadEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadNEQ applies the NEQ predicate on the "payload" field.
+func PayloadNEQ(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadIn applies the In predicate on the "payload" field.
+func PayloadIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldPayload), v...))
+	})
+}
+
+// PayloadNotIn applies the NotIn predicate on the "payload" field.
+func PayloadNotIn(vs ...string) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldPayload), v...))
+	})
+}
+
+// PayloadGT applies the GT predicate on the "payload" field.
+func PayloadGT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadGTE applies the GTE predicate on the "payload" field.
+func PayloadGTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadLT applies the LT predicate on the "payload" field.
+func PayloadLT(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadLTE applies the LTE predicate on the "payload" field.
+func PayloadLTE(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadContains applies the Contains predicate on the "payload" field.
+func PayloadContains(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadHasPrefix applies the HasPrefix predicate on the "payload" field.
+func PayloadHasPrefix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadHasSuffix applies the HasSuffix predicate on the "payload" field.
+func PayloadHasSuffix(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadEqualFold applies the EqualFold predicate on the "payload" field.
+func PayloadEqualFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldPayload), v))
+	})
+}
+
+// PayloadContainsFold applies the ContainsFold predicate on the "payload" field.
+func PayloadContainsFold(v string) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldPayload), v))
+	})
+}
+
+// CreatedEQ applies the EQ predicate on the "created" field.
+func CreatedEQ(v time.Time) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreated), v))
+	})
+}
+
+// CreatedNEQ applies the NEQ predicate on the "created" field.
+func CreatedNEQ(v time.Time) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCreated), v))
+	})
+}
+
+// CreatedIn applies the In predicate on the "created" field.
+func CreatedIn(vs ...time.Time) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldCreated), v...))
+	})
+}
+
+// CreatedNotIn applies the NotIn predicate on the "created" field.
+func CreatedNotIn(vs ...time.Time) predicate.Audit {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldCreated), v...))
+	})
+}
+
+// CreatedGT applies the GT predicate on the "created" field.
+func CreatedGT(v time.Time) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCreated), v))
+	})
+}
+
+// CreatedGTE applies the GTE predicate on the "created" field.
+func CreatedGTE(v time.Time) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCreated), v))
+	})
+}
+
+// CreatedLT applies the LT predicate on the "created" field.
+func CreatedLT(v time.Time) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCreated), v))
+	})
+}
+
+// CreatedLTE applies the LTE predicate on the "created" field.
+func CreatedLTE(v time.Time) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCreated), v))
+	})
+}
+
+// UndoneEQ applies the EQ predicate on the "undone" field.
+func UndoneEQ(v bool) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUndone), v))
+	})
+}
+
+// UndoneNEQ applies the NEQ predicate on the "undone" field.
+func UndoneNEQ(v bool) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldUndone), v))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Audit) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Audit) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Audit) predicate.Audit {
+	return predicate.Audit(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
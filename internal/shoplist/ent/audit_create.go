@@ -0,0 +1,386 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/audit"
+)
+
+// AuditCreate is the builder for creating a Audit entity.
+type AuditCreate struct {
+	config
+	mutation *AuditMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (ac *AuditCreate) SetUserID(i int) *AuditCreate {
+	ac.mutation.SetUserID(i)
+	return ac
+}
+
+// SetTelegramID sets the "telegram_id" field.
+func (ac *AuditCreate) SetTelegramID(i int64) *AuditCreate {
+	ac.mutation.SetTelegramID(i)
+	return ac
+}
+
+// SetComunityID sets the "comunity_id" field.
+func (ac *AuditCreate) SetComunityID(s string) *AuditCreate {
+	ac.mutation.SetComunityID(s)
+	return ac
+}
+
+// SetKind sets the "kind" field.
+func (ac *AuditCreate) SetKind(s string) *AuditCreate {
+	ac.mutation.SetKind(s)
+	return ac
+}
+
+// SetAction sets the "action" field.
+func (ac *AuditCreate) SetAction(s string) *AuditCreate {
+	ac.mutation.SetAction(s)
+	return ac
+}
+
+// SetPayload sets the "payload" field.
+func (ac *AuditCreate) SetPayload(s string) *AuditCreate {
+	ac.mutation.SetPayload(s)
+	return ac
+}
+
+// SetCreated sets the "created" field.
+func (ac *AuditCreate) SetCreated(t time.Time) *AuditCreate {
+	ac.mutation.SetCreated(t)
+	return ac
+}
+
+// SetNillableCreated sets the "created" field if the given value is not nil.
+func (ac *AuditCreate) SetNillableCreated(t *time.Time) *AuditCreate {
+	if t != nil {
+		ac.SetCreated(*t)
+	}
+	return ac
+}
+
+// SetUndone sets the "undone" field.
+func (ac *AuditCreate) SetUndone(b bool) *AuditCreate {
+	ac.mutation.SetUndone(b)
+	return ac
+}
+
+// SetNillableUndone sets the "undone" field if the given value is not nil.
+func (ac *AuditCreate) SetNillableUndone(b *bool) *AuditCreate {
+	if b != nil {
+		ac.SetUndone(*b)
+	}
+	return ac
+}
+
+// Mutation returns the AuditMutation object of the builder.
+func (ac *AuditCreate) Mutation() *AuditMutation {
+	return ac.mutation
+}
+
+// Save creates the Audit in the database.
+func (ac *AuditCreate) Save(ctx context.Context) (*Audit, error) {
+	var (
+		err  error
+		node *Audit
+	)
+	ac.defaults()
+	if len(ac.hooks) == 0 {
+		if err = ac.check(); err != nil {
+			return nil, err
+		}
+		node, err = ac.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*AuditMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ac.check(); err != nil {
+				return nil, err
+			}
+			ac.mutation = mutation
+			if node, err = ac.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ac.hooks) - 1; i >= 0; i-- {
+			if ac.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = ac.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, ac.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*Audit)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from AuditMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ac *AuditCreate) SaveX(ctx context.Context) *Audit {
+	v, err := ac.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ac *AuditCreate) Exec(ctx context.Context) error {
+	_, err := ac.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ac *AuditCreate) ExecX(ctx context.Context) {
+	if err := ac.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ac *AuditCreate) defaults() {
+	if _, ok := ac.mutation.Created(); !ok {
+		v := audit.DefaultCreated()
+		ac.mutation.SetCreated(v)
+	}
+	if _, ok := ac.mutation.Undone(); !ok {
+		v := audit.DefaultUndone
+		ac.mutation.SetUndone(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ac *AuditCreate) check() error {
+	if _, ok := ac.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "Audit.user_id"`)}
+	}
+	if _, ok := ac.mutation.TelegramID(); !ok {
+		return &ValidationError{Name: "telegram_id", err: errors.New(`ent: missing required field "Audit.telegram_id"`)}
+	}
+	if _, ok := ac.mutation.ComunityID(); !ok {
+		return &ValidationError{Name: "comunity_id", err: errors.New(`ent: missing required field "Audit.comunity_id"`)}
+	}
+	if _, ok := ac.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "Audit.kind"`)}
+	}
+	if v, ok := ac.mutation.Kind(); ok {
+		if err := audit.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Audit.kind": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New(`ent: missing required field "Audit.action"`)}
+	}
+	if v, ok := ac.mutation.Action(); ok {
+		if err := audit.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "Audit.action": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.Payload(); !ok {
+		return &ValidationError{Name: "payload", err: errors.New(`ent: missing required field "Audit.payload"`)}
+	}
+	if _, ok := ac.mutation.Created(); !ok {
+		return &ValidationError{Name: "created", err: errors.New(`ent: missing required field "Audit.created"`)}
+	}
+	if _, ok := ac.mutation.Undone(); !ok {
+		return &ValidationError{Name: "undone", err: errors.New(`ent: missing required field "Audit.undone"`)}
+	}
+	return nil
+}
+
+func (ac *AuditCreate) sqlSave(ctx context.Context) (*Audit, error) {
+	_node, _spec := ac.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ac.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (ac *AuditCreate) createSpec() (*Audit, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Audit{config: ac.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: audit.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: audit.FieldID,
+			},
+		}
+	)
+	if value, ok := ac.mutation.UserID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: audit.FieldUserID,
+		})
+		_node.UserID = value
+	}
+	if value, ok := ac.mutation.TelegramID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: audit.FieldTelegramID,
+		})
+		_node.TelegramID = value
+	}
+	if value, ok := ac.mutation.ComunityID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: audit.FieldComunityID,
+		})
+		_node.ComunityID = value
+	}
+	if value, ok := ac.mutation.Kind(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: audit.FieldKind,
+		})
+		_node.Kind = value
+	}
+	if value, ok := ac.mutation.Action(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: audit.FieldAction,
+		})
+		_node.Action = value
+	}
+	if value, ok := ac.mutation.Payload(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: audit.FieldPayload,
+		})
+		_node.Payload = value
+	}
+	if value, ok := ac.mutation.Created(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: audit.FieldCreated,
+		})
+		_node.Created = value
+	}
+	if value, ok := ac.mutation.Undone(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: audit.FieldUndone,
+		})
+		_node.Undone = value
+	}
+	return _node, _spec
+}
+
+// AuditCreateBulk is the builder for creating many Audit entities in bulk.
+type AuditCreateBulk struct {
+	config
+	builders []*AuditCreate
+}
+
+// Save creates the Audit entities in the database.
+func (acb *AuditCreateBulk) Save(ctx context.Context) ([]*Audit, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(acb.builders))
+	nodes := make([]*Audit, len(acb.builders))
+	mutators := make([]Mutator, len(acb.builders))
+	for i := range acb.builders {
+		func(i int, root context.Context) {
+			builder := acb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, acb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, acb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, acb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (acb *AuditCreateBulk) SaveX(ctx context.Context) []*Audit {
+	v, err := acb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (acb *AuditCreateBulk) Exec(ctx context.Context) error {
+	_, err := acb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (acb *AuditCreateBulk) ExecX(ctx context.Context) {
+	if err := acb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
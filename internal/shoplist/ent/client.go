@@ -10,7 +10,10 @@ import (
 
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/migrate"
 
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/audit"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/item"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shop"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shopping"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/user"
@@ -25,8 +28,14 @@ type Client struct {
 	config
 	// Schema is the client for creating, migrating and dropping schema.
 	Schema *migrate.Schema
+	// Audit is the client for interacting with the Audit builders.
+	Audit *AuditClient
 	// Item is the client for interacting with the Item builders.
 	Item *ItemClient
+	// ItemCategory is the client for interacting with the ItemCategory builders.
+	ItemCategory *ItemCategoryClient
+	// Product is the client for interacting with the Product builders.
+	Product *ProductClient
 	// Shop is the client for interacting with the Shop builders.
 	Shop *ShopClient
 	// Shopping is the client for interacting with the Shopping builders.
@@ -46,7 +55,10 @@ func NewClient(opts ...Option) *Client {
 
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
+	c.Audit = NewAuditClient(c.config)
 	c.Item = NewItemClient(c.config)
+	c.ItemCategory = NewItemCategoryClient(c.config)
+	c.Product = NewProductClient(c.config)
 	c.Shop = NewShopClient(c.config)
 	c.Shopping = NewShoppingClient(c.config)
 	c.User = NewUserClient(c.config)
@@ -81,12 +93,15 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:      ctx,
-		config:   cfg,
-		Item:     NewItemClient(cfg),
-		Shop:     NewShopClient(cfg),
-		Shopping: NewShoppingClient(cfg),
-		User:     NewUserClient(cfg),
+		ctx:          ctx,
+		config:       cfg,
+		Audit:        NewAuditClient(cfg),
+		Item:         NewItemClient(cfg),
+		ItemCategory: NewItemCategoryClient(cfg),
+		Product:      NewProductClient(cfg),
+		Shop:         NewShopClient(cfg),
+		Shopping:     NewShoppingClient(cfg),
+		User:         NewUserClient(cfg),
 	}, nil
 }
 
@@ -104,19 +119,22 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:      ctx,
-		config:   cfg,
-		Item:     NewItemClient(cfg),
-		Shop:     NewShopClient(cfg),
-		Shopping: NewShoppingClient(cfg),
-		User:     NewUserClient(cfg),
+		ctx:          ctx,
+		config:       cfg,
+		Audit:        NewAuditClient(cfg),
+		Item:         NewItemClient(cfg),
+		ItemCategory: NewItemCategoryClient(cfg),
+		Product:      NewProductClient(cfg),
+		Shop:         NewShopClient(cfg),
+		Shopping:     NewShoppingClient(cfg),
+		User:         NewUserClient(cfg),
 	}, nil
 }
 
 // Debug returns a new debug-client. It's used to get verbose logging on specific operations.
 //
 //	client.Debug().
-//		Item.
+//		Audit.
 //		Query().
 //		Count(ctx)
 //
@@ -139,12 +157,105 @@ func (c *Client) Close() error {
 // Use adds the mutation hooks to all the entity clients.
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
+	c.Audit.Use(hooks...)
 	c.Item.Use(hooks...)
+	c.ItemCategory.Use(hooks...)
+	c.Product.Use(hooks...)
 	c.Shop.Use(hooks...)
 	c.Shopping.Use(hooks...)
 	c.User.Use(hooks...)
 }
 
+// AuditClient is a client for the Audit schema.
+type AuditClient struct {
+	config
+}
+
+// NewAuditClient returns a client for the Audit from the given config.
+func NewAuditClient(c config) *AuditClient {
+	return &AuditClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `audit.Hooks(f(g(h())))`.
+func (c *AuditClient) Use(hooks ...Hook) {
+	c.hooks.Audit = append(c.hooks.Audit, hooks...)
+}
+
+// Create returns a builder for creating a Audit entity.
+func (c *AuditClient) Create() *AuditCreate {
+	mutation := newAuditMutation(c.config, OpCreate)
+	return &AuditCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Audit entities.
+func (c *AuditClient) CreateBulk(builders ...*AuditCreate) *AuditCreateBulk {
+	return &AuditCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Audit.
+func (c *AuditClient) Update() *AuditUpdate {
+	mutation := newAuditMutation(c.config, OpUpdate)
+	return &AuditUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *AuditClient) UpdateOne(a *Audit) *AuditUpdateOne {
+	mutation := newAuditMutation(c.config, OpUpdateOne, withAudit(a))
+	return &AuditUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *AuditClient) UpdateOneID(id int) *AuditUpdateOne {
+	mutation := newAuditMutation(c.config, OpUpdateOne, withAuditID(id))
+	return &AuditUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Audit.
+func (c *AuditClient) Delete() *AuditDelete {
+	mutation := newAuditMutation(c.config, OpDelete)
+	return &AuditDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *AuditClient) DeleteOne(a *Audit) *AuditDeleteOne {
+	return c.DeleteOneID(a.ID)
+}
+
+// DeleteOne returns a builder for deleting the given entity by its id.
+func (c *AuditClient) DeleteOneID(id int) *AuditDeleteOne {
+	builder := c.Delete().Where(audit.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &AuditDeleteOne{builder}
+}
+
+// Query returns a query builder for Audit.
+func (c *AuditClient) Query() *AuditQuery {
+	return &AuditQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a Audit entity by its id.
+func (c *AuditClient) Get(ctx context.Context, id int) (*Audit, error) {
+	return c.Query().Where(audit.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *AuditClient) GetX(ctx context.Context, id int) *Audit {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *AuditClient) Hooks() []Hook {
+	return c.hooks.Audit
+}
+
 // ItemClient is a client for the Item schema.
 type ItemClient struct {
 	config
@@ -251,6 +362,186 @@ func (c *ItemClient) Hooks() []Hook {
 	return c.hooks.Item
 }
 
+// ItemCategoryClient is a client for the ItemCategory schema.
+type ItemCategoryClient struct {
+	config
+}
+
+// NewItemCategoryClient returns a client for the ItemCategory from the given config.
+func NewItemCategoryClient(c config) *ItemCategoryClient {
+	return &ItemCategoryClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `itemcategory.Hooks(f(g(h())))`.
+func (c *ItemCategoryClient) Use(hooks ...Hook) {
+	c.hooks.ItemCategory = append(c.hooks.ItemCategory, hooks...)
+}
+
+// Create returns a builder for creating a ItemCategory entity.
+func (c *ItemCategoryClient) Create() *ItemCategoryCreate {
+	mutation := newItemCategoryMutation(c.config, OpCreate)
+	return &ItemCategoryCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ItemCategory entities.
+func (c *ItemCategoryClient) CreateBulk(builders ...*ItemCategoryCreate) *ItemCategoryCreateBulk {
+	return &ItemCategoryCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ItemCategory.
+func (c *ItemCategoryClient) Update() *ItemCategoryUpdate {
+	mutation := newItemCategoryMutation(c.config, OpUpdate)
+	return &ItemCategoryUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ItemCategoryClient) UpdateOne(ic *ItemCategory) *ItemCategoryUpdateOne {
+	mutation := newItemCategoryMutation(c.config, OpUpdateOne, withItemCategory(ic))
+	return &ItemCategoryUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ItemCategoryClient) UpdateOneID(id int) *ItemCategoryUpdateOne {
+	mutation := newItemCategoryMutation(c.config, OpUpdateOne, withItemCategoryID(id))
+	return &ItemCategoryUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ItemCategory.
+func (c *ItemCategoryClient) Delete() *ItemCategoryDelete {
+	mutation := newItemCategoryMutation(c.config, OpDelete)
+	return &ItemCategoryDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ItemCategoryClient) DeleteOne(ic *ItemCategory) *ItemCategoryDeleteOne {
+	return c.DeleteOneID(ic.ID)
+}
+
+// DeleteOne returns a builder for deleting the given entity by its id.
+func (c *ItemCategoryClient) DeleteOneID(id int) *ItemCategoryDeleteOne {
+	builder := c.Delete().Where(itemcategory.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ItemCategoryDeleteOne{builder}
+}
+
+// Query returns a query builder for ItemCategory.
+func (c *ItemCategoryClient) Query() *ItemCategoryQuery {
+	return &ItemCategoryQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a ItemCategory entity by its id.
+func (c *ItemCategoryClient) Get(ctx context.Context, id int) (*ItemCategory, error) {
+	return c.Query().Where(itemcategory.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ItemCategoryClient) GetX(ctx context.Context, id int) *ItemCategory {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *ItemCategoryClient) Hooks() []Hook {
+	return c.hooks.ItemCategory
+}
+
+// ProductClient is a client for the Product schema.
+type ProductClient struct {
+	config
+}
+
+// NewProductClient returns a client for the Product from the given config.
+func NewProductClient(c config) *ProductClient {
+	return &ProductClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `product.Hooks(f(g(h())))`.
+func (c *ProductClient) Use(hooks ...Hook) {
+	c.hooks.Product = append(c.hooks.Product, hooks...)
+}
+
+// Create returns a builder for creating a Product entity.
+func (c *ProductClient) Create() *ProductCreate {
+	mutation := newProductMutation(c.config, OpCreate)
+	return &ProductCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Product entities.
+func (c *ProductClient) CreateBulk(builders ...*ProductCreate) *ProductCreateBulk {
+	return &ProductCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Product.
+func (c *ProductClient) Update() *ProductUpdate {
+	mutation := newProductMutation(c.config, OpUpdate)
+	return &ProductUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ProductClient) UpdateOne(pr *Product) *ProductUpdateOne {
+	mutation := newProductMutation(c.config, OpUpdateOne, withProduct(pr))
+	return &ProductUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ProductClient) UpdateOneID(id int) *ProductUpdateOne {
+	mutation := newProductMutation(c.config, OpUpdateOne, withProductID(id))
+	return &ProductUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Product.
+func (c *ProductClient) Delete() *ProductDelete {
+	mutation := newProductMutation(c.config, OpDelete)
+	return &ProductDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ProductClient) DeleteOne(pr *Product) *ProductDeleteOne {
+	return c.DeleteOneID(pr.ID)
+}
+
+// DeleteOne returns a builder for deleting the given entity by its id.
+func (c *ProductClient) DeleteOneID(id int) *ProductDeleteOne {
+	builder := c.Delete().Where(product.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ProductDeleteOne{builder}
+}
+
+// Query returns a query builder for Product.
+func (c *ProductClient) Query() *ProductQuery {
+	return &ProductQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a Product entity by its id.
+func (c *ProductClient) Get(ctx context.Context, id int) (*Product, error) {
+	return c.Query().Where(product.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ProductClient) GetX(ctx context.Context, id int) *Product {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *ProductClient) Hooks() []Hook {
+	return c.hooks.Product
+}
+
 // ShopClient is a client for the Shop schema.
 type ShopClient struct {
 	config
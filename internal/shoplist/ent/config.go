@@ -24,10 +24,13 @@ type config struct {
 
 // hooks per client, for fast access.
 type hooks struct {
-	Item     []ent.Hook
-	Shop     []ent.Hook
-	Shopping []ent.Hook
-	User     []ent.Hook
+	Audit        []ent.Hook
+	Item         []ent.Hook
+	ItemCategory []ent.Hook
+	Product      []ent.Hook
+	Shop         []ent.Hook
+	Shopping     []ent.Hook
+	User         []ent.Hook
 }
 
 // Options applies the options on the config object.
@@ -10,7 +10,10 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/audit"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/item"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shop"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shopping"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/user"
@@ -34,10 +37,13 @@ type OrderFunc func(*sql.Selector)
 // columnChecker returns a function indicates if the column exists in the given column.
 func columnChecker(table string) func(string) error {
 	checks := map[string]func(string) bool{
-		item.Table:     item.ValidColumn,
-		shop.Table:     shop.ValidColumn,
-		shopping.Table: shopping.ValidColumn,
-		user.Table:     user.ValidColumn,
+		audit.Table:        audit.ValidColumn,
+		item.Table:         item.ValidColumn,
+		itemcategory.Table: itemcategory.ValidColumn,
+		product.Table:      product.ValidColumn,
+		shop.Table:         shop.ValidColumn,
+		shopping.Table:     shopping.ValidColumn,
+		user.Table:         user.ValidColumn,
 	}
 	check, ok := checks[table]
 	if !ok {
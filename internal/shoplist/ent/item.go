@@ -20,6 +20,8 @@ type Item struct {
 	ProductName string `json:"product_name,omitempty"`
 	// Quantity holds the value of the "quantity" field.
 	Quantity int `json:"quantity,omitempty"`
+	// Unit holds the value of the "unit" field.
+	Unit string `json:"unit,omitempty"`
 	// CategoryID holds the value of the "category_id" field.
 	CategoryID int `json:"category_id,omitempty"`
 	// Complete holds the value of the "complete" field.
@@ -61,7 +63,7 @@ func (*Item) scanValues(columns []string) ([]interface{}, error) {
 			values[i] = new(sql.NullBool)
 		case item.FieldID, item.FieldQuantity, item.FieldCategoryID:
 			values[i] = new(sql.NullInt64)
-		case item.FieldProductName:
+		case item.FieldProductName, item.FieldUnit:
 			values[i] = new(sql.NullString)
 		case item.ForeignKeys[0]: // shopping_item
 			values[i] = new(sql.NullInt64)
@@ -98,6 +100,12 @@ func (i *Item) assignValues(columns []string, values []interface{}) error {
 			} else if value.Valid {
 				i.Quantity = int(value.Int64)
 			}
+		case item.FieldUnit:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field unit", values[j])
+			} else if value.Valid {
+				i.Unit = value.String
+			}
 		case item.FieldCategoryID:
 			if value, ok := values[j].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field category_id", values[j])
@@ -156,6 +164,9 @@ func (i *Item) String() string {
 	builder.WriteString("quantity=")
 	builder.WriteString(fmt.Sprintf("%v", i.Quantity))
 	builder.WriteString(", ")
+	builder.WriteString("unit=")
+	builder.WriteString(i.Unit)
+	builder.WriteString(", ")
 	builder.WriteString("category_id=")
 	builder.WriteString(fmt.Sprintf("%v", i.CategoryID))
 	builder.WriteString(", ")
@@ -11,6 +11,8 @@ const (
 	FieldProductName = "product_name"
 	// FieldQuantity holds the string denoting the quantity field in the database.
 	FieldQuantity = "quantity"
+	// FieldUnit holds the string denoting the unit field in the database.
+	FieldUnit = "unit"
 	// FieldCategoryID holds the string denoting the category_id field in the database.
 	FieldCategoryID = "category_id"
 	// FieldComplete holds the string denoting the complete field in the database.
@@ -33,6 +35,7 @@ var Columns = []string{
 	FieldID,
 	FieldProductName,
 	FieldQuantity,
+	FieldUnit,
 	FieldCategoryID,
 	FieldComplete,
 }
@@ -63,6 +66,8 @@ var (
 	ProductNameValidator func(string) error
 	// DefaultQuantity holds the default value on creation for the "quantity" field.
 	DefaultQuantity int
+	// DefaultUnit holds the default value on creation for the "unit" field.
+	DefaultUnit string
 	// DefaultCategoryID holds the default value on creation for the "category_id" field.
 	DefaultCategoryID int
 	// DefaultComplete holds the default value on creation for the "complete" field.
@@ -40,6 +40,20 @@ func (ic *ItemCreate) SetNillableQuantity(i *int) *ItemCreate {
 	return ic
 }
 
+// SetUnit sets the "unit" field.
+func (ic *ItemCreate) SetUnit(s string) *ItemCreate {
+	ic.mutation.SetUnit(s)
+	return ic
+}
+
+// SetNillableUnit sets the "unit" field if the given value is not nil.
+func (ic *ItemCreate) SetNillableUnit(s *string) *ItemCreate {
+	if s != nil {
+		ic.SetUnit(*s)
+	}
+	return ic
+}
+
 // SetCategoryID sets the "category_id" field.
 func (ic *ItemCreate) SetCategoryID(i int) *ItemCreate {
 	ic.mutation.SetCategoryID(i)
@@ -168,6 +182,10 @@ func (ic *ItemCreate) defaults() {
 		v := item.DefaultQuantity
 		ic.mutation.SetQuantity(v)
 	}
+	if _, ok := ic.mutation.Unit(); !ok {
+		v := item.DefaultUnit
+		ic.mutation.SetUnit(v)
+	}
 	if _, ok := ic.mutation.CategoryID(); !ok {
 		v := item.DefaultCategoryID
 		ic.mutation.SetCategoryID(v)
@@ -191,6 +209,9 @@ func (ic *ItemCreate) check() error {
 	if _, ok := ic.mutation.Quantity(); !ok {
 		return &ValidationError{Name: "quantity", err: errors.New(`ent: missing required field "Item.quantity"`)}
 	}
+	if _, ok := ic.mutation.Unit(); !ok {
+		return &ValidationError{Name: "unit", err: errors.New(`ent: missing required field "Item.unit"`)}
+	}
 	if _, ok := ic.mutation.CategoryID(); !ok {
 		return &ValidationError{Name: "category_id", err: errors.New(`ent: missing required field "Item.category_id"`)}
 	}
@@ -240,6 +261,14 @@ func (ic *ItemCreate) createSpec() (*Item, *sqlgraph.CreateSpec) {
 		})
 		_node.Quantity = value
 	}
+	if value, ok := ic.mutation.Unit(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: item.FieldUnit,
+		})
+		_node.Unit = value
+	}
 	if value, ok := ic.mutation.CategoryID(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeInt,
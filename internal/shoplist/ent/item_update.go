@@ -55,6 +55,20 @@ func (iu *ItemUpdate) AddQuantity(i int) *ItemUpdate {
 	return iu
 }
 
+// SetUnit sets the "unit" field.
+func (iu *ItemUpdate) SetUnit(s string) *ItemUpdate {
+	iu.mutation.SetUnit(s)
+	return iu
+}
+
+// SetNillableUnit sets the "unit" field if the given value is not nil.
+func (iu *ItemUpdate) SetNillableUnit(s *string) *ItemUpdate {
+	if s != nil {
+		iu.SetUnit(*s)
+	}
+	return iu
+}
+
 // SetCategoryID sets the "category_id" field.
 func (iu *ItemUpdate) SetCategoryID(i int) *ItemUpdate {
 	iu.mutation.ResetCategoryID()
@@ -229,6 +243,13 @@ func (iu *ItemUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: item.FieldQuantity,
 		})
 	}
+	if value, ok := iu.mutation.Unit(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: item.FieldUnit,
+		})
+	}
 	if value, ok := iu.mutation.CategoryID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeInt,
@@ -331,6 +352,20 @@ func (iuo *ItemUpdateOne) AddQuantity(i int) *ItemUpdateOne {
 	return iuo
 }
 
+// SetUnit sets the "unit" field.
+func (iuo *ItemUpdateOne) SetUnit(s string) *ItemUpdateOne {
+	iuo.mutation.SetUnit(s)
+	return iuo
+}
+
+// SetNillableUnit sets the "unit" field if the given value is not nil.
+func (iuo *ItemUpdateOne) SetNillableUnit(s *string) *ItemUpdateOne {
+	if s != nil {
+		iuo.SetUnit(*s)
+	}
+	return iuo
+}
+
 // SetCategoryID sets the "category_id" field.
 func (iuo *ItemUpdateOne) SetCategoryID(i int) *ItemUpdateOne {
 	iuo.mutation.ResetCategoryID()
@@ -535,6 +570,13 @@ func (iuo *ItemUpdateOne) sqlSave(ctx context.Context) (_node *Item, err error)
 			Column: item.FieldQuantity,
 		})
 	}
+	if value, ok := iuo.mutation.Unit(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: item.FieldUnit,
+		})
+	}
 	if value, ok := iuo.mutation.CategoryID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeInt,
@@ -0,0 +1,143 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
+)
+
+// ItemCategory is the model entity for the ItemCategory schema.
+type ItemCategory struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// ComunityID holds the value of the "comunity_id" field.
+	ComunityID string `json:"comunity_id,omitempty"`
+	// Key holds the value of the "key" field.
+	Key string `json:"key,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Emoji holds the value of the "emoji" field.
+	Emoji string `json:"emoji,omitempty"`
+	// SortOrder holds the value of the "sort_order" field.
+	SortOrder int `json:"sort_order,omitempty"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ItemCategory) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case itemcategory.FieldID, itemcategory.FieldSortOrder:
+			values[i] = new(sql.NullInt64)
+		case itemcategory.FieldComunityID, itemcategory.FieldKey, itemcategory.FieldName, itemcategory.FieldEmoji:
+			values[i] = new(sql.NullString)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type ItemCategory", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ItemCategory fields.
+func (ic *ItemCategory) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case itemcategory.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			ic.ID = int(value.Int64)
+		case itemcategory.FieldComunityID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field comunity_id", values[i])
+			} else if value.Valid {
+				ic.ComunityID = value.String
+			}
+		case itemcategory.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				ic.Key = value.String
+			}
+		case itemcategory.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				ic.Name = value.String
+			}
+		case itemcategory.FieldEmoji:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field emoji", values[i])
+			} else if value.Valid {
+				ic.Emoji = value.String
+			}
+		case itemcategory.FieldSortOrder:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field sort_order", values[i])
+			} else if value.Valid {
+				ic.SortOrder = int(value.Int64)
+			}
+		}
+	}
+	return nil
+}
+
+// Update returns a builder for updating this ItemCategory.
+// Note that you need to call ItemCategory.Unwrap() before calling this method if this ItemCategory
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ic *ItemCategory) Update() *ItemCategoryUpdateOne {
+	return (&ItemCategoryClient{config: ic.config}).UpdateOne(ic)
+}
+
+// Unwrap unwraps the ItemCategory entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ic *ItemCategory) Unwrap() *ItemCategory {
+	_tx, ok := ic.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ItemCategory is not a transactional entity")
+	}
+	ic.config.driver = _tx.drv
+	return ic
+}
+
+// String implements the fmt.Stringer.
+func (ic *ItemCategory) String() string {
+	var builder strings.Builder
+	builder.WriteString("ItemCategory(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ic.ID))
+	builder.WriteString("comunity_id=")
+	builder.WriteString(ic.ComunityID)
+	builder.WriteString(", ")
+	builder.WriteString("key=")
+	builder.WriteString(ic.Key)
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(ic.Name)
+	builder.WriteString(", ")
+	builder.WriteString("emoji=")
+	builder.WriteString(ic.Emoji)
+	builder.WriteString(", ")
+	builder.WriteString("sort_order=")
+	builder.WriteString(fmt.Sprintf("%v", ic.SortOrder))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ItemCategories is a parsable slice of ItemCategory.
+type ItemCategories []*ItemCategory
+
+func (ic ItemCategories) config(cfg config) {
+	for _i := range ic {
+		ic[_i].config = cfg
+	}
+}
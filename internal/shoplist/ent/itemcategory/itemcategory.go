@@ -0,0 +1,53 @@
+// Code generated by ent, DO NOT EDIT.
+
+package itemcategory
+
+const (
+	// Label holds the string label denoting the itemcategory type in the database.
+	Label = "item_category"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldComunityID holds the string denoting the comunity_id field in the database.
+	FieldComunityID = "comunity_id"
+	// FieldKey holds the string denoting the key field in the database.
+	FieldKey = "key"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldEmoji holds the string denoting the emoji field in the database.
+	FieldEmoji = "emoji"
+	// FieldSortOrder holds the string denoting the sort_order field in the database.
+	FieldSortOrder = "sort_order"
+	// Table holds the table name of the itemcategory in the database.
+	Table = "item_categories"
+)
+
+// Columns holds all SQL columns for itemcategory fields.
+var Columns = []string{
+	FieldID,
+	FieldComunityID,
+	FieldKey,
+	FieldName,
+	FieldEmoji,
+	FieldSortOrder,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ComunityIDValidator is a validator for the "comunity_id" field. It is called by the builders before save.
+	ComunityIDValidator func(string) error
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// DefaultEmoji holds the default value on creation for the "emoji" field.
+	DefaultEmoji string
+	// DefaultSortOrder holds the default value on creation for the "sort_order" field.
+	DefaultSortOrder int
+)
@@ -0,0 +1,620 @@
+// Code generated by ent, DO NOT EDIT.
+
+package itemcategory
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// ComunityID applies equality check predicate on the "comunity_id" field. It's identical to ComunityIDEQ.
+func ComunityID(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldComunityID), v))
+	})
+}
+
+// Key applies equality check predicate on the "key" field. It's identical to KeyEQ.
+func Key(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldKey), v))
+	})
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldName), v))
+	})
+}
+
+// Emoji applies equality check predicate on the "emoji" field. It's identical to EmojiEQ.
+func Emoji(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldEmoji), v))
+	})
+}
+
+// SortOrder applies equality check predicate on the "sort_order" field. It's identical to SortOrderEQ.
+func SortOrder(v int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSortOrder), v))
+	})
+}
+
+// ComunityIDEQ applies the EQ predicate on the "comunity_id" field.
+func ComunityIDEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDNEQ applies the NEQ predicate on the "comunity_id" field.
+func ComunityIDNEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDIn applies the In predicate on the "comunity_id" field.
+func ComunityIDIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldComunityID), v...))
+	})
+}
+
+// ComunityIDNotIn applies the NotIn predicate on the "comunity_id" field.
+func ComunityIDNotIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldComunityID), v...))
+	})
+}
+
+// ComunityIDGT applies the GT predicate on the "comunity_id" field.
+func ComunityIDGT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDGTE applies the GTE predicate on the "comunity_id" field.
+func ComunityIDGTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDLT applies the LT predicate on the "comunity_id" field.
+func ComunityIDLT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDLTE applies the LTE predicate on the "comunity_id" field.
+func ComunityIDLTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDContains applies the Contains predicate on the "comunity_id" field.
+func ComunityIDContains(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDHasPrefix applies the HasPrefix predicate on the "comunity_id" field.
+func ComunityIDHasPrefix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDHasSuffix applies the HasSuffix predicate on the "comunity_id" field.
+func ComunityIDHasSuffix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDEqualFold applies the EqualFold predicate on the "comunity_id" field.
+func ComunityIDEqualFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDContainsFold applies the ContainsFold predicate on the "comunity_id" field.
+func ComunityIDContainsFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldComunityID), v))
+	})
+}
+
+// KeyEQ applies the EQ predicate on the "key" field.
+func KeyEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldKey), v))
+	})
+}
+
+// KeyNEQ applies the NEQ predicate on the "key" field.
+func KeyNEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldKey), v))
+	})
+}
+
+// KeyIn applies the In predicate on the "key" field.
+func KeyIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldKey), v...))
+	})
+}
+
+// KeyNotIn applies the NotIn predicate on the "key" field.
+func KeyNotIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldKey), v...))
+	})
+}
+
+// KeyGT applies the GT predicate on the "key" field.
+func KeyGT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldKey), v))
+	})
+}
+
+// KeyGTE applies the GTE predicate on the "key" field.
+func KeyGTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldKey), v))
+	})
+}
+
+// KeyLT applies the LT predicate on the "key" field.
+func KeyLT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldKey), v))
+	})
+}
+
+// KeyLTE applies the LTE predicate on the "key" field.
+func KeyLTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldKey), v))
+	})
+}
+
+// KeyContains applies the Contains predicate on the "key" field.
+func KeyContains(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldKey), v))
+	})
+}
+
+// KeyHasPrefix applies the HasPrefix predicate on the "key" field.
+func KeyHasPrefix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldKey), v))
+	})
+}
+
+// KeyHasSuffix applies the HasSuffix predicate on the "key" field.
+func KeyHasSuffix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldKey), v))
+	})
+}
+
+// KeyIsNil applies the IsNil predicate on the "key" field.
+func KeyIsNil() predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldKey)))
+	})
+}
+
+// KeyNotNil applies the NotNil predicate on the "key" field.
+func KeyNotNil() predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldKey)))
+	})
+}
+
+// KeyEqualFold applies the EqualFold predicate on the "key" field.
+func KeyEqualFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldKey), v))
+	})
+}
+
+// KeyContainsFold applies the ContainsFold predicate on the "key" field.
+func KeyContainsFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldKey), v))
+	})
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldName), v))
+	})
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldName), v))
+	})
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldName), v...))
+	})
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldName), v...))
+	})
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldName), v))
+	})
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldName), v))
+	})
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldName), v))
+	})
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldName), v))
+	})
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldName), v))
+	})
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldName), v))
+	})
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldName), v))
+	})
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldName), v))
+	})
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldName), v))
+	})
+}
+
+// EmojiEQ applies the EQ predicate on the "emoji" field.
+func EmojiEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiNEQ applies the NEQ predicate on the "emoji" field.
+func EmojiNEQ(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiIn applies the In predicate on the "emoji" field.
+func EmojiIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldEmoji), v...))
+	})
+}
+
+// EmojiNotIn applies the NotIn predicate on the "emoji" field.
+func EmojiNotIn(vs ...string) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldEmoji), v...))
+	})
+}
+
+// EmojiGT applies the GT predicate on the "emoji" field.
+func EmojiGT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiGTE applies the GTE predicate on the "emoji" field.
+func EmojiGTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiLT applies the LT predicate on the "emoji" field.
+func EmojiLT(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiLTE applies the LTE predicate on the "emoji" field.
+func EmojiLTE(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiContains applies the Contains predicate on the "emoji" field.
+func EmojiContains(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiHasPrefix applies the HasPrefix predicate on the "emoji" field.
+func EmojiHasPrefix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiHasSuffix applies the HasSuffix predicate on the "emoji" field.
+func EmojiHasSuffix(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiEqualFold applies the EqualFold predicate on the "emoji" field.
+func EmojiEqualFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldEmoji), v))
+	})
+}
+
+// EmojiContainsFold applies the ContainsFold predicate on the "emoji" field.
+func EmojiContainsFold(v string) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldEmoji), v))
+	})
+}
+
+// SortOrderEQ applies the EQ predicate on the "sort_order" field.
+func SortOrderEQ(v int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSortOrder), v))
+	})
+}
+
+// SortOrderNEQ applies the NEQ predicate on the "sort_order" field.
+func SortOrderNEQ(v int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldSortOrder), v))
+	})
+}
+
+// SortOrderIn applies the In predicate on the "sort_order" field.
+func SortOrderIn(vs ...int) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldSortOrder), v...))
+	})
+}
+
+// SortOrderNotIn applies the NotIn predicate on the "sort_order" field.
+func SortOrderNotIn(vs ...int) predicate.ItemCategory {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldSortOrder), v...))
+	})
+}
+
+// SortOrderGT applies the GT predicate on the "sort_order" field.
+func SortOrderGT(v int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldSortOrder), v))
+	})
+}
+
+// SortOrderGTE applies the GTE predicate on the "sort_order" field.
+func SortOrderGTE(v int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldSortOrder), v))
+	})
+}
+
+// SortOrderLT applies the LT predicate on the "sort_order" field.
+func SortOrderLT(v int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldSortOrder), v))
+	})
+}
+
+// SortOrderLTE applies the LTE predicate on the "sort_order" field.
+func SortOrderLTE(v int) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldSortOrder), v))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ItemCategory) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ItemCategory) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ItemCategory) predicate.ItemCategory {
+	return predicate.ItemCategory(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,339 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
+)
+
+// ItemCategoryCreate is the builder for creating a ItemCategory entity.
+type ItemCategoryCreate struct {
+	config
+	mutation *ItemCategoryMutation
+	hooks    []Hook
+}
+
+// SetComunityID sets the "comunity_id" field.
+func (icc *ItemCategoryCreate) SetComunityID(s string) *ItemCategoryCreate {
+	icc.mutation.SetComunityID(s)
+	return icc
+}
+
+// SetKey sets the "key" field.
+func (icc *ItemCategoryCreate) SetKey(s string) *ItemCategoryCreate {
+	icc.mutation.SetKey(s)
+	return icc
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (icc *ItemCategoryCreate) SetNillableKey(s *string) *ItemCategoryCreate {
+	if s != nil {
+		icc.SetKey(*s)
+	}
+	return icc
+}
+
+// SetName sets the "name" field.
+func (icc *ItemCategoryCreate) SetName(s string) *ItemCategoryCreate {
+	icc.mutation.SetName(s)
+	return icc
+}
+
+// SetEmoji sets the "emoji" field.
+func (icc *ItemCategoryCreate) SetEmoji(s string) *ItemCategoryCreate {
+	icc.mutation.SetEmoji(s)
+	return icc
+}
+
+// SetNillableEmoji sets the "emoji" field if the given value is not nil.
+func (icc *ItemCategoryCreate) SetNillableEmoji(s *string) *ItemCategoryCreate {
+	if s != nil {
+		icc.SetEmoji(*s)
+	}
+	return icc
+}
+
+// SetSortOrder sets the "sort_order" field.
+func (icc *ItemCategoryCreate) SetSortOrder(i int) *ItemCategoryCreate {
+	icc.mutation.SetSortOrder(i)
+	return icc
+}
+
+// SetNillableSortOrder sets the "sort_order" field if the given value is not nil.
+func (icc *ItemCategoryCreate) SetNillableSortOrder(i *int) *ItemCategoryCreate {
+	if i != nil {
+		icc.SetSortOrder(*i)
+	}
+	return icc
+}
+
+// Mutation returns the ItemCategoryMutation object of the builder.
+func (icc *ItemCategoryCreate) Mutation() *ItemCategoryMutation {
+	return icc.mutation
+}
+
+// Save creates the ItemCategory in the database.
+func (icc *ItemCategoryCreate) Save(ctx context.Context) (*ItemCategory, error) {
+	var (
+		err  error
+		node *ItemCategory
+	)
+	icc.defaults()
+	if len(icc.hooks) == 0 {
+		if err = icc.check(); err != nil {
+			return nil, err
+		}
+		node, err = icc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ItemCategoryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = icc.check(); err != nil {
+				return nil, err
+			}
+			icc.mutation = mutation
+			if node, err = icc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(icc.hooks) - 1; i >= 0; i-- {
+			if icc.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = icc.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, icc.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*ItemCategory)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from ItemCategoryMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (icc *ItemCategoryCreate) SaveX(ctx context.Context) *ItemCategory {
+	v, err := icc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (icc *ItemCategoryCreate) Exec(ctx context.Context) error {
+	_, err := icc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (icc *ItemCategoryCreate) ExecX(ctx context.Context) {
+	if err := icc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (icc *ItemCategoryCreate) defaults() {
+	if _, ok := icc.mutation.Emoji(); !ok {
+		v := itemcategory.DefaultEmoji
+		icc.mutation.SetEmoji(v)
+	}
+	if _, ok := icc.mutation.SortOrder(); !ok {
+		v := itemcategory.DefaultSortOrder
+		icc.mutation.SetSortOrder(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (icc *ItemCategoryCreate) check() error {
+	if _, ok := icc.mutation.ComunityID(); !ok {
+		return &ValidationError{Name: "comunity_id", err: errors.New(`ent: missing required field "ItemCategory.comunity_id"`)}
+	}
+	if v, ok := icc.mutation.ComunityID(); ok {
+		if err := itemcategory.ComunityIDValidator(v); err != nil {
+			return &ValidationError{Name: "comunity_id", err: fmt.Errorf(`ent: validator failed for field "ItemCategory.comunity_id": %w`, err)}
+		}
+	}
+	if _, ok := icc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "ItemCategory.name"`)}
+	}
+	if v, ok := icc.mutation.Name(); ok {
+		if err := itemcategory.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ItemCategory.name": %w`, err)}
+		}
+	}
+	if _, ok := icc.mutation.Emoji(); !ok {
+		return &ValidationError{Name: "emoji", err: errors.New(`ent: missing required field "ItemCategory.emoji"`)}
+	}
+	if _, ok := icc.mutation.SortOrder(); !ok {
+		return &ValidationError{Name: "sort_order", err: errors.New(`ent: missing required field "ItemCategory.sort_order"`)}
+	}
+	return nil
+}
+
+func (icc *ItemCategoryCreate) sqlSave(ctx context.Context) (*ItemCategory, error) {
+	_node, _spec := icc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, icc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (icc *ItemCategoryCreate) createSpec() (*ItemCategory, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ItemCategory{config: icc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: itemcategory.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: itemcategory.FieldID,
+			},
+		}
+	)
+	if value, ok := icc.mutation.ComunityID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldComunityID,
+		})
+		_node.ComunityID = value
+	}
+	if value, ok := icc.mutation.Key(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldKey,
+		})
+		_node.Key = value
+	}
+	if value, ok := icc.mutation.Name(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldName,
+		})
+		_node.Name = value
+	}
+	if value, ok := icc.mutation.Emoji(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldEmoji,
+		})
+		_node.Emoji = value
+	}
+	if value, ok := icc.mutation.SortOrder(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: itemcategory.FieldSortOrder,
+		})
+		_node.SortOrder = value
+	}
+	return _node, _spec
+}
+
+// ItemCategoryCreateBulk is the builder for creating many ItemCategory entities in bulk.
+type ItemCategoryCreateBulk struct {
+	config
+	builders []*ItemCategoryCreate
+}
+
+// Save creates the ItemCategory entities in the database.
+func (iccb *ItemCategoryCreateBulk) Save(ctx context.Context) ([]*ItemCategory, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(iccb.builders))
+	nodes := make([]*ItemCategory, len(iccb.builders))
+	mutators := make([]Mutator, len(iccb.builders))
+	for i := range iccb.builders {
+		func(i int, root context.Context) {
+			builder := iccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ItemCategoryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, iccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, iccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, iccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iccb *ItemCategoryCreateBulk) SaveX(ctx context.Context) []*ItemCategory {
+	v, err := iccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (iccb *ItemCategoryCreateBulk) Exec(ctx context.Context) error {
+	_, err := iccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iccb *ItemCategoryCreateBulk) ExecX(ctx context.Context) {
+	if err := iccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
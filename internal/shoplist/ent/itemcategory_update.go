@@ -0,0 +1,481 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/predicate"
+)
+
+// ItemCategoryUpdate is the builder for updating ItemCategory entities.
+type ItemCategoryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ItemCategoryMutation
+}
+
+// Where appends a list predicates to the ItemCategoryUpdate builder.
+func (icu *ItemCategoryUpdate) Where(ps ...predicate.ItemCategory) *ItemCategoryUpdate {
+	icu.mutation.Where(ps...)
+	return icu
+}
+
+// SetKey sets the "key" field.
+func (icu *ItemCategoryUpdate) SetKey(s string) *ItemCategoryUpdate {
+	icu.mutation.SetKey(s)
+	return icu
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (icu *ItemCategoryUpdate) SetNillableKey(s *string) *ItemCategoryUpdate {
+	if s != nil {
+		icu.SetKey(*s)
+	}
+	return icu
+}
+
+// ClearKey clears the value of the "key" field.
+func (icu *ItemCategoryUpdate) ClearKey() *ItemCategoryUpdate {
+	icu.mutation.ClearKey()
+	return icu
+}
+
+// SetName sets the "name" field.
+func (icu *ItemCategoryUpdate) SetName(s string) *ItemCategoryUpdate {
+	icu.mutation.SetName(s)
+	return icu
+}
+
+// SetEmoji sets the "emoji" field.
+func (icu *ItemCategoryUpdate) SetEmoji(s string) *ItemCategoryUpdate {
+	icu.mutation.SetEmoji(s)
+	return icu
+}
+
+// SetNillableEmoji sets the "emoji" field if the given value is not nil.
+func (icu *ItemCategoryUpdate) SetNillableEmoji(s *string) *ItemCategoryUpdate {
+	if s != nil {
+		icu.SetEmoji(*s)
+	}
+	return icu
+}
+
+// SetSortOrder sets the "sort_order" field.
+func (icu *ItemCategoryUpdate) SetSortOrder(i int) *ItemCategoryUpdate {
+	icu.mutation.ResetSortOrder()
+	icu.mutation.SetSortOrder(i)
+	return icu
+}
+
+// SetNillableSortOrder sets the "sort_order" field if the given value is not nil.
+func (icu *ItemCategoryUpdate) SetNillableSortOrder(i *int) *ItemCategoryUpdate {
+	if i != nil {
+		icu.SetSortOrder(*i)
+	}
+	return icu
+}
+
+// AddSortOrder adds i to the "sort_order" field.
+func (icu *ItemCategoryUpdate) AddSortOrder(i int) *ItemCategoryUpdate {
+	icu.mutation.AddSortOrder(i)
+	return icu
+}
+
+// Mutation returns the ItemCategoryMutation object of the builder.
+func (icu *ItemCategoryUpdate) Mutation() *ItemCategoryMutation {
+	return icu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (icu *ItemCategoryUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(icu.hooks) == 0 {
+		if err = icu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = icu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ItemCategoryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = icu.check(); err != nil {
+				return 0, err
+			}
+			icu.mutation = mutation
+			affected, err = icu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(icu.hooks) - 1; i >= 0; i-- {
+			if icu.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = icu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, icu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (icu *ItemCategoryUpdate) SaveX(ctx context.Context) int {
+	affected, err := icu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (icu *ItemCategoryUpdate) Exec(ctx context.Context) error {
+	_, err := icu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (icu *ItemCategoryUpdate) ExecX(ctx context.Context) {
+	if err := icu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (icu *ItemCategoryUpdate) check() error {
+	if v, ok := icu.mutation.Name(); ok {
+		if err := itemcategory.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ItemCategory.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (icu *ItemCategoryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   itemcategory.Table,
+			Columns: itemcategory.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: itemcategory.FieldID,
+			},
+		},
+	}
+	if ps := icu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := icu.mutation.Key(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldKey,
+		})
+	}
+	if icu.mutation.KeyCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: itemcategory.FieldKey,
+		})
+	}
+	if value, ok := icu.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldName,
+		})
+	}
+	if value, ok := icu.mutation.Emoji(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldEmoji,
+		})
+	}
+	if value, ok := icu.mutation.SortOrder(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: itemcategory.FieldSortOrder,
+		})
+	}
+	if value, ok := icu.mutation.AddedSortOrder(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: itemcategory.FieldSortOrder,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, icu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{itemcategory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// ItemCategoryUpdateOne is the builder for updating a single ItemCategory entity.
+type ItemCategoryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ItemCategoryMutation
+}
+
+// SetKey sets the "key" field.
+func (icuo *ItemCategoryUpdateOne) SetKey(s string) *ItemCategoryUpdateOne {
+	icuo.mutation.SetKey(s)
+	return icuo
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (icuo *ItemCategoryUpdateOne) SetNillableKey(s *string) *ItemCategoryUpdateOne {
+	if s != nil {
+		icuo.SetKey(*s)
+	}
+	return icuo
+}
+
+// ClearKey clears the value of the "key" field.
+func (icuo *ItemCategoryUpdateOne) ClearKey() *ItemCategoryUpdateOne {
+	icuo.mutation.ClearKey()
+	return icuo
+}
+
+// SetName sets the "name" field.
+func (icuo *ItemCategoryUpdateOne) SetName(s string) *ItemCategoryUpdateOne {
+	icuo.mutation.SetName(s)
+	return icuo
+}
+
+// SetEmoji sets the "emoji" field.
+func (icuo *ItemCategoryUpdateOne) SetEmoji(s string) *ItemCategoryUpdateOne {
+	icuo.mutation.SetEmoji(s)
+	return icuo
+}
+
+// SetNillableEmoji sets the "emoji" field if the given value is not nil.
+func (icuo *ItemCategoryUpdateOne) SetNillableEmoji(s *string) *ItemCategoryUpdateOne {
+	if s != nil {
+		icuo.SetEmoji(*s)
+	}
+	return icuo
+}
+
+// SetSortOrder sets the "sort_order" field.
+func (icuo *ItemCategoryUpdateOne) SetSortOrder(i int) *ItemCategoryUpdateOne {
+	icuo.mutation.ResetSortOrder()
+	icuo.mutation.SetSortOrder(i)
+	return icuo
+}
+
+// SetNillableSortOrder sets the "sort_order" field if the given value is not nil.
+func (icuo *ItemCategoryUpdateOne) SetNillableSortOrder(i *int) *ItemCategoryUpdateOne {
+	if i != nil {
+		icuo.SetSortOrder(*i)
+	}
+	return icuo
+}
+
+// AddSortOrder adds i to the "sort_order" field.
+func (icuo *ItemCategoryUpdateOne) AddSortOrder(i int) *ItemCategoryUpdateOne {
+	icuo.mutation.AddSortOrder(i)
+	return icuo
+}
+
+// Mutation returns the ItemCategoryMutation object of the builder.
+func (icuo *ItemCategoryUpdateOne) Mutation() *ItemCategoryMutation {
+	return icuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (icuo *ItemCategoryUpdateOne) Select(field string, fields ...string) *ItemCategoryUpdateOne {
+	icuo.fields = append([]string{field}, fields...)
+	return icuo
+}
+
+// Save executes the query and returns the updated ItemCategory entity.
+func (icuo *ItemCategoryUpdateOne) Save(ctx context.Context) (*ItemCategory, error) {
+	var (
+		err  error
+		node *ItemCategory
+	)
+	if len(icuo.hooks) == 0 {
+		if err = icuo.check(); err != nil {
+			return nil, err
+		}
+		node, err = icuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ItemCategoryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = icuo.check(); err != nil {
+				return nil, err
+			}
+			icuo.mutation = mutation
+			node, err = icuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(icuo.hooks) - 1; i >= 0; i-- {
+			if icuo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = icuo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, icuo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*ItemCategory)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from ItemCategoryMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (icuo *ItemCategoryUpdateOne) SaveX(ctx context.Context) *ItemCategory {
+	node, err := icuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (icuo *ItemCategoryUpdateOne) Exec(ctx context.Context) error {
+	_, err := icuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (icuo *ItemCategoryUpdateOne) ExecX(ctx context.Context) {
+	if err := icuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (icuo *ItemCategoryUpdateOne) check() error {
+	if v, ok := icuo.mutation.Name(); ok {
+		if err := itemcategory.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ItemCategory.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (icuo *ItemCategoryUpdateOne) sqlSave(ctx context.Context) (_node *ItemCategory, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   itemcategory.Table,
+			Columns: itemcategory.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: itemcategory.FieldID,
+			},
+		},
+	}
+	id, ok := icuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ItemCategory.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := icuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, itemcategory.FieldID)
+		for _, f := range fields {
+			if !itemcategory.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != itemcategory.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := icuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := icuo.mutation.Key(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldKey,
+		})
+	}
+	if icuo.mutation.KeyCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: itemcategory.FieldKey,
+		})
+	}
+	if value, ok := icuo.mutation.Name(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldName,
+		})
+	}
+	if value, ok := icuo.mutation.Emoji(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: itemcategory.FieldEmoji,
+		})
+	}
+	if value, ok := icuo.mutation.SortOrder(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: itemcategory.FieldSortOrder,
+		})
+	}
+	if value, ok := icuo.mutation.AddedSortOrder(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: itemcategory.FieldSortOrder,
+		})
+	}
+	_node = &ItemCategory{config: icuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, icuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{itemcategory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
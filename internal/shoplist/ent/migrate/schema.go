@@ -8,11 +8,37 @@ import (
 )
 
 var (
+	// AuditsColumns holds the columns for the "audits" table.
+	AuditsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "user_id", Type: field.TypeInt},
+		{Name: "telegram_id", Type: field.TypeInt64},
+		{Name: "comunity_id", Type: field.TypeString},
+		{Name: "kind", Type: field.TypeString},
+		{Name: "action", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeString},
+		{Name: "created", Type: field.TypeTime},
+		{Name: "undone", Type: field.TypeBool, Default: false},
+	}
+	// AuditsTable holds the schema information for the "audits" table.
+	AuditsTable = &schema.Table{
+		Name:       "audits",
+		Columns:    AuditsColumns,
+		PrimaryKey: []*schema.Column{AuditsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "audit_user_id_kind_created",
+				Unique:  false,
+				Columns: []*schema.Column{AuditsColumns[1], AuditsColumns[4], AuditsColumns[7]},
+			},
+		},
+	}
 	// ItemsColumns holds the columns for the "items" table.
 	ItemsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "product_name", Type: field.TypeString},
 		{Name: "quantity", Type: field.TypeInt, Default: 1},
+		{Name: "unit", Type: field.TypeString, Default: ""},
 		{Name: "category_id", Type: field.TypeInt, Default: 0},
 		{Name: "complete", Type: field.TypeBool, Default: false},
 		{Name: "shopping_item", Type: field.TypeInt, Nullable: true},
@@ -25,12 +51,69 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "items_shoppings_item",
-				Columns:    []*schema.Column{ItemsColumns[5]},
+				Columns:    []*schema.Column{ItemsColumns[6]},
 				RefColumns: []*schema.Column{ShoppingsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 		},
 	}
+	// ItemCategoriesColumns holds the columns for the "item_categories" table.
+	ItemCategoriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "comunity_id", Type: field.TypeString},
+		{Name: "key", Type: field.TypeString, Nullable: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "emoji", Type: field.TypeString, Default: ""},
+		{Name: "sort_order", Type: field.TypeInt, Default: 0},
+	}
+	// ItemCategoriesTable holds the schema information for the "item_categories" table.
+	ItemCategoriesTable = &schema.Table{
+		Name:       "item_categories",
+		Columns:    ItemCategoriesColumns,
+		PrimaryKey: []*schema.Column{ItemCategoriesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "itemcategory_comunity_id_sort_order",
+				Unique:  false,
+				Columns: []*schema.Column{ItemCategoriesColumns[1], ItemCategoriesColumns[5]},
+			},
+			{
+				Name:    "itemcategory_comunity_id_key",
+				Unique:  true,
+				Columns: []*schema.Column{ItemCategoriesColumns[1], ItemCategoriesColumns[2]},
+			},
+			{
+				Name:    "itemcategory_comunity_id_name",
+				Unique:  true,
+				Columns: []*schema.Column{ItemCategoriesColumns[1], ItemCategoriesColumns[3]},
+			},
+		},
+	}
+	// ProductsColumns holds the columns for the "products" table.
+	ProductsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "comunity_id", Type: field.TypeString},
+		{Name: "name", Type: field.TypeString},
+		{Name: "title", Type: field.TypeString},
+		{Name: "category_id", Type: field.TypeInt, Default: 0},
+		{Name: "added_count", Type: field.TypeInt, Default: 0},
+		{Name: "last_added", Type: field.TypeTime},
+		{Name: "bought_count", Type: field.TypeInt, Default: 0},
+		{Name: "last_bought", Type: field.TypeTime, Nullable: true},
+	}
+	// ProductsTable holds the schema information for the "products" table.
+	ProductsTable = &schema.Table{
+		Name:       "products",
+		Columns:    ProductsColumns,
+		PrimaryKey: []*schema.Column{ProductsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "product_comunity_id_name",
+				Unique:  true,
+				Columns: []*schema.Column{ProductsColumns[1], ProductsColumns[2]},
+			},
+		},
+	}
 	// ShopsColumns holds the columns for the "shops" table.
 	ShopsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -80,6 +163,12 @@ var (
 		{Name: "comunity_id", Type: field.TypeString},
 		{Name: "token", Type: field.TypeString},
 		{Name: "chat_id", Type: field.TypeInt64},
+		{Name: "language", Type: field.TypeString, Nullable: true},
+		{Name: "active", Type: field.TypeBool, Default: true},
+		{Name: "notify", Type: field.TypeEnum, Enums: []string{"immediate", "digest", "off"}, Default: "immediate"},
+		{Name: "digest_minutes", Type: field.TypeInt, Default: 60},
+		{Name: "digest_time", Type: field.TypeString, Nullable: true},
+		{Name: "digest_zone", Type: field.TypeString, Nullable: true},
 	}
 	// UsersTable holds the schema information for the "users" table.
 	UsersTable = &schema.Table{
@@ -96,7 +185,10 @@ var (
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AuditsTable,
 		ItemsTable,
+		ItemCategoriesTable,
+		ProductsTable,
 		ShopsTable,
 		ShoppingsTable,
 		UsersTable,
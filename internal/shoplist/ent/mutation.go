@@ -9,8 +9,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/audit"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/item"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/predicate"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shop"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shopping"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/user"
@@ -27,43 +30,2057 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeItem     = "Item"
-	TypeShop     = "Shop"
-	TypeShopping = "Shopping"
-	TypeUser     = "User"
+	TypeAudit        = "Audit"
+	TypeItem         = "Item"
+	TypeItemCategory = "ItemCategory"
+	TypeProduct      = "Product"
+	TypeShop         = "Shop"
+	TypeShopping     = "Shopping"
+	TypeUser         = "User"
 )
 
+// AuditMutation represents an operation that mutates the Audit nodes in the graph.
+type AuditMutation struct {
+	config
+	op             Op
+	typ            string
+	id             *int
+	user_id        *int
+	adduser_id     *int
+	telegram_id    *int64
+	addtelegram_id *int64
+	comunity_id    *string
+	kind           *string
+	action         *string
+	payload        *string
+	created        *time.Time
+	undone         *bool
+	clearedFields  map[string]struct{}
+	done           bool
+	oldValue       func(context.Context) (*Audit, error)
+	predicates     []predicate.Audit
+}
+
+var _ ent.Mutation = (*AuditMutation)(nil)
+
+// auditOption allows management of the mutation configuration using functional options.
+type auditOption func(*AuditMutation)
+
+// newAuditMutation creates new mutation for the Audit entity.
+func newAuditMutation(c config, op Op, opts ...auditOption) *AuditMutation {
+	m := &AuditMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeAudit,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withAuditID sets the ID field of the mutation.
+func withAuditID(id int) auditOption {
+	return func(m *AuditMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Audit
+		)
+		m.oldValue = func(ctx context.Context) (*Audit, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Audit.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withAudit sets the old Audit of the mutation.
+func withAudit(node *Audit) auditOption {
+	return func(m *AuditMutation) {
+		m.oldValue = func(context.Context) (*Audit, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m AuditMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m AuditMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *AuditMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *AuditMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Audit.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUserID sets the "user_id" field.
+func (m *AuditMutation) SetUserID(i int) {
+	m.user_id = &i
+	m.adduser_id = nil
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *AuditMutation) UserID() (r int, exists bool) {
+	v := m.user_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldUserID(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// AddUserID adds i to the "user_id" field.
+func (m *AuditMutation) AddUserID(i int) {
+	if m.adduser_id != nil {
+		*m.adduser_id += i
+	} else {
+		m.adduser_id = &i
+	}
+}
+
+// AddedUserID returns the value that was added to the "user_id" field in this mutation.
+func (m *AuditMutation) AddedUserID() (r int, exists bool) {
+	v := m.adduser_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *AuditMutation) ResetUserID() {
+	m.user_id = nil
+	m.adduser_id = nil
+}
+
+// SetTelegramID sets the "telegram_id" field.
+func (m *AuditMutation) SetTelegramID(i int64) {
+	m.telegram_id = &i
+	m.addtelegram_id = nil
+}
+
+// TelegramID returns the value of the "telegram_id" field in the mutation.
+func (m *AuditMutation) TelegramID() (r int64, exists bool) {
+	v := m.telegram_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTelegramID returns the old "telegram_id" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldTelegramID(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTelegramID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTelegramID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTelegramID: %w", err)
+	}
+	return oldValue.TelegramID, nil
+}
+
+// AddTelegramID adds i to the "telegram_id" field.
+func (m *AuditMutation) AddTelegramID(i int64) {
+	if m.addtelegram_id != nil {
+		*m.addtelegram_id += i
+	} else {
+		m.addtelegram_id = &i
+	}
+}
+
+// AddedTelegramID returns the value that was added to the "telegram_id" field in this mutation.
+func (m *AuditMutation) AddedTelegramID() (r int64, exists bool) {
+	v := m.addtelegram_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTelegramID resets all changes to the "telegram_id" field.
+func (m *AuditMutation) ResetTelegramID() {
+	m.telegram_id = nil
+	m.addtelegram_id = nil
+}
+
+// SetComunityID sets the "comunity_id" field.
+func (m *AuditMutation) SetComunityID(s string) {
+	m.comunity_id = &s
+}
+
+// ComunityID returns the value of the "comunity_id" field in the mutation.
+func (m *AuditMutation) ComunityID() (r string, exists bool) {
+	v := m.comunity_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldComunityID returns the old "comunity_id" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldComunityID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldComunityID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldComunityID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldComunityID: %w", err)
+	}
+	return oldValue.ComunityID, nil
+}
+
+// ResetComunityID resets all changes to the "comunity_id" field.
+func (m *AuditMutation) ResetComunityID() {
+	m.comunity_id = nil
+}
+
+// SetKind sets the "kind" field.
+func (m *AuditMutation) SetKind(s string) {
+	m.kind = &s
+}
+
+// Kind returns the value of the "kind" field in the mutation.
+func (m *AuditMutation) Kind() (r string, exists bool) {
+	v := m.kind
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldKind returns the old "kind" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldKind(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKind is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKind requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKind: %w", err)
+	}
+	return oldValue.Kind, nil
+}
+
+// ResetKind resets all changes to the "kind" field.
+func (m *AuditMutation) ResetKind() {
+	m.kind = nil
+}
+
+// SetAction sets the "action" field.
+func (m *AuditMutation) SetAction(s string) {
+	m.action = &s
+}
+
+// Action returns the value of the "action" field in the mutation.
+func (m *AuditMutation) Action() (r string, exists bool) {
+	v := m.action
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAction returns the old "action" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldAction(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAction is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAction requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAction: %w", err)
+	}
+	return oldValue.Action, nil
+}
+
+// ResetAction resets all changes to the "action" field.
+func (m *AuditMutation) ResetAction() {
+	m.action = nil
+}
+
+// SetPayload sets the "payload" field.
+func (m *AuditMutation) SetPayload(s string) {
+	m.payload = &s
+}
+
+// Payload returns the value of the "payload" field in the mutation.
+func (m *AuditMutation) Payload() (r string, exists bool) {
+	v := m.payload
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPayload returns the old "payload" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldPayload(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPayload requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPayload: %w", err)
+	}
+	return oldValue.Payload, nil
+}
+
+// ResetPayload resets all changes to the "payload" field.
+func (m *AuditMutation) ResetPayload() {
+	m.payload = nil
+}
+
+// SetCreated sets the "created" field.
+func (m *AuditMutation) SetCreated(t time.Time) {
+	m.created = &t
+}
+
+// Created returns the value of the "created" field in the mutation.
+func (m *AuditMutation) Created() (r time.Time, exists bool) {
+	v := m.created
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreated returns the old "created" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldCreated(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreated is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreated requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreated: %w", err)
+	}
+	return oldValue.Created, nil
+}
+
+// ResetCreated resets all changes to the "created" field.
+func (m *AuditMutation) ResetCreated() {
+	m.created = nil
+}
+
+// SetUndone sets the "undone" field.
+func (m *AuditMutation) SetUndone(b bool) {
+	m.undone = &b
+}
+
+// Undone returns the value of the "undone" field in the mutation.
+func (m *AuditMutation) Undone() (r bool, exists bool) {
+	v := m.undone
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUndone returns the old "undone" field's value of the Audit entity.
+// If the Audit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditMutation) OldUndone(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUndone is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUndone requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUndone: %w", err)
+	}
+	return oldValue.Undone, nil
+}
+
+// ResetUndone resets all changes to the "undone" field.
+func (m *AuditMutation) ResetUndone() {
+	m.undone = nil
+}
+
+// Where appends a list predicates to the AuditMutation builder.
+func (m *AuditMutation) Where(ps ...predicate.Audit) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *AuditMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (Audit).
+func (m *AuditMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *AuditMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.user_id != nil {
+		fields = append(fields, audit.FieldUserID)
+	}
+	if m.telegram_id != nil {
+		fields = append(fields, audit.FieldTelegramID)
+	}
+	if m.comunity_id != nil {
+		fields = append(fields, audit.FieldComunityID)
+	}
+	if m.kind != nil {
+		fields = append(fields, audit.FieldKind)
+	}
+	if m.action != nil {
+		fields = append(fields, audit.FieldAction)
+	}
+	if m.payload != nil {
+		fields = append(fields, audit.FieldPayload)
+	}
+	if m.created != nil {
+		fields = append(fields, audit.FieldCreated)
+	}
+	if m.undone != nil {
+		fields = append(fields, audit.FieldUndone)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *AuditMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case audit.FieldUserID:
+		return m.UserID()
+	case audit.FieldTelegramID:
+		return m.TelegramID()
+	case audit.FieldComunityID:
+		return m.ComunityID()
+	case audit.FieldKind:
+		return m.Kind()
+	case audit.FieldAction:
+		return m.Action()
+	case audit.FieldPayload:
+		return m.Payload()
+	case audit.FieldCreated:
+		return m.Created()
+	case audit.FieldUndone:
+		return m.Undone()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *AuditMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case audit.FieldUserID:
+		return m.OldUserID(ctx)
+	case audit.FieldTelegramID:
+		return m.OldTelegramID(ctx)
+	case audit.FieldComunityID:
+		return m.OldComunityID(ctx)
+	case audit.FieldKind:
+		return m.OldKind(ctx)
+	case audit.FieldAction:
+		return m.OldAction(ctx)
+	case audit.FieldPayload:
+		return m.OldPayload(ctx)
+	case audit.FieldCreated:
+		return m.OldCreated(ctx)
+	case audit.FieldUndone:
+		return m.OldUndone(ctx)
+	}
+	return nil, fmt.Errorf("unknown Audit field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *AuditMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case audit.FieldUserID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case audit.FieldTelegramID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTelegramID(v)
+		return nil
+	case audit.FieldComunityID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetComunityID(v)
+		return nil
+	case audit.FieldKind:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKind(v)
+		return nil
+	case audit.FieldAction:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAction(v)
+		return nil
+	case audit.FieldPayload:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPayload(v)
+		return nil
+	case audit.FieldCreated:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreated(v)
+		return nil
+	case audit.FieldUndone:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUndone(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Audit field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *AuditMutation) AddedFields() []string {
+	var fields []string
+	if m.adduser_id != nil {
+		fields = append(fields, audit.FieldUserID)
+	}
+	if m.addtelegram_id != nil {
+		fields = append(fields, audit.FieldTelegramID)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *AuditMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case audit.FieldUserID:
+		return m.AddedUserID()
+	case audit.FieldTelegramID:
+		return m.AddedTelegramID()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *AuditMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case audit.FieldUserID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUserID(v)
+		return nil
+	case audit.FieldTelegramID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTelegramID(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Audit numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *AuditMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *AuditMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *AuditMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Audit nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *AuditMutation) ResetField(name string) error {
+	switch name {
+	case audit.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case audit.FieldTelegramID:
+		m.ResetTelegramID()
+		return nil
+	case audit.FieldComunityID:
+		m.ResetComunityID()
+		return nil
+	case audit.FieldKind:
+		m.ResetKind()
+		return nil
+	case audit.FieldAction:
+		m.ResetAction()
+		return nil
+	case audit.FieldPayload:
+		m.ResetPayload()
+		return nil
+	case audit.FieldCreated:
+		m.ResetCreated()
+		return nil
+	case audit.FieldUndone:
+		m.ResetUndone()
+		return nil
+	}
+	return fmt.Errorf("unknown Audit field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *AuditMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *AuditMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *AuditMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *AuditMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *AuditMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *AuditMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *AuditMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Audit unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *AuditMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Audit edge %s", name)
+}
+
 // ItemMutation represents an operation that mutates the Item nodes in the graph.
 type ItemMutation struct {
 	config
 	op              Op
 	typ             string
 	id              *int
-	product_name    *string
-	quantity        *int
-	addquantity     *int
+	product_name    *string
+	quantity        *int
+	addquantity     *int
+	unit            *string
+	category_id     *int
+	addcategory_id  *int
+	complete        *bool
+	clearedFields   map[string]struct{}
+	shopping        *int
+	clearedshopping bool
+	done            bool
+	oldValue        func(context.Context) (*Item, error)
+	predicates      []predicate.Item
+}
+
+var _ ent.Mutation = (*ItemMutation)(nil)
+
+// itemOption allows management of the mutation configuration using functional options.
+type itemOption func(*ItemMutation)
+
+// newItemMutation creates new mutation for the Item entity.
+func newItemMutation(c config, op Op, opts ...itemOption) *ItemMutation {
+	m := &ItemMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeItem,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withItemID sets the ID field of the mutation.
+func withItemID(id int) itemOption {
+	return func(m *ItemMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Item
+		)
+		m.oldValue = func(ctx context.Context) (*Item, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Item.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withItem sets the old Item of the mutation.
+func withItem(node *Item) itemOption {
+	return func(m *ItemMutation) {
+		m.oldValue = func(context.Context) (*Item, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ItemMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ItemMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ItemMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ItemMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Item.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetProductName sets the "product_name" field.
+func (m *ItemMutation) SetProductName(s string) {
+	m.product_name = &s
+}
+
+// ProductName returns the value of the "product_name" field in the mutation.
+func (m *ItemMutation) ProductName() (r string, exists bool) {
+	v := m.product_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProductName returns the old "product_name" field's value of the Item entity.
+// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemMutation) OldProductName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProductName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProductName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProductName: %w", err)
+	}
+	return oldValue.ProductName, nil
+}
+
+// ResetProductName resets all changes to the "product_name" field.
+func (m *ItemMutation) ResetProductName() {
+	m.product_name = nil
+}
+
+// SetQuantity sets the "quantity" field.
+func (m *ItemMutation) SetQuantity(i int) {
+	m.quantity = &i
+	m.addquantity = nil
+}
+
+// Quantity returns the value of the "quantity" field in the mutation.
+func (m *ItemMutation) Quantity() (r int, exists bool) {
+	v := m.quantity
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQuantity returns the old "quantity" field's value of the Item entity.
+// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemMutation) OldQuantity(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQuantity is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQuantity requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQuantity: %w", err)
+	}
+	return oldValue.Quantity, nil
+}
+
+// AddQuantity adds i to the "quantity" field.
+func (m *ItemMutation) AddQuantity(i int) {
+	if m.addquantity != nil {
+		*m.addquantity += i
+	} else {
+		m.addquantity = &i
+	}
+}
+
+// AddedQuantity returns the value that was added to the "quantity" field in this mutation.
+func (m *ItemMutation) AddedQuantity() (r int, exists bool) {
+	v := m.addquantity
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetQuantity resets all changes to the "quantity" field.
+func (m *ItemMutation) ResetQuantity() {
+	m.quantity = nil
+	m.addquantity = nil
+}
+
+// SetUnit sets the "unit" field.
+func (m *ItemMutation) SetUnit(s string) {
+	m.unit = &s
+}
+
+// Unit returns the value of the "unit" field in the mutation.
+func (m *ItemMutation) Unit() (r string, exists bool) {
+	v := m.unit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUnit returns the old "unit" field's value of the Item entity.
+// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemMutation) OldUnit(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUnit is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUnit requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUnit: %w", err)
+	}
+	return oldValue.Unit, nil
+}
+
+// ResetUnit resets all changes to the "unit" field.
+func (m *ItemMutation) ResetUnit() {
+	m.unit = nil
+}
+
+// SetCategoryID sets the "category_id" field.
+func (m *ItemMutation) SetCategoryID(i int) {
+	m.category_id = &i
+	m.addcategory_id = nil
+}
+
+// CategoryID returns the value of the "category_id" field in the mutation.
+func (m *ItemMutation) CategoryID() (r int, exists bool) {
+	v := m.category_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCategoryID returns the old "category_id" field's value of the Item entity.
+// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemMutation) OldCategoryID(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCategoryID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCategoryID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCategoryID: %w", err)
+	}
+	return oldValue.CategoryID, nil
+}
+
+// AddCategoryID adds i to the "category_id" field.
+func (m *ItemMutation) AddCategoryID(i int) {
+	if m.addcategory_id != nil {
+		*m.addcategory_id += i
+	} else {
+		m.addcategory_id = &i
+	}
+}
+
+// AddedCategoryID returns the value that was added to the "category_id" field in this mutation.
+func (m *ItemMutation) AddedCategoryID() (r int, exists bool) {
+	v := m.addcategory_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCategoryID resets all changes to the "category_id" field.
+func (m *ItemMutation) ResetCategoryID() {
+	m.category_id = nil
+	m.addcategory_id = nil
+}
+
+// SetComplete sets the "complete" field.
+func (m *ItemMutation) SetComplete(b bool) {
+	m.complete = &b
+}
+
+// Complete returns the value of the "complete" field in the mutation.
+func (m *ItemMutation) Complete() (r bool, exists bool) {
+	v := m.complete
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldComplete returns the old "complete" field's value of the Item entity.
+// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemMutation) OldComplete(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldComplete is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldComplete requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldComplete: %w", err)
+	}
+	return oldValue.Complete, nil
+}
+
+// ResetComplete resets all changes to the "complete" field.
+func (m *ItemMutation) ResetComplete() {
+	m.complete = nil
+}
+
+// SetShoppingID sets the "shopping" edge to the Shopping entity by id.
+func (m *ItemMutation) SetShoppingID(id int) {
+	m.shopping = &id
+}
+
+// ClearShopping clears the "shopping" edge to the Shopping entity.
+func (m *ItemMutation) ClearShopping() {
+	m.clearedshopping = true
+}
+
+// ShoppingCleared reports if the "shopping" edge to the Shopping entity was cleared.
+func (m *ItemMutation) ShoppingCleared() bool {
+	return m.clearedshopping
+}
+
+// ShoppingID returns the "shopping" edge ID in the mutation.
+func (m *ItemMutation) ShoppingID() (id int, exists bool) {
+	if m.shopping != nil {
+		return *m.shopping, true
+	}
+	return
+}
+
+// ShoppingIDs returns the "shopping" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ShoppingID instead. It exists only for internal usage by the builders.
+func (m *ItemMutation) ShoppingIDs() (ids []int) {
+	if id := m.shopping; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetShopping resets all changes to the "shopping" edge.
+func (m *ItemMutation) ResetShopping() {
+	m.shopping = nil
+	m.clearedshopping = false
+}
+
+// Where appends a list predicates to the ItemMutation builder.
+func (m *ItemMutation) Where(ps ...predicate.Item) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *ItemMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (Item).
+func (m *ItemMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ItemMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m.product_name != nil {
+		fields = append(fields, item.FieldProductName)
+	}
+	if m.quantity != nil {
+		fields = append(fields, item.FieldQuantity)
+	}
+	if m.unit != nil {
+		fields = append(fields, item.FieldUnit)
+	}
+	if m.category_id != nil {
+		fields = append(fields, item.FieldCategoryID)
+	}
+	if m.complete != nil {
+		fields = append(fields, item.FieldComplete)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ItemMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case item.FieldProductName:
+		return m.ProductName()
+	case item.FieldQuantity:
+		return m.Quantity()
+	case item.FieldUnit:
+		return m.Unit()
+	case item.FieldCategoryID:
+		return m.CategoryID()
+	case item.FieldComplete:
+		return m.Complete()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ItemMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case item.FieldProductName:
+		return m.OldProductName(ctx)
+	case item.FieldQuantity:
+		return m.OldQuantity(ctx)
+	case item.FieldUnit:
+		return m.OldUnit(ctx)
+	case item.FieldCategoryID:
+		return m.OldCategoryID(ctx)
+	case item.FieldComplete:
+		return m.OldComplete(ctx)
+	}
+	return nil, fmt.Errorf("unknown Item field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ItemMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case item.FieldProductName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProductName(v)
+		return nil
+	case item.FieldQuantity:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQuantity(v)
+		return nil
+	case item.FieldUnit:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUnit(v)
+		return nil
+	case item.FieldCategoryID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCategoryID(v)
+		return nil
+	case item.FieldComplete:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetComplete(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Item field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ItemMutation) AddedFields() []string {
+	var fields []string
+	if m.addquantity != nil {
+		fields = append(fields, item.FieldQuantity)
+	}
+	if m.addcategory_id != nil {
+		fields = append(fields, item.FieldCategoryID)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ItemMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case item.FieldQuantity:
+		return m.AddedQuantity()
+	case item.FieldCategoryID:
+		return m.AddedCategoryID()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ItemMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case item.FieldQuantity:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddQuantity(v)
+		return nil
+	case item.FieldCategoryID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCategoryID(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Item numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ItemMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ItemMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ItemMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Item nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ItemMutation) ResetField(name string) error {
+	switch name {
+	case item.FieldProductName:
+		m.ResetProductName()
+		return nil
+	case item.FieldQuantity:
+		m.ResetQuantity()
+		return nil
+	case item.FieldUnit:
+		m.ResetUnit()
+		return nil
+	case item.FieldCategoryID:
+		m.ResetCategoryID()
+		return nil
+	case item.FieldComplete:
+		m.ResetComplete()
+		return nil
+	}
+	return fmt.Errorf("unknown Item field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ItemMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.shopping != nil {
+		edges = append(edges, item.EdgeShopping)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ItemMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case item.EdgeShopping:
+		if id := m.shopping; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ItemMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ItemMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ItemMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedshopping {
+		edges = append(edges, item.EdgeShopping)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ItemMutation) EdgeCleared(name string) bool {
+	switch name {
+	case item.EdgeShopping:
+		return m.clearedshopping
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ItemMutation) ClearEdge(name string) error {
+	switch name {
+	case item.EdgeShopping:
+		m.ClearShopping()
+		return nil
+	}
+	return fmt.Errorf("unknown Item unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ItemMutation) ResetEdge(name string) error {
+	switch name {
+	case item.EdgeShopping:
+		m.ResetShopping()
+		return nil
+	}
+	return fmt.Errorf("unknown Item edge %s", name)
+}
+
+// ItemCategoryMutation represents an operation that mutates the ItemCategory nodes in the graph.
+type ItemCategoryMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	comunity_id   *string
+	key           *string
+	name          *string
+	emoji         *string
+	sort_order    *int
+	addsort_order *int
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*ItemCategory, error)
+	predicates    []predicate.ItemCategory
+}
+
+var _ ent.Mutation = (*ItemCategoryMutation)(nil)
+
+// itemcategoryOption allows management of the mutation configuration using functional options.
+type itemcategoryOption func(*ItemCategoryMutation)
+
+// newItemCategoryMutation creates new mutation for the ItemCategory entity.
+func newItemCategoryMutation(c config, op Op, opts ...itemcategoryOption) *ItemCategoryMutation {
+	m := &ItemCategoryMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeItemCategory,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withItemCategoryID sets the ID field of the mutation.
+func withItemCategoryID(id int) itemcategoryOption {
+	return func(m *ItemCategoryMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *ItemCategory
+		)
+		m.oldValue = func(ctx context.Context) (*ItemCategory, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().ItemCategory.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withItemCategory sets the old ItemCategory of the mutation.
+func withItemCategory(node *ItemCategory) itemcategoryOption {
+	return func(m *ItemCategoryMutation) {
+		m.oldValue = func(context.Context) (*ItemCategory, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ItemCategoryMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ItemCategoryMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ItemCategoryMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ItemCategoryMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().ItemCategory.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetComunityID sets the "comunity_id" field.
+func (m *ItemCategoryMutation) SetComunityID(s string) {
+	m.comunity_id = &s
+}
+
+// ComunityID returns the value of the "comunity_id" field in the mutation.
+func (m *ItemCategoryMutation) ComunityID() (r string, exists bool) {
+	v := m.comunity_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldComunityID returns the old "comunity_id" field's value of the ItemCategory entity.
+// If the ItemCategory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemCategoryMutation) OldComunityID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldComunityID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldComunityID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldComunityID: %w", err)
+	}
+	return oldValue.ComunityID, nil
+}
+
+// ResetComunityID resets all changes to the "comunity_id" field.
+func (m *ItemCategoryMutation) ResetComunityID() {
+	m.comunity_id = nil
+}
+
+// SetKey sets the "key" field.
+func (m *ItemCategoryMutation) SetKey(s string) {
+	m.key = &s
+}
+
+// Key returns the value of the "key" field in the mutation.
+func (m *ItemCategoryMutation) Key() (r string, exists bool) {
+	v := m.key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldKey returns the old "key" field's value of the ItemCategory entity.
+// If the ItemCategory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemCategoryMutation) OldKey(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKey: %w", err)
+	}
+	return oldValue.Key, nil
+}
+
+// ClearKey clears the value of the "key" field.
+func (m *ItemCategoryMutation) ClearKey() {
+	m.key = nil
+	m.clearedFields[itemcategory.FieldKey] = struct{}{}
+}
+
+// KeyCleared returns if the "key" field was cleared in this mutation.
+func (m *ItemCategoryMutation) KeyCleared() bool {
+	_, ok := m.clearedFields[itemcategory.FieldKey]
+	return ok
+}
+
+// ResetKey resets all changes to the "key" field.
+func (m *ItemCategoryMutation) ResetKey() {
+	m.key = nil
+	delete(m.clearedFields, itemcategory.FieldKey)
+}
+
+// SetName sets the "name" field.
+func (m *ItemCategoryMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ItemCategoryMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the ItemCategory entity.
+// If the ItemCategory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemCategoryMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *ItemCategoryMutation) ResetName() {
+	m.name = nil
+}
+
+// SetEmoji sets the "emoji" field.
+func (m *ItemCategoryMutation) SetEmoji(s string) {
+	m.emoji = &s
+}
+
+// Emoji returns the value of the "emoji" field in the mutation.
+func (m *ItemCategoryMutation) Emoji() (r string, exists bool) {
+	v := m.emoji
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmoji returns the old "emoji" field's value of the ItemCategory entity.
+// If the ItemCategory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemCategoryMutation) OldEmoji(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmoji is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmoji requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmoji: %w", err)
+	}
+	return oldValue.Emoji, nil
+}
+
+// ResetEmoji resets all changes to the "emoji" field.
+func (m *ItemCategoryMutation) ResetEmoji() {
+	m.emoji = nil
+}
+
+// SetSortOrder sets the "sort_order" field.
+func (m *ItemCategoryMutation) SetSortOrder(i int) {
+	m.sort_order = &i
+	m.addsort_order = nil
+}
+
+// SortOrder returns the value of the "sort_order" field in the mutation.
+func (m *ItemCategoryMutation) SortOrder() (r int, exists bool) {
+	v := m.sort_order
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSortOrder returns the old "sort_order" field's value of the ItemCategory entity.
+// If the ItemCategory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ItemCategoryMutation) OldSortOrder(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSortOrder is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSortOrder requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSortOrder: %w", err)
+	}
+	return oldValue.SortOrder, nil
+}
+
+// AddSortOrder adds i to the "sort_order" field.
+func (m *ItemCategoryMutation) AddSortOrder(i int) {
+	if m.addsort_order != nil {
+		*m.addsort_order += i
+	} else {
+		m.addsort_order = &i
+	}
+}
+
+// AddedSortOrder returns the value that was added to the "sort_order" field in this mutation.
+func (m *ItemCategoryMutation) AddedSortOrder() (r int, exists bool) {
+	v := m.addsort_order
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetSortOrder resets all changes to the "sort_order" field.
+func (m *ItemCategoryMutation) ResetSortOrder() {
+	m.sort_order = nil
+	m.addsort_order = nil
+}
+
+// Where appends a list predicates to the ItemCategoryMutation builder.
+func (m *ItemCategoryMutation) Where(ps ...predicate.ItemCategory) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *ItemCategoryMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (ItemCategory).
+func (m *ItemCategoryMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ItemCategoryMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m.comunity_id != nil {
+		fields = append(fields, itemcategory.FieldComunityID)
+	}
+	if m.key != nil {
+		fields = append(fields, itemcategory.FieldKey)
+	}
+	if m.name != nil {
+		fields = append(fields, itemcategory.FieldName)
+	}
+	if m.emoji != nil {
+		fields = append(fields, itemcategory.FieldEmoji)
+	}
+	if m.sort_order != nil {
+		fields = append(fields, itemcategory.FieldSortOrder)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ItemCategoryMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case itemcategory.FieldComunityID:
+		return m.ComunityID()
+	case itemcategory.FieldKey:
+		return m.Key()
+	case itemcategory.FieldName:
+		return m.Name()
+	case itemcategory.FieldEmoji:
+		return m.Emoji()
+	case itemcategory.FieldSortOrder:
+		return m.SortOrder()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ItemCategoryMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case itemcategory.FieldComunityID:
+		return m.OldComunityID(ctx)
+	case itemcategory.FieldKey:
+		return m.OldKey(ctx)
+	case itemcategory.FieldName:
+		return m.OldName(ctx)
+	case itemcategory.FieldEmoji:
+		return m.OldEmoji(ctx)
+	case itemcategory.FieldSortOrder:
+		return m.OldSortOrder(ctx)
+	}
+	return nil, fmt.Errorf("unknown ItemCategory field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ItemCategoryMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case itemcategory.FieldComunityID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetComunityID(v)
+		return nil
+	case itemcategory.FieldKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKey(v)
+		return nil
+	case itemcategory.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case itemcategory.FieldEmoji:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmoji(v)
+		return nil
+	case itemcategory.FieldSortOrder:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSortOrder(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ItemCategory field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ItemCategoryMutation) AddedFields() []string {
+	var fields []string
+	if m.addsort_order != nil {
+		fields = append(fields, itemcategory.FieldSortOrder)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ItemCategoryMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case itemcategory.FieldSortOrder:
+		return m.AddedSortOrder()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ItemCategoryMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case itemcategory.FieldSortOrder:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSortOrder(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ItemCategory numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ItemCategoryMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(itemcategory.FieldKey) {
+		fields = append(fields, itemcategory.FieldKey)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ItemCategoryMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ItemCategoryMutation) ClearField(name string) error {
+	switch name {
+	case itemcategory.FieldKey:
+		m.ClearKey()
+		return nil
+	}
+	return fmt.Errorf("unknown ItemCategory nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ItemCategoryMutation) ResetField(name string) error {
+	switch name {
+	case itemcategory.FieldComunityID:
+		m.ResetComunityID()
+		return nil
+	case itemcategory.FieldKey:
+		m.ResetKey()
+		return nil
+	case itemcategory.FieldName:
+		m.ResetName()
+		return nil
+	case itemcategory.FieldEmoji:
+		m.ResetEmoji()
+		return nil
+	case itemcategory.FieldSortOrder:
+		m.ResetSortOrder()
+		return nil
+	}
+	return fmt.Errorf("unknown ItemCategory field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ItemCategoryMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ItemCategoryMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ItemCategoryMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ItemCategoryMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ItemCategoryMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ItemCategoryMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ItemCategoryMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown ItemCategory unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ItemCategoryMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown ItemCategory edge %s", name)
+}
+
+// ProductMutation represents an operation that mutates the Product nodes in the graph.
+type ProductMutation struct {
+	config
+	op              Op
+	typ             string
+	id              *int
+	comunity_id     *string
+	name            *string
+	title           *string
 	category_id     *int
 	addcategory_id  *int
-	complete        *bool
+	added_count     *int
+	addadded_count  *int
+	last_added      *time.Time
+	bought_count    *int
+	addbought_count *int
+	last_bought     *time.Time
 	clearedFields   map[string]struct{}
-	shopping        *int
-	clearedshopping bool
 	done            bool
-	oldValue        func(context.Context) (*Item, error)
-	predicates      []predicate.Item
+	oldValue        func(context.Context) (*Product, error)
+	predicates      []predicate.Product
 }
 
-var _ ent.Mutation = (*ItemMutation)(nil)
+var _ ent.Mutation = (*ProductMutation)(nil)
 
-// itemOption allows management of the mutation configuration using functional options.
-type itemOption func(*ItemMutation)
+// productOption allows management of the mutation configuration using functional options.
+type productOption func(*ProductMutation)
 
-// newItemMutation creates new mutation for the Item entity.
-func newItemMutation(c config, op Op, opts ...itemOption) *ItemMutation {
-	m := &ItemMutation{
+// newProductMutation creates new mutation for the Product entity.
+func newProductMutation(c config, op Op, opts ...productOption) *ProductMutation {
+	m := &ProductMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeItem,
+		typ:           TypeProduct,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -72,20 +2089,20 @@ func newItemMutation(c config, op Op, opts ...itemOption) *ItemMutation {
 	return m
 }
 
-// withItemID sets the ID field of the mutation.
-func withItemID(id int) itemOption {
-	return func(m *ItemMutation) {
+// withProductID sets the ID field of the mutation.
+func withProductID(id int) productOption {
+	return func(m *ProductMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Item
+			value *Product
 		)
-		m.oldValue = func(ctx context.Context) (*Item, error) {
+		m.oldValue = func(ctx context.Context) (*Product, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Item.Get(ctx, id)
+					value, err = m.Client().Product.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -94,10 +2111,10 @@ func withItemID(id int) itemOption {
 	}
 }
 
-// withItem sets the old Item of the mutation.
-func withItem(node *Item) itemOption {
-	return func(m *ItemMutation) {
-		m.oldValue = func(context.Context) (*Item, error) {
+// withProduct sets the old Product of the mutation.
+func withProduct(node *Product) productOption {
+	return func(m *ProductMutation) {
+		m.oldValue = func(context.Context) (*Product, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -106,7 +2123,7 @@ func withItem(node *Item) itemOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ItemMutation) Client() *Client {
+func (m ProductMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -114,7 +2131,7 @@ func (m ItemMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ItemMutation) Tx() (*Tx, error) {
+func (m ProductMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -125,7 +2142,7 @@ func (m ItemMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ItemMutation) ID() (id int, exists bool) {
+func (m *ProductMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -136,7 +2153,7 @@ func (m *ItemMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ItemMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *ProductMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -145,266 +2162,416 @@ func (m *ItemMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Item.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Product.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetProductName sets the "product_name" field.
-func (m *ItemMutation) SetProductName(s string) {
-	m.product_name = &s
+// SetComunityID sets the "comunity_id" field.
+func (m *ProductMutation) SetComunityID(s string) {
+	m.comunity_id = &s
 }
 
-// ProductName returns the value of the "product_name" field in the mutation.
-func (m *ItemMutation) ProductName() (r string, exists bool) {
-	v := m.product_name
+// ComunityID returns the value of the "comunity_id" field in the mutation.
+func (m *ProductMutation) ComunityID() (r string, exists bool) {
+	v := m.comunity_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldComunityID returns the old "comunity_id" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProductMutation) OldComunityID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldComunityID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldComunityID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldComunityID: %w", err)
+	}
+	return oldValue.ComunityID, nil
+}
+
+// ResetComunityID resets all changes to the "comunity_id" field.
+func (m *ProductMutation) ResetComunityID() {
+	m.comunity_id = nil
+}
+
+// SetName sets the "name" field.
+func (m *ProductMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ProductMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProductMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *ProductMutation) ResetName() {
+	m.name = nil
+}
+
+// SetTitle sets the "title" field.
+func (m *ProductMutation) SetTitle(s string) {
+	m.title = &s
+}
+
+// Title returns the value of the "title" field in the mutation.
+func (m *ProductMutation) Title() (r string, exists bool) {
+	v := m.title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTitle returns the old "title" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProductMutation) OldTitle(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
+	}
+	return oldValue.Title, nil
+}
+
+// ResetTitle resets all changes to the "title" field.
+func (m *ProductMutation) ResetTitle() {
+	m.title = nil
+}
+
+// SetCategoryID sets the "category_id" field.
+func (m *ProductMutation) SetCategoryID(i int) {
+	m.category_id = &i
+	m.addcategory_id = nil
+}
+
+// CategoryID returns the value of the "category_id" field in the mutation.
+func (m *ProductMutation) CategoryID() (r int, exists bool) {
+	v := m.category_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCategoryID returns the old "category_id" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProductMutation) OldCategoryID(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCategoryID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCategoryID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCategoryID: %w", err)
+	}
+	return oldValue.CategoryID, nil
+}
+
+// AddCategoryID adds i to the "category_id" field.
+func (m *ProductMutation) AddCategoryID(i int) {
+	if m.addcategory_id != nil {
+		*m.addcategory_id += i
+	} else {
+		m.addcategory_id = &i
+	}
+}
+
+// AddedCategoryID returns the value that was added to the "category_id" field in this mutation.
+func (m *ProductMutation) AddedCategoryID() (r int, exists bool) {
+	v := m.addcategory_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCategoryID resets all changes to the "category_id" field.
+func (m *ProductMutation) ResetCategoryID() {
+	m.category_id = nil
+	m.addcategory_id = nil
+}
+
+// SetAddedCount sets the "added_count" field.
+func (m *ProductMutation) SetAddedCount(i int) {
+	m.added_count = &i
+	m.addadded_count = nil
+}
+
+// AddedCount returns the value of the "added_count" field in the mutation.
+func (m *ProductMutation) AddedCount() (r int, exists bool) {
+	v := m.added_count
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldProductName returns the old "product_name" field's value of the Item entity.
-// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// OldAddedCount returns the old "added_count" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ItemMutation) OldProductName(ctx context.Context) (v string, err error) {
+func (m *ProductMutation) OldAddedCount(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProductName is only allowed on UpdateOne operations")
+		return v, errors.New("OldAddedCount is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProductName requires an ID field in the mutation")
+		return v, errors.New("OldAddedCount requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProductName: %w", err)
+		return v, fmt.Errorf("querying old value for OldAddedCount: %w", err)
 	}
-	return oldValue.ProductName, nil
+	return oldValue.AddedCount, nil
 }
 
-// ResetProductName resets all changes to the "product_name" field.
-func (m *ItemMutation) ResetProductName() {
-	m.product_name = nil
+// AddAddedCount adds i to the "added_count" field.
+func (m *ProductMutation) AddAddedCount(i int) {
+	if m.addadded_count != nil {
+		*m.addadded_count += i
+	} else {
+		m.addadded_count = &i
+	}
 }
 
-// SetQuantity sets the "quantity" field.
-func (m *ItemMutation) SetQuantity(i int) {
-	m.quantity = &i
-	m.addquantity = nil
+// AddedAddedCount returns the value that was added to the "added_count" field in this mutation.
+func (m *ProductMutation) AddedAddedCount() (r int, exists bool) {
+	v := m.addadded_count
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Quantity returns the value of the "quantity" field in the mutation.
-func (m *ItemMutation) Quantity() (r int, exists bool) {
-	v := m.quantity
+// ResetAddedCount resets all changes to the "added_count" field.
+func (m *ProductMutation) ResetAddedCount() {
+	m.added_count = nil
+	m.addadded_count = nil
+}
+
+// SetLastAdded sets the "last_added" field.
+func (m *ProductMutation) SetLastAdded(t time.Time) {
+	m.last_added = &t
+}
+
+// LastAdded returns the value of the "last_added" field in the mutation.
+func (m *ProductMutation) LastAdded() (r time.Time, exists bool) {
+	v := m.last_added
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldQuantity returns the old "quantity" field's value of the Item entity.
-// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// OldLastAdded returns the old "last_added" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ItemMutation) OldQuantity(ctx context.Context) (v int, err error) {
+func (m *ProductMutation) OldLastAdded(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldQuantity is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastAdded is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldQuantity requires an ID field in the mutation")
+		return v, errors.New("OldLastAdded requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldQuantity: %w", err)
-	}
-	return oldValue.Quantity, nil
-}
-
-// AddQuantity adds i to the "quantity" field.
-func (m *ItemMutation) AddQuantity(i int) {
-	if m.addquantity != nil {
-		*m.addquantity += i
-	} else {
-		m.addquantity = &i
-	}
-}
-
-// AddedQuantity returns the value that was added to the "quantity" field in this mutation.
-func (m *ItemMutation) AddedQuantity() (r int, exists bool) {
-	v := m.addquantity
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldLastAdded: %w", err)
 	}
-	return *v, true
+	return oldValue.LastAdded, nil
 }
 
-// ResetQuantity resets all changes to the "quantity" field.
-func (m *ItemMutation) ResetQuantity() {
-	m.quantity = nil
-	m.addquantity = nil
+// ResetLastAdded resets all changes to the "last_added" field.
+func (m *ProductMutation) ResetLastAdded() {
+	m.last_added = nil
 }
 
-// SetCategoryID sets the "category_id" field.
-func (m *ItemMutation) SetCategoryID(i int) {
-	m.category_id = &i
-	m.addcategory_id = nil
+// SetBoughtCount sets the "bought_count" field.
+func (m *ProductMutation) SetBoughtCount(i int) {
+	m.bought_count = &i
+	m.addbought_count = nil
 }
 
-// CategoryID returns the value of the "category_id" field in the mutation.
-func (m *ItemMutation) CategoryID() (r int, exists bool) {
-	v := m.category_id
+// BoughtCount returns the value of the "bought_count" field in the mutation.
+func (m *ProductMutation) BoughtCount() (r int, exists bool) {
+	v := m.bought_count
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCategoryID returns the old "category_id" field's value of the Item entity.
-// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// OldBoughtCount returns the old "bought_count" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ItemMutation) OldCategoryID(ctx context.Context) (v int, err error) {
+func (m *ProductMutation) OldBoughtCount(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCategoryID is only allowed on UpdateOne operations")
+		return v, errors.New("OldBoughtCount is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCategoryID requires an ID field in the mutation")
+		return v, errors.New("OldBoughtCount requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCategoryID: %w", err)
+		return v, fmt.Errorf("querying old value for OldBoughtCount: %w", err)
 	}
-	return oldValue.CategoryID, nil
+	return oldValue.BoughtCount, nil
 }
 
-// AddCategoryID adds i to the "category_id" field.
-func (m *ItemMutation) AddCategoryID(i int) {
-	if m.addcategory_id != nil {
-		*m.addcategory_id += i
+// AddBoughtCount adds i to the "bought_count" field.
+func (m *ProductMutation) AddBoughtCount(i int) {
+	if m.addbought_count != nil {
+		*m.addbought_count += i
 	} else {
-		m.addcategory_id = &i
+		m.addbought_count = &i
 	}
 }
 
-// AddedCategoryID returns the value that was added to the "category_id" field in this mutation.
-func (m *ItemMutation) AddedCategoryID() (r int, exists bool) {
-	v := m.addcategory_id
+// AddedBoughtCount returns the value that was added to the "bought_count" field in this mutation.
+func (m *ProductMutation) AddedBoughtCount() (r int, exists bool) {
+	v := m.addbought_count
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetCategoryID resets all changes to the "category_id" field.
-func (m *ItemMutation) ResetCategoryID() {
-	m.category_id = nil
-	m.addcategory_id = nil
+// ResetBoughtCount resets all changes to the "bought_count" field.
+func (m *ProductMutation) ResetBoughtCount() {
+	m.bought_count = nil
+	m.addbought_count = nil
 }
 
-// SetComplete sets the "complete" field.
-func (m *ItemMutation) SetComplete(b bool) {
-	m.complete = &b
+// SetLastBought sets the "last_bought" field.
+func (m *ProductMutation) SetLastBought(t time.Time) {
+	m.last_bought = &t
 }
 
-// Complete returns the value of the "complete" field in the mutation.
-func (m *ItemMutation) Complete() (r bool, exists bool) {
-	v := m.complete
+// LastBought returns the value of the "last_bought" field in the mutation.
+func (m *ProductMutation) LastBought() (r time.Time, exists bool) {
+	v := m.last_bought
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldComplete returns the old "complete" field's value of the Item entity.
-// If the Item object wasn't provided to the builder, the object is fetched from the database.
+// OldLastBought returns the old "last_bought" field's value of the Product entity.
+// If the Product object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ItemMutation) OldComplete(ctx context.Context) (v bool, err error) {
+func (m *ProductMutation) OldLastBought(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldComplete is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastBought is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldComplete requires an ID field in the mutation")
+		return v, errors.New("OldLastBought requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldComplete: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastBought: %w", err)
 	}
-	return oldValue.Complete, nil
-}
-
-// ResetComplete resets all changes to the "complete" field.
-func (m *ItemMutation) ResetComplete() {
-	m.complete = nil
-}
-
-// SetShoppingID sets the "shopping" edge to the Shopping entity by id.
-func (m *ItemMutation) SetShoppingID(id int) {
-	m.shopping = &id
-}
-
-// ClearShopping clears the "shopping" edge to the Shopping entity.
-func (m *ItemMutation) ClearShopping() {
-	m.clearedshopping = true
-}
-
-// ShoppingCleared reports if the "shopping" edge to the Shopping entity was cleared.
-func (m *ItemMutation) ShoppingCleared() bool {
-	return m.clearedshopping
+	return oldValue.LastBought, nil
 }
 
-// ShoppingID returns the "shopping" edge ID in the mutation.
-func (m *ItemMutation) ShoppingID() (id int, exists bool) {
-	if m.shopping != nil {
-		return *m.shopping, true
-	}
-	return
+// ClearLastBought clears the value of the "last_bought" field.
+func (m *ProductMutation) ClearLastBought() {
+	m.last_bought = nil
+	m.clearedFields[product.FieldLastBought] = struct{}{}
 }
 
-// ShoppingIDs returns the "shopping" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ShoppingID instead. It exists only for internal usage by the builders.
-func (m *ItemMutation) ShoppingIDs() (ids []int) {
-	if id := m.shopping; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// LastBoughtCleared returns if the "last_bought" field was cleared in this mutation.
+func (m *ProductMutation) LastBoughtCleared() bool {
+	_, ok := m.clearedFields[product.FieldLastBought]
+	return ok
 }
 
-// ResetShopping resets all changes to the "shopping" edge.
-func (m *ItemMutation) ResetShopping() {
-	m.shopping = nil
-	m.clearedshopping = false
+// ResetLastBought resets all changes to the "last_bought" field.
+func (m *ProductMutation) ResetLastBought() {
+	m.last_bought = nil
+	delete(m.clearedFields, product.FieldLastBought)
 }
 
-// Where appends a list predicates to the ItemMutation builder.
-func (m *ItemMutation) Where(ps ...predicate.Item) {
+// Where appends a list predicates to the ProductMutation builder.
+func (m *ProductMutation) Where(ps ...predicate.Product) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *ItemMutation) Op() Op {
+func (m *ProductMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Item).
-func (m *ItemMutation) Type() string {
+// Type returns the node type of this mutation (Product).
+func (m *ProductMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ItemMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.product_name != nil {
-		fields = append(fields, item.FieldProductName)
+func (m *ProductMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.comunity_id != nil {
+		fields = append(fields, product.FieldComunityID)
 	}
-	if m.quantity != nil {
-		fields = append(fields, item.FieldQuantity)
+	if m.name != nil {
+		fields = append(fields, product.FieldName)
+	}
+	if m.title != nil {
+		fields = append(fields, product.FieldTitle)
 	}
 	if m.category_id != nil {
-		fields = append(fields, item.FieldCategoryID)
+		fields = append(fields, product.FieldCategoryID)
 	}
-	if m.complete != nil {
-		fields = append(fields, item.FieldComplete)
+	if m.added_count != nil {
+		fields = append(fields, product.FieldAddedCount)
+	}
+	if m.last_added != nil {
+		fields = append(fields, product.FieldLastAdded)
+	}
+	if m.bought_count != nil {
+		fields = append(fields, product.FieldBoughtCount)
+	}
+	if m.last_bought != nil {
+		fields = append(fields, product.FieldLastBought)
 	}
 	return fields
 }
@@ -412,16 +2579,24 @@ func (m *ItemMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ItemMutation) Field(name string) (ent.Value, bool) {
+func (m *ProductMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case item.FieldProductName:
-		return m.ProductName()
-	case item.FieldQuantity:
-		return m.Quantity()
-	case item.FieldCategoryID:
+	case product.FieldComunityID:
+		return m.ComunityID()
+	case product.FieldName:
+		return m.Name()
+	case product.FieldTitle:
+		return m.Title()
+	case product.FieldCategoryID:
 		return m.CategoryID()
-	case item.FieldComplete:
-		return m.Complete()
+	case product.FieldAddedCount:
+		return m.AddedCount()
+	case product.FieldLastAdded:
+		return m.LastAdded()
+	case product.FieldBoughtCount:
+		return m.BoughtCount()
+	case product.FieldLastBought:
+		return m.LastBought()
 	}
 	return nil, false
 }
@@ -429,66 +2604,105 @@ func (m *ItemMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ItemMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *ProductMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case item.FieldProductName:
-		return m.OldProductName(ctx)
-	case item.FieldQuantity:
-		return m.OldQuantity(ctx)
-	case item.FieldCategoryID:
+	case product.FieldComunityID:
+		return m.OldComunityID(ctx)
+	case product.FieldName:
+		return m.OldName(ctx)
+	case product.FieldTitle:
+		return m.OldTitle(ctx)
+	case product.FieldCategoryID:
 		return m.OldCategoryID(ctx)
-	case item.FieldComplete:
-		return m.OldComplete(ctx)
+	case product.FieldAddedCount:
+		return m.OldAddedCount(ctx)
+	case product.FieldLastAdded:
+		return m.OldLastAdded(ctx)
+	case product.FieldBoughtCount:
+		return m.OldBoughtCount(ctx)
+	case product.FieldLastBought:
+		return m.OldLastBought(ctx)
 	}
-	return nil, fmt.Errorf("unknown Item field %s", name)
+	return nil, fmt.Errorf("unknown Product field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ItemMutation) SetField(name string, value ent.Value) error {
+func (m *ProductMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case item.FieldProductName:
+	case product.FieldComunityID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetProductName(v)
+		m.SetComunityID(v)
 		return nil
-	case item.FieldQuantity:
-		v, ok := value.(int)
+	case product.FieldName:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetQuantity(v)
+		m.SetName(v)
 		return nil
-	case item.FieldCategoryID:
+	case product.FieldTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTitle(v)
+		return nil
+	case product.FieldCategoryID:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCategoryID(v)
 		return nil
-	case item.FieldComplete:
-		v, ok := value.(bool)
+	case product.FieldAddedCount:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetComplete(v)
+		m.SetAddedCount(v)
+		return nil
+	case product.FieldLastAdded:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastAdded(v)
+		return nil
+	case product.FieldBoughtCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBoughtCount(v)
+		return nil
+	case product.FieldLastBought:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastBought(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Item field %s", name)
+	return fmt.Errorf("unknown Product field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ItemMutation) AddedFields() []string {
+func (m *ProductMutation) AddedFields() []string {
 	var fields []string
-	if m.addquantity != nil {
-		fields = append(fields, item.FieldQuantity)
-	}
 	if m.addcategory_id != nil {
-		fields = append(fields, item.FieldCategoryID)
+		fields = append(fields, product.FieldCategoryID)
+	}
+	if m.addadded_count != nil {
+		fields = append(fields, product.FieldAddedCount)
+	}
+	if m.addbought_count != nil {
+		fields = append(fields, product.FieldBoughtCount)
 	}
 	return fields
 }
@@ -496,12 +2710,14 @@ func (m *ItemMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ItemMutation) AddedField(name string) (ent.Value, bool) {
+func (m *ProductMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case item.FieldQuantity:
-		return m.AddedQuantity()
-	case item.FieldCategoryID:
+	case product.FieldCategoryID:
 		return m.AddedCategoryID()
+	case product.FieldAddedCount:
+		return m.AddedAddedCount()
+	case product.FieldBoughtCount:
+		return m.AddedBoughtCount()
 	}
 	return nil, false
 }
@@ -509,139 +2725,139 @@ func (m *ItemMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ItemMutation) AddField(name string, value ent.Value) error {
+func (m *ProductMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case item.FieldQuantity:
+	case product.FieldCategoryID:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddQuantity(v)
+		m.AddCategoryID(v)
 		return nil
-	case item.FieldCategoryID:
+	case product.FieldAddedCount:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddCategoryID(v)
+		m.AddAddedCount(v)
+		return nil
+	case product.FieldBoughtCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddBoughtCount(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Item numeric field %s", name)
+	return fmt.Errorf("unknown Product numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ItemMutation) ClearedFields() []string {
-	return nil
+func (m *ProductMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(product.FieldLastBought) {
+		fields = append(fields, product.FieldLastBought)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ItemMutation) FieldCleared(name string) bool {
+func (m *ProductMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ItemMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Item nullable field %s", name)
+func (m *ProductMutation) ClearField(name string) error {
+	switch name {
+	case product.FieldLastBought:
+		m.ClearLastBought()
+		return nil
+	}
+	return fmt.Errorf("unknown Product nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ItemMutation) ResetField(name string) error {
+func (m *ProductMutation) ResetField(name string) error {
 	switch name {
-	case item.FieldProductName:
-		m.ResetProductName()
+	case product.FieldComunityID:
+		m.ResetComunityID()
 		return nil
-	case item.FieldQuantity:
-		m.ResetQuantity()
+	case product.FieldName:
+		m.ResetName()
+		return nil
+	case product.FieldTitle:
+		m.ResetTitle()
+		return nil
+	case product.FieldCategoryID:
+		m.ResetCategoryID()
+		return nil
+	case product.FieldAddedCount:
+		m.ResetAddedCount()
+		return nil
+	case product.FieldLastAdded:
+		m.ResetLastAdded()
 		return nil
-	case item.FieldCategoryID:
-		m.ResetCategoryID()
+	case product.FieldBoughtCount:
+		m.ResetBoughtCount()
 		return nil
-	case item.FieldComplete:
-		m.ResetComplete()
+	case product.FieldLastBought:
+		m.ResetLastBought()
 		return nil
 	}
-	return fmt.Errorf("unknown Item field %s", name)
+	return fmt.Errorf("unknown Product field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ItemMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.shopping != nil {
-		edges = append(edges, item.EdgeShopping)
-	}
+func (m *ProductMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ItemMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case item.EdgeShopping:
-		if id := m.shopping; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *ProductMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ItemMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *ProductMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ItemMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	}
+func (m *ProductMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ItemMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.clearedshopping {
-		edges = append(edges, item.EdgeShopping)
-	}
+func (m *ProductMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ItemMutation) EdgeCleared(name string) bool {
-	switch name {
-	case item.EdgeShopping:
-		return m.clearedshopping
-	}
+func (m *ProductMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ItemMutation) ClearEdge(name string) error {
-	switch name {
-	case item.EdgeShopping:
-		m.ClearShopping()
-		return nil
-	}
-	return fmt.Errorf("unknown Item unique edge %s", name)
+func (m *ProductMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Product unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ItemMutation) ResetEdge(name string) error {
-	switch name {
-	case item.EdgeShopping:
-		m.ResetShopping()
-		return nil
-	}
-	return fmt.Errorf("unknown Item edge %s", name)
+func (m *ProductMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Product edge %s", name)
 }
 
 // ShopMutation represents an operation that mutates the Shop nodes in the graph.
@@ -1814,6 +4030,13 @@ type UserMutation struct {
 	token             *string
 	chat_id           *int64
 	addchat_id        *int64
+	language          *string
+	active            *bool
+	notify            *user.Notify
+	digest_minutes    *int
+	adddigest_minutes *int
+	digest_time       *string
+	digest_zone       *string
 	clearedFields     map[string]struct{}
 	shopping          map[int]struct{}
 	removedshopping   map[int]struct{}
@@ -2141,6 +4364,281 @@ func (m *UserMutation) ResetChatID() {
 	m.addchat_id = nil
 }
 
+// SetLanguage sets the "language" field.
+func (m *UserMutation) SetLanguage(s string) {
+	m.language = &s
+}
+
+// Language returns the value of the "language" field in the mutation.
+func (m *UserMutation) Language() (r string, exists bool) {
+	v := m.language
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLanguage returns the old "language" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldLanguage(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLanguage is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLanguage requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLanguage: %w", err)
+	}
+	return oldValue.Language, nil
+}
+
+// ClearLanguage clears the value of the "language" field.
+func (m *UserMutation) ClearLanguage() {
+	m.language = nil
+	m.clearedFields[user.FieldLanguage] = struct{}{}
+}
+
+// LanguageCleared returns if the "language" field was cleared in this mutation.
+func (m *UserMutation) LanguageCleared() bool {
+	_, ok := m.clearedFields[user.FieldLanguage]
+	return ok
+}
+
+// ResetLanguage resets all changes to the "language" field.
+func (m *UserMutation) ResetLanguage() {
+	m.language = nil
+	delete(m.clearedFields, user.FieldLanguage)
+}
+
+// SetActive sets the "active" field.
+func (m *UserMutation) SetActive(b bool) {
+	m.active = &b
+}
+
+// Active returns the value of the "active" field in the mutation.
+func (m *UserMutation) Active() (r bool, exists bool) {
+	v := m.active
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldActive returns the old "active" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldActive(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldActive is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldActive requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldActive: %w", err)
+	}
+	return oldValue.Active, nil
+}
+
+// ResetActive resets all changes to the "active" field.
+func (m *UserMutation) ResetActive() {
+	m.active = nil
+}
+
+// SetNotify sets the "notify" field.
+func (m *UserMutation) SetNotify(u user.Notify) {
+	m.notify = &u
+}
+
+// Notify returns the value of the "notify" field in the mutation.
+func (m *UserMutation) Notify() (r user.Notify, exists bool) {
+	v := m.notify
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNotify returns the old "notify" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldNotify(ctx context.Context) (v user.Notify, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNotify is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNotify requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNotify: %w", err)
+	}
+	return oldValue.Notify, nil
+}
+
+// ResetNotify resets all changes to the "notify" field.
+func (m *UserMutation) ResetNotify() {
+	m.notify = nil
+}
+
+// SetDigestMinutes sets the "digest_minutes" field.
+func (m *UserMutation) SetDigestMinutes(i int) {
+	m.digest_minutes = &i
+	m.adddigest_minutes = nil
+}
+
+// DigestMinutes returns the value of the "digest_minutes" field in the mutation.
+func (m *UserMutation) DigestMinutes() (r int, exists bool) {
+	v := m.digest_minutes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDigestMinutes returns the old "digest_minutes" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDigestMinutes(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDigestMinutes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDigestMinutes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDigestMinutes: %w", err)
+	}
+	return oldValue.DigestMinutes, nil
+}
+
+// AddDigestMinutes adds i to the "digest_minutes" field.
+func (m *UserMutation) AddDigestMinutes(i int) {
+	if m.adddigest_minutes != nil {
+		*m.adddigest_minutes += i
+	} else {
+		m.adddigest_minutes = &i
+	}
+}
+
+// AddedDigestMinutes returns the value that was added to the "digest_minutes" field in this mutation.
+func (m *UserMutation) AddedDigestMinutes() (r int, exists bool) {
+	v := m.adddigest_minutes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetDigestMinutes resets all changes to the "digest_minutes" field.
+func (m *UserMutation) ResetDigestMinutes() {
+	m.digest_minutes = nil
+	m.adddigest_minutes = nil
+}
+
+// SetDigestTime sets the "digest_time" field.
+func (m *UserMutation) SetDigestTime(s string) {
+	m.digest_time = &s
+}
+
+// DigestTime returns the value of the "digest_time" field in the mutation.
+func (m *UserMutation) DigestTime() (r string, exists bool) {
+	v := m.digest_time
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDigestTime returns the old "digest_time" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDigestTime(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDigestTime is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDigestTime requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDigestTime: %w", err)
+	}
+	return oldValue.DigestTime, nil
+}
+
+// ClearDigestTime clears the value of the "digest_time" field.
+func (m *UserMutation) ClearDigestTime() {
+	m.digest_time = nil
+	m.clearedFields[user.FieldDigestTime] = struct{}{}
+}
+
+// DigestTimeCleared returns if the "digest_time" field was cleared in this mutation.
+func (m *UserMutation) DigestTimeCleared() bool {
+	_, ok := m.clearedFields[user.FieldDigestTime]
+	return ok
+}
+
+// ResetDigestTime resets all changes to the "digest_time" field.
+func (m *UserMutation) ResetDigestTime() {
+	m.digest_time = nil
+	delete(m.clearedFields, user.FieldDigestTime)
+}
+
+// SetDigestZone sets the "digest_zone" field.
+func (m *UserMutation) SetDigestZone(s string) {
+	m.digest_zone = &s
+}
+
+// DigestZone returns the value of the "digest_zone" field in the mutation.
+func (m *UserMutation) DigestZone() (r string, exists bool) {
+	v := m.digest_zone
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDigestZone returns the old "digest_zone" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDigestZone(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDigestZone is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDigestZone requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDigestZone: %w", err)
+	}
+	return oldValue.DigestZone, nil
+}
+
+// ClearDigestZone clears the value of the "digest_zone" field.
+func (m *UserMutation) ClearDigestZone() {
+	m.digest_zone = nil
+	m.clearedFields[user.FieldDigestZone] = struct{}{}
+}
+
+// DigestZoneCleared returns if the "digest_zone" field was cleared in this mutation.
+func (m *UserMutation) DigestZoneCleared() bool {
+	_, ok := m.clearedFields[user.FieldDigestZone]
+	return ok
+}
+
+// ResetDigestZone resets all changes to the "digest_zone" field.
+func (m *UserMutation) ResetDigestZone() {
+	m.digest_zone = nil
+	delete(m.clearedFields, user.FieldDigestZone)
+}
+
 // AddShoppingIDs adds the "shopping" edge to the Shopping entity by ids.
 func (m *UserMutation) AddShoppingIDs(ids ...int) {
 	if m.shopping == nil {
@@ -2214,7 +4712,7 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 5)
+	fields := make([]string, 0, 11)
 	if m.telegram_id != nil {
 		fields = append(fields, user.FieldTelegramID)
 	}
@@ -2230,6 +4728,24 @@ func (m *UserMutation) Fields() []string {
 	if m.chat_id != nil {
 		fields = append(fields, user.FieldChatID)
 	}
+	if m.language != nil {
+		fields = append(fields, user.FieldLanguage)
+	}
+	if m.active != nil {
+		fields = append(fields, user.FieldActive)
+	}
+	if m.notify != nil {
+		fields = append(fields, user.FieldNotify)
+	}
+	if m.digest_minutes != nil {
+		fields = append(fields, user.FieldDigestMinutes)
+	}
+	if m.digest_time != nil {
+		fields = append(fields, user.FieldDigestTime)
+	}
+	if m.digest_zone != nil {
+		fields = append(fields, user.FieldDigestZone)
+	}
 	return fields
 }
 
@@ -2248,6 +4764,18 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 		return m.Token()
 	case user.FieldChatID:
 		return m.ChatID()
+	case user.FieldLanguage:
+		return m.Language()
+	case user.FieldActive:
+		return m.Active()
+	case user.FieldNotify:
+		return m.Notify()
+	case user.FieldDigestMinutes:
+		return m.DigestMinutes()
+	case user.FieldDigestTime:
+		return m.DigestTime()
+	case user.FieldDigestZone:
+		return m.DigestZone()
 	}
 	return nil, false
 }
@@ -2267,6 +4795,18 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldToken(ctx)
 	case user.FieldChatID:
 		return m.OldChatID(ctx)
+	case user.FieldLanguage:
+		return m.OldLanguage(ctx)
+	case user.FieldActive:
+		return m.OldActive(ctx)
+	case user.FieldNotify:
+		return m.OldNotify(ctx)
+	case user.FieldDigestMinutes:
+		return m.OldDigestMinutes(ctx)
+	case user.FieldDigestTime:
+		return m.OldDigestTime(ctx)
+	case user.FieldDigestZone:
+		return m.OldDigestZone(ctx)
 	}
 	return nil, fmt.Errorf("unknown User field %s", name)
 }
@@ -2311,6 +4851,48 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetChatID(v)
 		return nil
+	case user.FieldLanguage:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLanguage(v)
+		return nil
+	case user.FieldActive:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActive(v)
+		return nil
+	case user.FieldNotify:
+		v, ok := value.(user.Notify)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNotify(v)
+		return nil
+	case user.FieldDigestMinutes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDigestMinutes(v)
+		return nil
+	case user.FieldDigestTime:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDigestTime(v)
+		return nil
+	case user.FieldDigestZone:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDigestZone(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
@@ -2325,6 +4907,9 @@ func (m *UserMutation) AddedFields() []string {
 	if m.addchat_id != nil {
 		fields = append(fields, user.FieldChatID)
 	}
+	if m.adddigest_minutes != nil {
+		fields = append(fields, user.FieldDigestMinutes)
+	}
 	return fields
 }
 
@@ -2337,6 +4922,8 @@ func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedTelegramID()
 	case user.FieldChatID:
 		return m.AddedChatID()
+	case user.FieldDigestMinutes:
+		return m.AddedDigestMinutes()
 	}
 	return nil, false
 }
@@ -2360,6 +4947,13 @@ func (m *UserMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddChatID(v)
 		return nil
+	case user.FieldDigestMinutes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDigestMinutes(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User numeric field %s", name)
 }
@@ -2367,7 +4961,17 @@ func (m *UserMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *UserMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(user.FieldLanguage) {
+		fields = append(fields, user.FieldLanguage)
+	}
+	if m.FieldCleared(user.FieldDigestTime) {
+		fields = append(fields, user.FieldDigestTime)
+	}
+	if m.FieldCleared(user.FieldDigestZone) {
+		fields = append(fields, user.FieldDigestZone)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -2380,6 +4984,17 @@ func (m *UserMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *UserMutation) ClearField(name string) error {
+	switch name {
+	case user.FieldLanguage:
+		m.ClearLanguage()
+		return nil
+	case user.FieldDigestTime:
+		m.ClearDigestTime()
+		return nil
+	case user.FieldDigestZone:
+		m.ClearDigestZone()
+		return nil
+	}
 	return fmt.Errorf("unknown User nullable field %s", name)
 }
 
@@ -2402,6 +5017,24 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldChatID:
 		m.ResetChatID()
 		return nil
+	case user.FieldLanguage:
+		m.ResetLanguage()
+		return nil
+	case user.FieldActive:
+		m.ResetActive()
+		return nil
+	case user.FieldNotify:
+		m.ResetNotify()
+		return nil
+	case user.FieldDigestMinutes:
+		m.ResetDigestMinutes()
+		return nil
+	case user.FieldDigestTime:
+		m.ResetDigestTime()
+		return nil
+	case user.FieldDigestZone:
+		m.ResetDigestZone()
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
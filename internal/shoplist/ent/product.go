@@ -0,0 +1,182 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
+)
+
+// Product is the model entity for the Product schema.
+type Product struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// ComunityID holds the value of the "comunity_id" field.
+	ComunityID string `json:"comunity_id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Title holds the value of the "title" field.
+	Title string `json:"title,omitempty"`
+	// CategoryID holds the value of the "category_id" field.
+	CategoryID int `json:"category_id,omitempty"`
+	// AddedCount holds the value of the "added_count" field.
+	AddedCount int `json:"added_count,omitempty"`
+	// LastAdded holds the value of the "last_added" field.
+	LastAdded time.Time `json:"last_added,omitempty"`
+	// BoughtCount holds the value of the "bought_count" field.
+	BoughtCount int `json:"bought_count,omitempty"`
+	// LastBought holds the value of the "last_bought" field.
+	LastBought *time.Time `json:"last_bought,omitempty"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Product) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case product.FieldID, product.FieldCategoryID, product.FieldAddedCount, product.FieldBoughtCount:
+			values[i] = new(sql.NullInt64)
+		case product.FieldComunityID, product.FieldName, product.FieldTitle:
+			values[i] = new(sql.NullString)
+		case product.FieldLastAdded, product.FieldLastBought:
+			values[i] = new(sql.NullTime)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type Product", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Product fields.
+func (pr *Product) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case product.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pr.ID = int(value.Int64)
+		case product.FieldComunityID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field comunity_id", values[i])
+			} else if value.Valid {
+				pr.ComunityID = value.String
+			}
+		case product.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				pr.Name = value.String
+			}
+		case product.FieldTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field title", values[i])
+			} else if value.Valid {
+				pr.Title = value.String
+			}
+		case product.FieldCategoryID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field category_id", values[i])
+			} else if value.Valid {
+				pr.CategoryID = int(value.Int64)
+			}
+		case product.FieldAddedCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field added_count", values[i])
+			} else if value.Valid {
+				pr.AddedCount = int(value.Int64)
+			}
+		case product.FieldLastAdded:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_added", values[i])
+			} else if value.Valid {
+				pr.LastAdded = value.Time
+			}
+		case product.FieldBoughtCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field bought_count", values[i])
+			} else if value.Valid {
+				pr.BoughtCount = int(value.Int64)
+			}
+		case product.FieldLastBought:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_bought", values[i])
+			} else if value.Valid {
+				pr.LastBought = new(time.Time)
+				*pr.LastBought = value.Time
+			}
+		}
+	}
+	return nil
+}
+
+// Update returns a builder for updating this Product.
+// Note that you need to call Product.Unwrap() before calling this method if this Product
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pr *Product) Update() *ProductUpdateOne {
+	return (&ProductClient{config: pr.config}).UpdateOne(pr)
+}
+
+// Unwrap unwraps the Product entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pr *Product) Unwrap() *Product {
+	_tx, ok := pr.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Product is not a transactional entity")
+	}
+	pr.config.driver = _tx.drv
+	return pr
+}
+
+// String implements the fmt.Stringer.
+func (pr *Product) String() string {
+	var builder strings.Builder
+	builder.WriteString("Product(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pr.ID))
+	builder.WriteString("comunity_id=")
+	builder.WriteString(pr.ComunityID)
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(pr.Name)
+	builder.WriteString(", ")
+	builder.WriteString("title=")
+	builder.WriteString(pr.Title)
+	builder.WriteString(", ")
+	builder.WriteString("category_id=")
+	builder.WriteString(fmt.Sprintf("%v", pr.CategoryID))
+	builder.WriteString(", ")
+	builder.WriteString("added_count=")
+	builder.WriteString(fmt.Sprintf("%v", pr.AddedCount))
+	builder.WriteString(", ")
+	builder.WriteString("last_added=")
+	builder.WriteString(pr.LastAdded.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("bought_count=")
+	builder.WriteString(fmt.Sprintf("%v", pr.BoughtCount))
+	builder.WriteString(", ")
+	if v := pr.LastBought; v != nil {
+		builder.WriteString("last_bought=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Products is a parsable slice of Product.
+type Products []*Product
+
+func (pr Products) config(cfg config) {
+	for _i := range pr {
+		pr[_i].config = cfg
+	}
+}
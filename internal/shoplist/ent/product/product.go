@@ -0,0 +1,72 @@
+// Code generated by ent, DO NOT EDIT.
+
+package product
+
+import (
+	"time"
+)
+
+const (
+	// Label holds the string label denoting the product type in the database.
+	Label = "product"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldComunityID holds the string denoting the comunity_id field in the database.
+	FieldComunityID = "comunity_id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldTitle holds the string denoting the title field in the database.
+	FieldTitle = "title"
+	// FieldCategoryID holds the string denoting the category_id field in the database.
+	FieldCategoryID = "category_id"
+	// FieldAddedCount holds the string denoting the added_count field in the database.
+	FieldAddedCount = "added_count"
+	// FieldLastAdded holds the string denoting the last_added field in the database.
+	FieldLastAdded = "last_added"
+	// FieldBoughtCount holds the string denoting the bought_count field in the database.
+	FieldBoughtCount = "bought_count"
+	// FieldLastBought holds the string denoting the last_bought field in the database.
+	FieldLastBought = "last_bought"
+	// Table holds the table name of the product in the database.
+	Table = "products"
+)
+
+// Columns holds all SQL columns for product fields.
+var Columns = []string{
+	FieldID,
+	FieldComunityID,
+	FieldName,
+	FieldTitle,
+	FieldCategoryID,
+	FieldAddedCount,
+	FieldLastAdded,
+	FieldBoughtCount,
+	FieldLastBought,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ComunityIDValidator is a validator for the "comunity_id" field. It is called by the builders before save.
+	ComunityIDValidator func(string) error
+	// NameValidator is a validator for the "name" field. It is called by the builders before save.
+	NameValidator func(string) error
+	// TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	TitleValidator func(string) error
+	// DefaultCategoryID holds the default value on creation for the "category_id" field.
+	DefaultCategoryID int
+	// DefaultAddedCount holds the default value on creation for the "added_count" field.
+	DefaultAddedCount int
+	// DefaultLastAdded holds the default value on creation for the "last_added" field.
+	DefaultLastAdded func() time.Time
+	// DefaultBoughtCount holds the default value on creation for the "bought_count" field.
+	DefaultBoughtCount int
+)
@@ -0,0 +1,800 @@
+// Code generated by ent, DO NOT EDIT.
+
+package product
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// ComunityID applies equality check predicate on the "comunity_id" field. It's identical to ComunityIDEQ.
+func ComunityID(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldComunityID), v))
+	})
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldName), v))
+	})
+}
+
+// Title applies equality check predicate on the "title" field. It's identical to TitleEQ.
+func Title(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTitle), v))
+	})
+}
+
+// CategoryID applies equality check predicate on the "category_id" field. It's identical to CategoryIDEQ.
+func CategoryID(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCategoryID), v))
+	})
+}
+
+// AddedCount applies equality check predicate on the "added_count" field. It's identical to AddedCountEQ.
+func AddedCount(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAddedCount), v))
+	})
+}
+
+// LastAdded applies equality check predicate on the "last_added" field. It's identical to LastAddedEQ.
+func LastAdded(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastAdded), v))
+	})
+}
+
+// BoughtCount applies equality check predicate on the "bought_count" field. It's identical to BoughtCountEQ.
+func BoughtCount(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBoughtCount), v))
+	})
+}
+
+// LastBought applies equality check predicate on the "last_bought" field. It's identical to LastBoughtEQ.
+func LastBought(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastBought), v))
+	})
+}
+
+// ComunityIDEQ applies the EQ predicate on the "comunity_id" field.
+func ComunityIDEQ(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDNEQ applies the NEQ predicate on the "comunity_id" field.
+func ComunityIDNEQ(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDIn applies the In predicate on the "comunity_id" field.
+func ComunityIDIn(vs ...string) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldComunityID), v...))
+	})
+}
+
+// ComunityIDNotIn applies the NotIn predicate on the "comunity_id" field.
+func ComunityIDNotIn(vs ...string) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldComunityID), v...))
+	})
+}
+
+// ComunityIDGT applies the GT predicate on the "comunity_id" field.
+func ComunityIDGT(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDGTE applies the GTE predicate on the "comunity_id" field.
+func ComunityIDGTE(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDLT applies the LT predicate on the "comunity_id" field.
+func ComunityIDLT(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDLTE applies the LTE predicate on the "comunity_id" field.
+func ComunityIDLTE(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDContains applies the Contains predicate on the "comunity_id" field.
+func ComunityIDContains(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDHasPrefix applies the HasPrefix predicate on the "comunity_id" field.
+func ComunityIDHasPrefix(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDHasSuffix applies the HasSuffix predicate on the "comunity_id" field.
+func ComunityIDHasSuffix(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDEqualFold applies the EqualFold predicate on the "comunity_id" field.
+func ComunityIDEqualFold(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldComunityID), v))
+	})
+}
+
+// ComunityIDContainsFold applies the ContainsFold predicate on the "comunity_id" field.
+func ComunityIDContainsFold(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldComunityID), v))
+	})
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldName), v))
+	})
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldName), v))
+	})
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldName), v...))
+	})
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldName), v...))
+	})
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldName), v))
+	})
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldName), v))
+	})
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldName), v))
+	})
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldName), v))
+	})
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldName), v))
+	})
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldName), v))
+	})
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldName), v))
+	})
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldName), v))
+	})
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldName), v))
+	})
+}
+
+// TitleEQ applies the EQ predicate on the "title" field.
+func TitleEQ(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTitle), v))
+	})
+}
+
+// TitleNEQ applies the NEQ predicate on the "title" field.
+func TitleNEQ(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldTitle), v))
+	})
+}
+
+// TitleIn applies the In predicate on the "title" field.
+func TitleIn(vs ...string) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldTitle), v...))
+	})
+}
+
+// TitleNotIn applies the NotIn predicate on the "title" field.
+func TitleNotIn(vs ...string) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldTitle), v...))
+	})
+}
+
+// TitleGT applies the GT predicate on the "title" field.
+func TitleGT(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldTitle), v))
+	})
+}
+
+// TitleGTE applies the GTE predicate on the "title" field.
+func TitleGTE(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldTitle), v))
+	})
+}
+
+// TitleLT applies the LT predicate on the "title" field.
+func TitleLT(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldTitle), v))
+	})
+}
+
+// TitleLTE applies the LTE predicate on the "title" field.
+func TitleLTE(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldTitle), v))
+	})
+}
+
+// TitleContains applies the Contains predicate on the "title" field.
+func TitleContains(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldTitle), v))
+	})
+}
+
+// TitleHasPrefix applies the HasPrefix predicate on the "title" field.
+func TitleHasPrefix(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldTitle), v))
+	})
+}
+
+// TitleHasSuffix applies the HasSuffix predicate on the "title" field.
+func TitleHasSuffix(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldTitle), v))
+	})
+}
+
+// TitleEqualFold applies the EqualFold predicate on the "title" field.
+func TitleEqualFold(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldTitle), v))
+	})
+}
+
+// TitleContainsFold applies the ContainsFold predicate on the "title" field.
+func TitleContainsFold(v string) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldTitle), v))
+	})
+}
+
+// CategoryIDEQ applies the EQ predicate on the "category_id" field.
+func CategoryIDEQ(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCategoryID), v))
+	})
+}
+
+// CategoryIDNEQ applies the NEQ predicate on the "category_id" field.
+func CategoryIDNEQ(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCategoryID), v))
+	})
+}
+
+// CategoryIDIn applies the In predicate on the "category_id" field.
+func CategoryIDIn(vs ...int) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldCategoryID), v...))
+	})
+}
+
+// CategoryIDNotIn applies the NotIn predicate on the "category_id" field.
+func CategoryIDNotIn(vs ...int) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldCategoryID), v...))
+	})
+}
+
+// CategoryIDGT applies the GT predicate on the "category_id" field.
+func CategoryIDGT(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCategoryID), v))
+	})
+}
+
+// CategoryIDGTE applies the GTE predicate on the "category_id" field.
+func CategoryIDGTE(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCategoryID), v))
+	})
+}
+
+// CategoryIDLT applies the LT predicate on the "category_id" field.
+func CategoryIDLT(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCategoryID), v))
+	})
+}
+
+// CategoryIDLTE applies the LTE predicate on the "category_id" field.
+func CategoryIDLTE(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCategoryID), v))
+	})
+}
+
+// AddedCountEQ applies the EQ predicate on the "added_count" field.
+func AddedCountEQ(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAddedCount), v))
+	})
+}
+
+// AddedCountNEQ applies the NEQ predicate on the "added_count" field.
+func AddedCountNEQ(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAddedCount), v))
+	})
+}
+
+// AddedCountIn applies the In predicate on the "added_count" field.
+func AddedCountIn(vs ...int) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldAddedCount), v...))
+	})
+}
+
+// AddedCountNotIn applies the NotIn predicate on the "added_count" field.
+func AddedCountNotIn(vs ...int) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldAddedCount), v...))
+	})
+}
+
+// AddedCountGT applies the GT predicate on the "added_count" field.
+func AddedCountGT(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAddedCount), v))
+	})
+}
+
+// AddedCountGTE applies the GTE predicate on the "added_count" field.
+func AddedCountGTE(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAddedCount), v))
+	})
+}
+
+// AddedCountLT applies the LT predicate on the "added_count" field.
+func AddedCountLT(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAddedCount), v))
+	})
+}
+
+// AddedCountLTE applies the LTE predicate on the "added_count" field.
+func AddedCountLTE(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAddedCount), v))
+	})
+}
+
+// LastAddedEQ applies the EQ predicate on the "last_added" field.
+func LastAddedEQ(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastAdded), v))
+	})
+}
+
+// LastAddedNEQ applies the NEQ predicate on the "last_added" field.
+func LastAddedNEQ(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLastAdded), v))
+	})
+}
+
+// LastAddedIn applies the In predicate on the "last_added" field.
+func LastAddedIn(vs ...time.Time) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldLastAdded), v...))
+	})
+}
+
+// LastAddedNotIn applies the NotIn predicate on the "last_added" field.
+func LastAddedNotIn(vs ...time.Time) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldLastAdded), v...))
+	})
+}
+
+// LastAddedGT applies the GT predicate on the "last_added" field.
+func LastAddedGT(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLastAdded), v))
+	})
+}
+
+// LastAddedGTE applies the GTE predicate on the "last_added" field.
+func LastAddedGTE(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLastAdded), v))
+	})
+}
+
+// LastAddedLT applies the LT predicate on the "last_added" field.
+func LastAddedLT(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLastAdded), v))
+	})
+}
+
+// LastAddedLTE applies the LTE predicate on the "last_added" field.
+func LastAddedLTE(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLastAdded), v))
+	})
+}
+
+// BoughtCountEQ applies the EQ predicate on the "bought_count" field.
+func BoughtCountEQ(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldBoughtCount), v))
+	})
+}
+
+// BoughtCountNEQ applies the NEQ predicate on the "bought_count" field.
+func BoughtCountNEQ(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldBoughtCount), v))
+	})
+}
+
+// BoughtCountIn applies the In predicate on the "bought_count" field.
+func BoughtCountIn(vs ...int) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldBoughtCount), v...))
+	})
+}
+
+// BoughtCountNotIn applies the NotIn predicate on the "bought_count" field.
+func BoughtCountNotIn(vs ...int) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldBoughtCount), v...))
+	})
+}
+
+// BoughtCountGT applies the GT predicate on the "bought_count" field.
+func BoughtCountGT(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldBoughtCount), v))
+	})
+}
+
+// BoughtCountGTE applies the GTE predicate on the "bought_count" field.
+func BoughtCountGTE(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldBoughtCount), v))
+	})
+}
+
+// BoughtCountLT applies the LT predicate on the "bought_count" field.
+func BoughtCountLT(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldBoughtCount), v))
+	})
+}
+
+// BoughtCountLTE applies the LTE predicate on the "bought_count" field.
+func BoughtCountLTE(v int) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldBoughtCount), v))
+	})
+}
+
+// LastBoughtEQ applies the EQ predicate on the "last_bought" field.
+func LastBoughtEQ(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastBought), v))
+	})
+}
+
+// LastBoughtNEQ applies the NEQ predicate on the "last_bought" field.
+func LastBoughtNEQ(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLastBought), v))
+	})
+}
+
+// LastBoughtIn applies the In predicate on the "last_bought" field.
+func LastBoughtIn(vs ...time.Time) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldLastBought), v...))
+	})
+}
+
+// LastBoughtNotIn applies the NotIn predicate on the "last_bought" field.
+func LastBoughtNotIn(vs ...time.Time) predicate.Product {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldLastBought), v...))
+	})
+}
+
+// LastBoughtGT applies the GT predicate on the "last_bought" field.
+func LastBoughtGT(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLastBought), v))
+	})
+}
+
+// LastBoughtGTE applies the GTE predicate on the "last_bought" field.
+func LastBoughtGTE(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLastBought), v))
+	})
+}
+
+// LastBoughtLT applies the LT predicate on the "last_bought" field.
+func LastBoughtLT(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLastBought), v))
+	})
+}
+
+// LastBoughtLTE applies the LTE predicate on the "last_bought" field.
+func LastBoughtLTE(v time.Time) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLastBought), v))
+	})
+}
+
+// LastBoughtIsNil applies the IsNil predicate on the "last_bought" field.
+func LastBoughtIsNil() predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldLastBought)))
+	})
+}
+
+// LastBoughtNotNil applies the NotNil predicate on the "last_bought" field.
+func LastBoughtNotNil() predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldLastBought)))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Product) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Product) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Product) predicate.Product {
+	return predicate.Product(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
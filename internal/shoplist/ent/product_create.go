@@ -0,0 +1,420 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
+)
+
+// ProductCreate is the builder for creating a Product entity.
+type ProductCreate struct {
+	config
+	mutation *ProductMutation
+	hooks    []Hook
+}
+
+// SetComunityID sets the "comunity_id" field.
+func (pc *ProductCreate) SetComunityID(s string) *ProductCreate {
+	pc.mutation.SetComunityID(s)
+	return pc
+}
+
+// SetName sets the "name" field.
+func (pc *ProductCreate) SetName(s string) *ProductCreate {
+	pc.mutation.SetName(s)
+	return pc
+}
+
+// SetTitle sets the "title" field.
+func (pc *ProductCreate) SetTitle(s string) *ProductCreate {
+	pc.mutation.SetTitle(s)
+	return pc
+}
+
+// SetCategoryID sets the "category_id" field.
+func (pc *ProductCreate) SetCategoryID(i int) *ProductCreate {
+	pc.mutation.SetCategoryID(i)
+	return pc
+}
+
+// SetNillableCategoryID sets the "category_id" field if the given value is not nil.
+func (pc *ProductCreate) SetNillableCategoryID(i *int) *ProductCreate {
+	if i != nil {
+		pc.SetCategoryID(*i)
+	}
+	return pc
+}
+
+// SetAddedCount sets the "added_count" field.
+func (pc *ProductCreate) SetAddedCount(i int) *ProductCreate {
+	pc.mutation.SetAddedCount(i)
+	return pc
+}
+
+// SetNillableAddedCount sets the "added_count" field if the given value is not nil.
+func (pc *ProductCreate) SetNillableAddedCount(i *int) *ProductCreate {
+	if i != nil {
+		pc.SetAddedCount(*i)
+	}
+	return pc
+}
+
+// SetLastAdded sets the "last_added" field.
+func (pc *ProductCreate) SetLastAdded(t time.Time) *ProductCreate {
+	pc.mutation.SetLastAdded(t)
+	return pc
+}
+
+// SetNillableLastAdded sets the "last_added" field if the given value is not nil.
+func (pc *ProductCreate) SetNillableLastAdded(t *time.Time) *ProductCreate {
+	if t != nil {
+		pc.SetLastAdded(*t)
+	}
+	return pc
+}
+
+// SetBoughtCount sets the "bought_count" field.
+func (pc *ProductCreate) SetBoughtCount(i int) *ProductCreate {
+	pc.mutation.SetBoughtCount(i)
+	return pc
+}
+
+// SetNillableBoughtCount sets the "bought_count" field if the given value is not nil.
+func (pc *ProductCreate) SetNillableBoughtCount(i *int) *ProductCreate {
+	if i != nil {
+		pc.SetBoughtCount(*i)
+	}
+	return pc
+}
+
+// SetLastBought sets the "last_bought" field.
+func (pc *ProductCreate) SetLastBought(t time.Time) *ProductCreate {
+	pc.mutation.SetLastBought(t)
+	return pc
+}
+
+// SetNillableLastBought sets the "last_bought" field if the given value is not nil.
+func (pc *ProductCreate) SetNillableLastBought(t *time.Time) *ProductCreate {
+	if t != nil {
+		pc.SetLastBought(*t)
+	}
+	return pc
+}
+
+// Mutation returns the ProductMutation object of the builder.
+func (pc *ProductCreate) Mutation() *ProductMutation {
+	return pc.mutation
+}
+
+// Save creates the Product in the database.
+func (pc *ProductCreate) Save(ctx context.Context) (*Product, error) {
+	var (
+		err  error
+		node *Product
+	)
+	pc.defaults()
+	if len(pc.hooks) == 0 {
+		if err = pc.check(); err != nil {
+			return nil, err
+		}
+		node, err = pc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ProductMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = pc.check(); err != nil {
+				return nil, err
+			}
+			pc.mutation = mutation
+			if node, err = pc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(pc.hooks) - 1; i >= 0; i-- {
+			if pc.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = pc.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, pc.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*Product)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from ProductMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pc *ProductCreate) SaveX(ctx context.Context) *Product {
+	v, err := pc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pc *ProductCreate) Exec(ctx context.Context) error {
+	_, err := pc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pc *ProductCreate) ExecX(ctx context.Context) {
+	if err := pc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pc *ProductCreate) defaults() {
+	if _, ok := pc.mutation.CategoryID(); !ok {
+		v := product.DefaultCategoryID
+		pc.mutation.SetCategoryID(v)
+	}
+	if _, ok := pc.mutation.AddedCount(); !ok {
+		v := product.DefaultAddedCount
+		pc.mutation.SetAddedCount(v)
+	}
+	if _, ok := pc.mutation.LastAdded(); !ok {
+		v := product.DefaultLastAdded()
+		pc.mutation.SetLastAdded(v)
+	}
+	if _, ok := pc.mutation.BoughtCount(); !ok {
+		v := product.DefaultBoughtCount
+		pc.mutation.SetBoughtCount(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pc *ProductCreate) check() error {
+	if _, ok := pc.mutation.ComunityID(); !ok {
+		return &ValidationError{Name: "comunity_id", err: errors.New(`ent: missing required field "Product.comunity_id"`)}
+	}
+	if v, ok := pc.mutation.ComunityID(); ok {
+		if err := product.ComunityIDValidator(v); err != nil {
+			return &ValidationError{Name: "comunity_id", err: fmt.Errorf(`ent: validator failed for field "Product.comunity_id": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Product.name"`)}
+	}
+	if v, ok := pc.mutation.Name(); ok {
+		if err := product.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Product.name": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.Title(); !ok {
+		return &ValidationError{Name: "title", err: errors.New(`ent: missing required field "Product.title"`)}
+	}
+	if v, ok := pc.mutation.Title(); ok {
+		if err := product.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Product.title": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.CategoryID(); !ok {
+		return &ValidationError{Name: "category_id", err: errors.New(`ent: missing required field "Product.category_id"`)}
+	}
+	if _, ok := pc.mutation.AddedCount(); !ok {
+		return &ValidationError{Name: "added_count", err: errors.New(`ent: missing required field "Product.added_count"`)}
+	}
+	if _, ok := pc.mutation.LastAdded(); !ok {
+		return &ValidationError{Name: "last_added", err: errors.New(`ent: missing required field "Product.last_added"`)}
+	}
+	if _, ok := pc.mutation.BoughtCount(); !ok {
+		return &ValidationError{Name: "bought_count", err: errors.New(`ent: missing required field "Product.bought_count"`)}
+	}
+	return nil
+}
+
+func (pc *ProductCreate) sqlSave(ctx context.Context) (*Product, error) {
+	_node, _spec := pc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (pc *ProductCreate) createSpec() (*Product, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Product{config: pc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: product.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: product.FieldID,
+			},
+		}
+	)
+	if value, ok := pc.mutation.ComunityID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldComunityID,
+		})
+		_node.ComunityID = value
+	}
+	if value, ok := pc.mutation.Name(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldName,
+		})
+		_node.Name = value
+	}
+	if value, ok := pc.mutation.Title(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldTitle,
+		})
+		_node.Title = value
+	}
+	if value, ok := pc.mutation.CategoryID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldCategoryID,
+		})
+		_node.CategoryID = value
+	}
+	if value, ok := pc.mutation.AddedCount(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldAddedCount,
+		})
+		_node.AddedCount = value
+	}
+	if value, ok := pc.mutation.LastAdded(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldLastAdded,
+		})
+		_node.LastAdded = value
+	}
+	if value, ok := pc.mutation.BoughtCount(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldBoughtCount,
+		})
+		_node.BoughtCount = value
+	}
+	if value, ok := pc.mutation.LastBought(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldLastBought,
+		})
+		_node.LastBought = &value
+	}
+	return _node, _spec
+}
+
+// ProductCreateBulk is the builder for creating many Product entities in bulk.
+type ProductCreateBulk struct {
+	config
+	builders []*ProductCreate
+}
+
+// Save creates the Product entities in the database.
+func (pcb *ProductCreateBulk) Save(ctx context.Context) ([]*Product, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(pcb.builders))
+	nodes := make([]*Product, len(pcb.builders))
+	mutators := make([]Mutator, len(pcb.builders))
+	for i := range pcb.builders {
+		func(i int, root context.Context) {
+			builder := pcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ProductMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pcb *ProductCreateBulk) SaveX(ctx context.Context) []*Product {
+	v, err := pcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pcb *ProductCreateBulk) Exec(ctx context.Context) error {
+	_, err := pcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcb *ProductCreateBulk) ExecX(ctx context.Context) {
+	if err := pcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
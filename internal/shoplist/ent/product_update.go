@@ -0,0 +1,622 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/predicate"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
+)
+
+// ProductUpdate is the builder for updating Product entities.
+type ProductUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ProductMutation
+}
+
+// Where appends a list predicates to the ProductUpdate builder.
+func (pu *ProductUpdate) Where(ps ...predicate.Product) *ProductUpdate {
+	pu.mutation.Where(ps...)
+	return pu
+}
+
+// SetTitle sets the "title" field.
+func (pu *ProductUpdate) SetTitle(s string) *ProductUpdate {
+	pu.mutation.SetTitle(s)
+	return pu
+}
+
+// SetCategoryID sets the "category_id" field.
+func (pu *ProductUpdate) SetCategoryID(i int) *ProductUpdate {
+	pu.mutation.ResetCategoryID()
+	pu.mutation.SetCategoryID(i)
+	return pu
+}
+
+// SetNillableCategoryID sets the "category_id" field if the given value is not nil.
+func (pu *ProductUpdate) SetNillableCategoryID(i *int) *ProductUpdate {
+	if i != nil {
+		pu.SetCategoryID(*i)
+	}
+	return pu
+}
+
+// AddCategoryID adds i to the "category_id" field.
+func (pu *ProductUpdate) AddCategoryID(i int) *ProductUpdate {
+	pu.mutation.AddCategoryID(i)
+	return pu
+}
+
+// SetAddedCount sets the "added_count" field.
+func (pu *ProductUpdate) SetAddedCount(i int) *ProductUpdate {
+	pu.mutation.ResetAddedCount()
+	pu.mutation.SetAddedCount(i)
+	return pu
+}
+
+// SetNillableAddedCount sets the "added_count" field if the given value is not nil.
+func (pu *ProductUpdate) SetNillableAddedCount(i *int) *ProductUpdate {
+	if i != nil {
+		pu.SetAddedCount(*i)
+	}
+	return pu
+}
+
+// AddAddedCount adds i to the "added_count" field.
+func (pu *ProductUpdate) AddAddedCount(i int) *ProductUpdate {
+	pu.mutation.AddAddedCount(i)
+	return pu
+}
+
+// SetLastAdded sets the "last_added" field.
+func (pu *ProductUpdate) SetLastAdded(t time.Time) *ProductUpdate {
+	pu.mutation.SetLastAdded(t)
+	return pu
+}
+
+// SetNillableLastAdded sets the "last_added" field if the given value is not nil.
+func (pu *ProductUpdate) SetNillableLastAdded(t *time.Time) *ProductUpdate {
+	if t != nil {
+		pu.SetLastAdded(*t)
+	}
+	return pu
+}
+
+// SetBoughtCount sets the "bought_count" field.
+func (pu *ProductUpdate) SetBoughtCount(i int) *ProductUpdate {
+	pu.mutation.ResetBoughtCount()
+	pu.mutation.SetBoughtCount(i)
+	return pu
+}
+
+// SetNillableBoughtCount sets the "bought_count" field if the given value is not nil.
+func (pu *ProductUpdate) SetNillableBoughtCount(i *int) *ProductUpdate {
+	if i != nil {
+		pu.SetBoughtCount(*i)
+	}
+	return pu
+}
+
+// AddBoughtCount adds i to the "bought_count" field.
+func (pu *ProductUpdate) AddBoughtCount(i int) *ProductUpdate {
+	pu.mutation.AddBoughtCount(i)
+	return pu
+}
+
+// SetLastBought sets the "last_bought" field.
+func (pu *ProductUpdate) SetLastBought(t time.Time) *ProductUpdate {
+	pu.mutation.SetLastBought(t)
+	return pu
+}
+
+// SetNillableLastBought sets the "last_bought" field if the given value is not nil.
+func (pu *ProductUpdate) SetNillableLastBought(t *time.Time) *ProductUpdate {
+	if t != nil {
+		pu.SetLastBought(*t)
+	}
+	return pu
+}
+
+// ClearLastBought clears the value of the "last_bought" field.
+func (pu *ProductUpdate) ClearLastBought() *ProductUpdate {
+	pu.mutation.ClearLastBought()
+	return pu
+}
+
+// Mutation returns the ProductMutation object of the builder.
+func (pu *ProductUpdate) Mutation() *ProductMutation {
+	return pu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pu *ProductUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(pu.hooks) == 0 {
+		if err = pu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = pu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ProductMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = pu.check(); err != nil {
+				return 0, err
+			}
+			pu.mutation = mutation
+			affected, err = pu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(pu.hooks) - 1; i >= 0; i-- {
+			if pu.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = pu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, pu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pu *ProductUpdate) SaveX(ctx context.Context) int {
+	affected, err := pu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pu *ProductUpdate) Exec(ctx context.Context) error {
+	_, err := pu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pu *ProductUpdate) ExecX(ctx context.Context) {
+	if err := pu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pu *ProductUpdate) check() error {
+	if v, ok := pu.mutation.Title(); ok {
+		if err := product.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Product.title": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (pu *ProductUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   product.Table,
+			Columns: product.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: product.FieldID,
+			},
+		},
+	}
+	if ps := pu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := pu.mutation.Title(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldTitle,
+		})
+	}
+	if value, ok := pu.mutation.CategoryID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldCategoryID,
+		})
+	}
+	if value, ok := pu.mutation.AddedCategoryID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldCategoryID,
+		})
+	}
+	if value, ok := pu.mutation.AddedCount(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldAddedCount,
+		})
+	}
+	if value, ok := pu.mutation.AddedAddedCount(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldAddedCount,
+		})
+	}
+	if value, ok := pu.mutation.LastAdded(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldLastAdded,
+		})
+	}
+	if value, ok := pu.mutation.BoughtCount(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldBoughtCount,
+		})
+	}
+	if value, ok := pu.mutation.AddedBoughtCount(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldBoughtCount,
+		})
+	}
+	if value, ok := pu.mutation.LastBought(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldLastBought,
+		})
+	}
+	if pu.mutation.LastBoughtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: product.FieldLastBought,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{product.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// ProductUpdateOne is the builder for updating a single Product entity.
+type ProductUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ProductMutation
+}
+
+// SetTitle sets the "title" field.
+func (puo *ProductUpdateOne) SetTitle(s string) *ProductUpdateOne {
+	puo.mutation.SetTitle(s)
+	return puo
+}
+
+// SetCategoryID sets the "category_id" field.
+func (puo *ProductUpdateOne) SetCategoryID(i int) *ProductUpdateOne {
+	puo.mutation.ResetCategoryID()
+	puo.mutation.SetCategoryID(i)
+	return puo
+}
+
+// SetNillableCategoryID sets the "category_id" field if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableCategoryID(i *int) *ProductUpdateOne {
+	if i != nil {
+		puo.SetCategoryID(*i)
+	}
+	return puo
+}
+
+// AddCategoryID adds i to the "category_id" field.
+func (puo *ProductUpdateOne) AddCategoryID(i int) *ProductUpdateOne {
+	puo.mutation.AddCategoryID(i)
+	return puo
+}
+
+// SetAddedCount sets the "added_count" field.
+func (puo *ProductUpdateOne) SetAddedCount(i int) *ProductUpdateOne {
+	puo.mutation.ResetAddedCount()
+	puo.mutation.SetAddedCount(i)
+	return puo
+}
+
+// SetNillableAddedCount sets the "added_count" field if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableAddedCount(i *int) *ProductUpdateOne {
+	if i != nil {
+		puo.SetAddedCount(*i)
+	}
+	return puo
+}
+
+// AddAddedCount adds i to the "added_count" field.
+func (puo *ProductUpdateOne) AddAddedCount(i int) *ProductUpdateOne {
+	puo.mutation.AddAddedCount(i)
+	return puo
+}
+
+// SetLastAdded sets the "last_added" field.
+func (puo *ProductUpdateOne) SetLastAdded(t time.Time) *ProductUpdateOne {
+	puo.mutation.SetLastAdded(t)
+	return puo
+}
+
+// SetNillableLastAdded sets the "last_added" field if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableLastAdded(t *time.Time) *ProductUpdateOne {
+	if t != nil {
+		puo.SetLastAdded(*t)
+	}
+	return puo
+}
+
+// SetBoughtCount sets the "bought_count" field.
+func (puo *ProductUpdateOne) SetBoughtCount(i int) *ProductUpdateOne {
+	puo.mutation.ResetBoughtCount()
+	puo.mutation.SetBoughtCount(i)
+	return puo
+}
+
+// SetNillableBoughtCount sets the "bought_count" field if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableBoughtCount(i *int) *ProductUpdateOne {
+	if i != nil {
+		puo.SetBoughtCount(*i)
+	}
+	return puo
+}
+
+// AddBoughtCount adds i to the "bought_count" field.
+func (puo *ProductUpdateOne) AddBoughtCount(i int) *ProductUpdateOne {
+	puo.mutation.AddBoughtCount(i)
+	return puo
+}
+
+// SetLastBought sets the "last_bought" field.
+func (puo *ProductUpdateOne) SetLastBought(t time.Time) *ProductUpdateOne {
+	puo.mutation.SetLastBought(t)
+	return puo
+}
+
+// SetNillableLastBought sets the "last_bought" field if the given value is not nil.
+func (puo *ProductUpdateOne) SetNillableLastBought(t *time.Time) *ProductUpdateOne {
+	if t != nil {
+		puo.SetLastBought(*t)
+	}
+	return puo
+}
+
+// ClearLastBought clears the value of the "last_bought" field.
+func (puo *ProductUpdateOne) ClearLastBought() *ProductUpdateOne {
+	puo.mutation.ClearLastBought()
+	return puo
+}
+
+// Mutation returns the ProductMutation object of the builder.
+func (puo *ProductUpdateOne) Mutation() *ProductMutation {
+	return puo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (puo *ProductUpdateOne) Select(field string, fields ...string) *ProductUpdateOne {
+	puo.fields = append([]string{field}, fields...)
+	return puo
+}
+
+// Save executes the query and returns the updated Product entity.
+func (puo *ProductUpdateOne) Save(ctx context.Context) (*Product, error) {
+	var (
+		err  error
+		node *Product
+	)
+	if len(puo.hooks) == 0 {
+		if err = puo.check(); err != nil {
+			return nil, err
+		}
+		node, err = puo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ProductMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = puo.check(); err != nil {
+				return nil, err
+			}
+			puo.mutation = mutation
+			node, err = puo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(puo.hooks) - 1; i >= 0; i-- {
+			if puo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = puo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, puo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*Product)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from ProductMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (puo *ProductUpdateOne) SaveX(ctx context.Context) *Product {
+	node, err := puo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (puo *ProductUpdateOne) Exec(ctx context.Context) error {
+	_, err := puo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (puo *ProductUpdateOne) ExecX(ctx context.Context) {
+	if err := puo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (puo *ProductUpdateOne) check() error {
+	if v, ok := puo.mutation.Title(); ok {
+		if err := product.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Product.title": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (puo *ProductUpdateOne) sqlSave(ctx context.Context) (_node *Product, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   product.Table,
+			Columns: product.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: product.FieldID,
+			},
+		},
+	}
+	id, ok := puo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Product.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := puo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, product.FieldID)
+		for _, f := range fields {
+			if !product.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != product.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := puo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := puo.mutation.Title(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: product.FieldTitle,
+		})
+	}
+	if value, ok := puo.mutation.CategoryID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldCategoryID,
+		})
+	}
+	if value, ok := puo.mutation.AddedCategoryID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldCategoryID,
+		})
+	}
+	if value, ok := puo.mutation.AddedCount(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldAddedCount,
+		})
+	}
+	if value, ok := puo.mutation.AddedAddedCount(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldAddedCount,
+		})
+	}
+	if value, ok := puo.mutation.LastAdded(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldLastAdded,
+		})
+	}
+	if value, ok := puo.mutation.BoughtCount(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldBoughtCount,
+		})
+	}
+	if value, ok := puo.mutation.AddedBoughtCount(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: product.FieldBoughtCount,
+		})
+	}
+	if value, ok := puo.mutation.LastBought(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: product.FieldLastBought,
+		})
+	}
+	if puo.mutation.LastBoughtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: product.FieldLastBought,
+		})
+	}
+	_node = &Product{config: puo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, puo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{product.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -5,7 +5,10 @@ package ent
 import (
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/audit"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/item"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/schema"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shop"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shopping"
@@ -16,6 +19,24 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	auditFields := schema.Audit{}.Fields()
+	_ = auditFields
+	// auditDescKind is the schema descriptor for kind field.
+	auditDescKind := auditFields[3].Descriptor()
+	// audit.KindValidator is a validator for the "kind" field. It is called by the builders before save.
+	audit.KindValidator = auditDescKind.Validators[0].(func(string) error)
+	// auditDescAction is the schema descriptor for action field.
+	auditDescAction := auditFields[4].Descriptor()
+	// audit.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	audit.ActionValidator = auditDescAction.Validators[0].(func(string) error)
+	// auditDescCreated is the schema descriptor for created field.
+	auditDescCreated := auditFields[6].Descriptor()
+	// audit.DefaultCreated holds the default value on creation for the created field.
+	audit.DefaultCreated = auditDescCreated.Default.(func() time.Time)
+	// auditDescUndone is the schema descriptor for undone field.
+	auditDescUndone := auditFields[7].Descriptor()
+	// audit.DefaultUndone holds the default value on creation for the undone field.
+	audit.DefaultUndone = auditDescUndone.Default.(bool)
 	itemFields := schema.Item{}.Fields()
 	_ = itemFields
 	// itemDescProductName is the schema descriptor for product_name field.
@@ -26,14 +47,66 @@ func init() {
 	itemDescQuantity := itemFields[1].Descriptor()
 	// item.DefaultQuantity holds the default value on creation for the quantity field.
 	item.DefaultQuantity = itemDescQuantity.Default.(int)
+	// itemDescUnit is the schema descriptor for unit field.
+	itemDescUnit := itemFields[2].Descriptor()
+	// item.DefaultUnit holds the default value on creation for the unit field.
+	item.DefaultUnit = itemDescUnit.Default.(string)
 	// itemDescCategoryID is the schema descriptor for category_id field.
-	itemDescCategoryID := itemFields[2].Descriptor()
+	itemDescCategoryID := itemFields[3].Descriptor()
 	// item.DefaultCategoryID holds the default value on creation for the category_id field.
 	item.DefaultCategoryID = itemDescCategoryID.Default.(int)
 	// itemDescComplete is the schema descriptor for complete field.
-	itemDescComplete := itemFields[3].Descriptor()
+	itemDescComplete := itemFields[4].Descriptor()
 	// item.DefaultComplete holds the default value on creation for the complete field.
 	item.DefaultComplete = itemDescComplete.Default.(bool)
+	itemcategoryFields := schema.ItemCategory{}.Fields()
+	_ = itemcategoryFields
+	// itemcategoryDescComunityID is the schema descriptor for comunity_id field.
+	itemcategoryDescComunityID := itemcategoryFields[0].Descriptor()
+	// itemcategory.ComunityIDValidator is a validator for the "comunity_id" field. It is called by the builders before save.
+	itemcategory.ComunityIDValidator = itemcategoryDescComunityID.Validators[0].(func(string) error)
+	// itemcategoryDescName is the schema descriptor for name field.
+	itemcategoryDescName := itemcategoryFields[2].Descriptor()
+	// itemcategory.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	itemcategory.NameValidator = itemcategoryDescName.Validators[0].(func(string) error)
+	// itemcategoryDescEmoji is the schema descriptor for emoji field.
+	itemcategoryDescEmoji := itemcategoryFields[3].Descriptor()
+	// itemcategory.DefaultEmoji holds the default value on creation for the emoji field.
+	itemcategory.DefaultEmoji = itemcategoryDescEmoji.Default.(string)
+	// itemcategoryDescSortOrder is the schema descriptor for sort_order field.
+	itemcategoryDescSortOrder := itemcategoryFields[4].Descriptor()
+	// itemcategory.DefaultSortOrder holds the default value on creation for the sort_order field.
+	itemcategory.DefaultSortOrder = itemcategoryDescSortOrder.Default.(int)
+	productFields := schema.Product{}.Fields()
+	_ = productFields
+	// productDescComunityID is the schema descriptor for comunity_id field.
+	productDescComunityID := productFields[0].Descriptor()
+	// product.ComunityIDValidator is a validator for the "comunity_id" field. It is called by the builders before save.
+	product.ComunityIDValidator = productDescComunityID.Validators[0].(func(string) error)
+	// productDescName is the schema descriptor for name field.
+	productDescName := productFields[1].Descriptor()
+	// product.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	product.NameValidator = productDescName.Validators[0].(func(string) error)
+	// productDescTitle is the schema descriptor for title field.
+	productDescTitle := productFields[2].Descriptor()
+	// product.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	product.TitleValidator = productDescTitle.Validators[0].(func(string) error)
+	// productDescCategoryID is the schema descriptor for category_id field.
+	productDescCategoryID := productFields[3].Descriptor()
+	// product.DefaultCategoryID holds the default value on creation for the category_id field.
+	product.DefaultCategoryID = productDescCategoryID.Default.(int)
+	// productDescAddedCount is the schema descriptor for added_count field.
+	productDescAddedCount := productFields[4].Descriptor()
+	// product.DefaultAddedCount holds the default value on creation for the added_count field.
+	product.DefaultAddedCount = productDescAddedCount.Default.(int)
+	// productDescLastAdded is the schema descriptor for last_added field.
+	productDescLastAdded := productFields[5].Descriptor()
+	// product.DefaultLastAdded holds the default value on creation for the last_added field.
+	product.DefaultLastAdded = productDescLastAdded.Default.(func() time.Time)
+	// productDescBoughtCount is the schema descriptor for bought_count field.
+	productDescBoughtCount := productFields[6].Descriptor()
+	// product.DefaultBoughtCount holds the default value on creation for the bought_count field.
+	product.DefaultBoughtCount = productDescBoughtCount.Default.(int)
 	shopFields := schema.Shop{}.Fields()
 	_ = shopFields
 	// shopDescName is the schema descriptor for name field.
@@ -68,4 +141,12 @@ func init() {
 	userDescToken := userFields[3].Descriptor()
 	// user.TokenValidator is a validator for the "token" field. It is called by the builders before save.
 	user.TokenValidator = userDescToken.Validators[0].(func(string) error)
+	// userDescActive is the schema descriptor for active field.
+	userDescActive := userFields[6].Descriptor()
+	// user.DefaultActive holds the default value on creation for the active field.
+	user.DefaultActive = userDescActive.Default.(bool)
+	// userDescDigestMinutes is the schema descriptor for digest_minutes field.
+	userDescDigestMinutes := userFields[8].Descriptor()
+	// user.DefaultDigestMinutes holds the default value on creation for the digest_minutes field.
+	user.DefaultDigestMinutes = userDescDigestMinutes.Default.(int)
 }
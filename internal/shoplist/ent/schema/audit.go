@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Audit holds the schema definition for the Audit entity.
+type Audit struct {
+	ent.Schema
+}
+
+// Fields of the Audit.
+func (Audit) Fields() []ent.Field {
+	return []ent.Field{
+		// user_id is the ent ID of the user who made the change
+		field.Int("user_id").Immutable(),
+		field.Int64("telegram_id").Immutable(),
+		field.String("comunity_id").Immutable(),
+		// kind is "shoplist" or "buget", nodes undo operations of their kind
+		field.String("kind").NotEmpty().Immutable(),
+		// action is the event name, e.g. "item_added"
+		field.String("action").NotEmpty().Immutable(),
+		// payload is the json event with the previous values
+		field.String("payload").Immutable(),
+		field.Time("created").Default(time.Now).Immutable(),
+		field.Bool("undone").Default(false),
+	}
+}
+
+func (Audit) Indexes() []ent.Index {
+	return []ent.Index{
+		// non-unique index.
+		index.Fields(
+			"user_id",
+			"kind",
+			"created",
+		),
+	}
+}
@@ -16,6 +16,9 @@ func (Item) Fields() []ent.Field {
 	return []ent.Field{
 		field.String("product_name").NotEmpty(),
 		field.Int("quantity").Default(1),
+		// unit is the normalized unit of the quantity, empty for pieces
+		field.String("unit").Default(""),
+		// category_id is the ItemCategory ID, 0 is no category
 		field.Int("category_id").Default(0),
 		field.Bool("complete").Default(false),
 	}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ItemCategory holds the schema definition for the ItemCategory entity,
+// it is the store aisle of the community items, e.g. "Молочка".
+type ItemCategory struct {
+	ent.Schema
+}
+
+// Fields of the ItemCategory.
+func (ItemCategory) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("comunity_id").NotEmpty().Immutable(),
+		// key is the keyword dictionary key of the default category, e.g. "dairy"
+		field.String("key").Optional(),
+		field.String("name").NotEmpty(),
+		field.String("emoji").Default(""),
+		// sort_order is the aisle order of the category in the lists
+		field.Int("sort_order").Default(0),
+	}
+}
+
+func (ItemCategory) Indexes() []ent.Index {
+	return []ent.Index{
+		// non-unique index.
+		index.Fields(
+			"comunity_id",
+			"sort_order",
+		),
+		// defaults are seeded once even if the community opens lists concurrently,
+		// names may differ by the language of the user who seeded them
+		index.Fields(
+			"comunity_id",
+			"key",
+		).Unique(),
+		index.Fields(
+			"comunity_id",
+			"name",
+		).Unique(),
+	}
+}
@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Product holds the schema definition for the Product entity,
+// it is the product of the community catalog learned from the items.
+type Product struct {
+	ent.Schema
+}
+
+// Fields of the Product.
+func (Product) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("comunity_id").NotEmpty().Immutable(),
+		// name is the normalized product name, see category.Normalize
+		field.String("name").NotEmpty().Immutable(),
+		// title is the name items of the product get, it is the first spelling
+		field.String("title").NotEmpty(),
+		// category_id is the ItemCategory ID chosen for the product, 0 is not chosen
+		field.Int("category_id").Default(0),
+		field.Int("added_count").Default(0),
+		field.Time("last_added").Default(time.Now),
+		field.Int("bought_count").Default(0),
+		field.Time("last_bought").Optional().Nillable(),
+	}
+}
+
+func (Product) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields(
+			"comunity_id",
+			"name",
+		).Unique(),
+	}
+}
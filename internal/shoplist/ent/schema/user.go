@@ -20,6 +20,17 @@ func (User) Fields() []ent.Field {
 		field.String("comunity_id").NotEmpty(),
 		field.String("token").NotEmpty().Immutable(),
 		field.Int64("chat_id").Immutable(),
+		// language is set in settings, empty means telegram language
+		field.String("language").Optional(),
+		// active is false when the user blocked the bot, notifications are not sent
+		field.Bool("active").Default(true),
+		// notify is the mode of the community notifications
+		field.Enum("notify").Values("immediate", "digest", "off").Default("immediate"),
+		// digest is sent every digest_minutes or daily at digest_time ("20:00") if it is set
+		field.Int("digest_minutes").Default(60),
+		field.String("digest_time").Optional(),
+		// digest_zone is the IANA time zone of digest_time, empty is the bot time zone
+		field.String("digest_zone").Optional(),
 	}
 }
 
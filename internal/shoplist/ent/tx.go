@@ -12,8 +12,14 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// Audit is the client for interacting with the Audit builders.
+	Audit *AuditClient
 	// Item is the client for interacting with the Item builders.
 	Item *ItemClient
+	// ItemCategory is the client for interacting with the ItemCategory builders.
+	ItemCategory *ItemCategoryClient
+	// Product is the client for interacting with the Product builders.
+	Product *ProductClient
 	// Shop is the client for interacting with the Shop builders.
 	Shop *ShopClient
 	// Shopping is the client for interacting with the Shopping builders.
@@ -155,7 +161,10 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.Audit = NewAuditClient(tx.config)
 	tx.Item = NewItemClient(tx.config)
+	tx.ItemCategory = NewItemCategoryClient(tx.config)
+	tx.Product = NewProductClient(tx.config)
 	tx.Shop = NewShopClient(tx.config)
 	tx.Shopping = NewShoppingClient(tx.config)
 	tx.User = NewUserClient(tx.config)
@@ -168,7 +177,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Item.QueryXXX(), the query will be executed
+// applies a query, for example: Audit.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
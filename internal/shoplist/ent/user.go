@@ -25,6 +25,18 @@ type User struct {
 	Token string `json:"token,omitempty"`
 	// ChatID holds the value of the "chat_id" field.
 	ChatID int64 `json:"chat_id,omitempty"`
+	// Language holds the value of the "language" field.
+	Language string `json:"language,omitempty"`
+	// Active holds the value of the "active" field.
+	Active bool `json:"active,omitempty"`
+	// Notify holds the value of the "notify" field.
+	Notify user.Notify `json:"notify,omitempty"`
+	// DigestMinutes holds the value of the "digest_minutes" field.
+	DigestMinutes int `json:"digest_minutes,omitempty"`
+	// DigestTime holds the value of the "digest_time" field.
+	DigestTime string `json:"digest_time,omitempty"`
+	// DigestZone holds the value of the "digest_zone" field.
+	DigestZone string `json:"digest_zone,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the UserQuery when eager-loading is set.
 	Edges UserEdges `json:"edges"`
@@ -53,9 +65,11 @@ func (*User) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldID, user.FieldTelegramID, user.FieldChatID:
+		case user.FieldActive:
+			values[i] = new(sql.NullBool)
+		case user.FieldID, user.FieldTelegramID, user.FieldChatID, user.FieldDigestMinutes:
 			values[i] = new(sql.NullInt64)
-		case user.FieldTelegramUsername, user.FieldComunityID, user.FieldToken:
+		case user.FieldTelegramUsername, user.FieldComunityID, user.FieldToken, user.FieldLanguage, user.FieldNotify, user.FieldDigestTime, user.FieldDigestZone:
 			values[i] = new(sql.NullString)
 		default:
 			return nil, fmt.Errorf("unexpected column %q for type User", columns[i])
@@ -108,6 +122,42 @@ func (u *User) assignValues(columns []string, values []interface{}) error {
 			} else if value.Valid {
 				u.ChatID = value.Int64
 			}
+		case user.FieldLanguage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field language", values[i])
+			} else if value.Valid {
+				u.Language = value.String
+			}
+		case user.FieldActive:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field active", values[i])
+			} else if value.Valid {
+				u.Active = value.Bool
+			}
+		case user.FieldNotify:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field notify", values[i])
+			} else if value.Valid {
+				u.Notify = user.Notify(value.String)
+			}
+		case user.FieldDigestMinutes:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field digest_minutes", values[i])
+			} else if value.Valid {
+				u.DigestMinutes = int(value.Int64)
+			}
+		case user.FieldDigestTime:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field digest_time", values[i])
+			} else if value.Valid {
+				u.DigestTime = value.String
+			}
+		case user.FieldDigestZone:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field digest_zone", values[i])
+			} else if value.Valid {
+				u.DigestZone = value.String
+			}
 		}
 	}
 	return nil
@@ -155,6 +205,24 @@ func (u *User) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("chat_id=")
 	builder.WriteString(fmt.Sprintf("%v", u.ChatID))
+	builder.WriteString(", ")
+	builder.WriteString("language=")
+	builder.WriteString(u.Language)
+	builder.WriteString(", ")
+	builder.WriteString("active=")
+	builder.WriteString(fmt.Sprintf("%v", u.Active))
+	builder.WriteString(", ")
+	builder.WriteString("notify=")
+	builder.WriteString(fmt.Sprintf("%v", u.Notify))
+	builder.WriteString(", ")
+	builder.WriteString("digest_minutes=")
+	builder.WriteString(fmt.Sprintf("%v", u.DigestMinutes))
+	builder.WriteString(", ")
+	builder.WriteString("digest_time=")
+	builder.WriteString(u.DigestTime)
+	builder.WriteString(", ")
+	builder.WriteString("digest_zone=")
+	builder.WriteString(u.DigestZone)
 	builder.WriteByte(')')
 	return builder.String()
 }
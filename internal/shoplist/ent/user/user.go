@@ -2,6 +2,10 @@
 
 package user
 
+import (
+	"fmt"
+)
+
 const (
 	// Label holds the string label denoting the user type in the database.
 	Label = "user"
@@ -17,6 +21,18 @@ const (
 	FieldToken = "token"
 	// FieldChatID holds the string denoting the chat_id field in the database.
 	FieldChatID = "chat_id"
+	// FieldLanguage holds the string denoting the language field in the database.
+	FieldLanguage = "language"
+	// FieldActive holds the string denoting the active field in the database.
+	FieldActive = "active"
+	// FieldNotify holds the string denoting the notify field in the database.
+	FieldNotify = "notify"
+	// FieldDigestMinutes holds the string denoting the digest_minutes field in the database.
+	FieldDigestMinutes = "digest_minutes"
+	// FieldDigestTime holds the string denoting the digest_time field in the database.
+	FieldDigestTime = "digest_time"
+	// FieldDigestZone holds the string denoting the digest_zone field in the database.
+	FieldDigestZone = "digest_zone"
 	// EdgeShopping holds the string denoting the shopping edge name in mutations.
 	EdgeShopping = "shopping"
 	// Table holds the table name of the user in the database.
@@ -38,6 +54,12 @@ var Columns = []string{
 	FieldComunityID,
 	FieldToken,
 	FieldChatID,
+	FieldLanguage,
+	FieldActive,
+	FieldNotify,
+	FieldDigestMinutes,
+	FieldDigestTime,
+	FieldDigestZone,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -55,4 +77,35 @@ var (
 	ComunityIDValidator func(string) error
 	// TokenValidator is a validator for the "token" field. It is called by the builders before save.
 	TokenValidator func(string) error
+	// DefaultActive holds the default value on creation for the "active" field.
+	DefaultActive bool
+	// DefaultDigestMinutes holds the default value on creation for the "digest_minutes" field.
+	DefaultDigestMinutes int
+)
+
+// Notify defines the type for the "notify" enum field.
+type Notify string
+
+// NotifyImmediate is the default value of the Notify enum.
+const DefaultNotify = NotifyImmediate
+
+// Notify values.
+const (
+	NotifyImmediate Notify = "immediate"
+	NotifyDigest    Notify = "digest"
+	NotifyOff       Notify = "off"
 )
+
+func (n Notify) String() string {
+	return string(n)
+}
+
+// NotifyValidator is a validator for the "notify" field enum values. It is called by the builders before save.
+func NotifyValidator(n Notify) error {
+	switch n {
+	case NotifyImmediate, NotifyDigest, NotifyOff:
+		return nil
+	default:
+		return fmt.Errorf("user: invalid enum value for notify field: %q", n)
+	}
+}
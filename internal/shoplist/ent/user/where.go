@@ -114,6 +114,41 @@ func ChatID(v int64) predicate.User {
 	})
 }
 
+// Language applies equality check predicate on the "language" field. It's identical to LanguageEQ.
+func Language(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLanguage), v))
+	})
+}
+
+// Active applies equality check predicate on the "active" field. It's identical to ActiveEQ.
+func Active(v bool) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldActive), v))
+	})
+}
+
+// DigestMinutes applies equality check predicate on the "digest_minutes" field. It's identical to DigestMinutesEQ.
+func DigestMinutes(v int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDigestMinutes), v))
+	})
+}
+
+// DigestTime applies equality check predicate on the "digest_time" field. It's identical to DigestTimeEQ.
+func DigestTime(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestZone applies equality check predicate on the "digest_zone" field. It's identical to DigestZoneEQ.
+func DigestZone(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDigestZone), v))
+	})
+}
+
 // TelegramIDEQ applies the EQ predicate on the "telegram_id" field.
 func TelegramIDEQ(v int64) predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -539,6 +574,459 @@ func ChatIDLTE(v int64) predicate.User {
 	})
 }
 
+// LanguageEQ applies the EQ predicate on the "language" field.
+func LanguageEQ(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageNEQ applies the NEQ predicate on the "language" field.
+func LanguageNEQ(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageIn applies the In predicate on the "language" field.
+func LanguageIn(vs ...string) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldLanguage), v...))
+	})
+}
+
+// LanguageNotIn applies the NotIn predicate on the "language" field.
+func LanguageNotIn(vs ...string) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldLanguage), v...))
+	})
+}
+
+// LanguageGT applies the GT predicate on the "language" field.
+func LanguageGT(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageGTE applies the GTE predicate on the "language" field.
+func LanguageGTE(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageLT applies the LT predicate on the "language" field.
+func LanguageLT(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageLTE applies the LTE predicate on the "language" field.
+func LanguageLTE(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageContains applies the Contains predicate on the "language" field.
+func LanguageContains(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageHasPrefix applies the HasPrefix predicate on the "language" field.
+func LanguageHasPrefix(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageHasSuffix applies the HasSuffix predicate on the "language" field.
+func LanguageHasSuffix(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageIsNil applies the IsNil predicate on the "language" field.
+func LanguageIsNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldLanguage)))
+	})
+}
+
+// LanguageNotNil applies the NotNil predicate on the "language" field.
+func LanguageNotNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldLanguage)))
+	})
+}
+
+// LanguageEqualFold applies the EqualFold predicate on the "language" field.
+func LanguageEqualFold(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldLanguage), v))
+	})
+}
+
+// LanguageContainsFold applies the ContainsFold predicate on the "language" field.
+func LanguageContainsFold(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldLanguage), v))
+	})
+}
+
+// ActiveEQ applies the EQ predicate on the "active" field.
+func ActiveEQ(v bool) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldActive), v))
+	})
+}
+
+// ActiveNEQ applies the NEQ predicate on the "active" field.
+func ActiveNEQ(v bool) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldActive), v))
+	})
+}
+
+// NotifyEQ applies the EQ predicate on the "notify" field.
+func NotifyEQ(v Notify) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldNotify), v))
+	})
+}
+
+// NotifyNEQ applies the NEQ predicate on the "notify" field.
+func NotifyNEQ(v Notify) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldNotify), v))
+	})
+}
+
+// NotifyIn applies the In predicate on the "notify" field.
+func NotifyIn(vs ...Notify) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldNotify), v...))
+	})
+}
+
+// NotifyNotIn applies the NotIn predicate on the "notify" field.
+func NotifyNotIn(vs ...Notify) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldNotify), v...))
+	})
+}
+
+// DigestMinutesEQ applies the EQ predicate on the "digest_minutes" field.
+func DigestMinutesEQ(v int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDigestMinutes), v))
+	})
+}
+
+// DigestMinutesNEQ applies the NEQ predicate on the "digest_minutes" field.
+func DigestMinutesNEQ(v int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDigestMinutes), v))
+	})
+}
+
+// DigestMinutesIn applies the In predicate on the "digest_minutes" field.
+func DigestMinutesIn(vs ...int) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldDigestMinutes), v...))
+	})
+}
+
+// DigestMinutesNotIn applies the NotIn predicate on the "digest_minutes" field.
+func DigestMinutesNotIn(vs ...int) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldDigestMinutes), v...))
+	})
+}
+
+// DigestMinutesGT applies the GT predicate on the "digest_minutes" field.
+func DigestMinutesGT(v int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldDigestMinutes), v))
+	})
+}
+
+// DigestMinutesGTE applies the GTE predicate on the "digest_minutes" field.
+func DigestMinutesGTE(v int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldDigestMinutes), v))
+	})
+}
+
+// DigestMinutesLT applies the LT predicate on the "digest_minutes" field.
+func DigestMinutesLT(v int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldDigestMinutes), v))
+	})
+}
+
+// DigestMinutesLTE applies the LTE predicate on the "digest_minutes" field.
+func DigestMinutesLTE(v int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldDigestMinutes), v))
+	})
+}
+
+// DigestTimeEQ applies the EQ predicate on the "digest_time" field.
+func DigestTimeEQ(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeNEQ applies the NEQ predicate on the "digest_time" field.
+func DigestTimeNEQ(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeIn applies the In predicate on the "digest_time" field.
+func DigestTimeIn(vs ...string) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldDigestTime), v...))
+	})
+}
+
+// DigestTimeNotIn applies the NotIn predicate on the "digest_time" field.
+func DigestTimeNotIn(vs ...string) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldDigestTime), v...))
+	})
+}
+
+// DigestTimeGT applies the GT predicate on the "digest_time" field.
+func DigestTimeGT(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeGTE applies the GTE predicate on the "digest_time" field.
+func DigestTimeGTE(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeLT applies the LT predicate on the "digest_time" field.
+func DigestTimeLT(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeLTE applies the LTE predicate on the "digest_time" field.
+func DigestTimeLTE(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeContains applies the Contains predicate on the "digest_time" field.
+func DigestTimeContains(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeHasPrefix applies the HasPrefix predicate on the "digest_time" field.
+func DigestTimeHasPrefix(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeHasSuffix applies the HasSuffix predicate on the "digest_time" field.
+func DigestTimeHasSuffix(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeIsNil applies the IsNil predicate on the "digest_time" field.
+func DigestTimeIsNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldDigestTime)))
+	})
+}
+
+// DigestTimeNotNil applies the NotNil predicate on the "digest_time" field.
+func DigestTimeNotNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldDigestTime)))
+	})
+}
+
+// DigestTimeEqualFold applies the EqualFold predicate on the "digest_time" field.
+func DigestTimeEqualFold(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestTimeContainsFold applies the ContainsFold predicate on the "digest_time" field.
+func DigestTimeContainsFold(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldDigestTime), v))
+	})
+}
+
+// DigestZoneEQ applies the EQ predicate on the "digest_zone" field.
+func DigestZoneEQ(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneNEQ applies the NEQ predicate on the "digest_zone" field.
+func DigestZoneNEQ(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneIn applies the In predicate on the "digest_zone" field.
+func DigestZoneIn(vs ...string) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldDigestZone), v...))
+	})
+}
+
+// DigestZoneNotIn applies the NotIn predicate on the "digest_zone" field.
+func DigestZoneNotIn(vs ...string) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldDigestZone), v...))
+	})
+}
+
+// DigestZoneGT applies the GT predicate on the "digest_zone" field.
+func DigestZoneGT(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneGTE applies the GTE predicate on the "digest_zone" field.
+func DigestZoneGTE(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneLT applies the LT predicate on the "digest_zone" field.
+func DigestZoneLT(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneLTE applies the LTE predicate on the "digest_zone" field.
+func DigestZoneLTE(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneContains applies the Contains predicate on the "digest_zone" field.
+func DigestZoneContains(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneHasPrefix applies the HasPrefix predicate on the "digest_zone" field.
+func DigestZoneHasPrefix(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneHasSuffix applies the HasSuffix predicate on the "digest_zone" field.
+func DigestZoneHasSuffix(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneIsNil applies the IsNil predicate on the "digest_zone" field.
+func DigestZoneIsNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldDigestZone)))
+	})
+}
+
+// DigestZoneNotNil applies the NotNil predicate on the "digest_zone" field.
+func DigestZoneNotNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldDigestZone)))
+	})
+}
+
+// DigestZoneEqualFold applies the EqualFold predicate on the "digest_zone" field.
+func DigestZoneEqualFold(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldDigestZone), v))
+	})
+}
+
+// DigestZoneContainsFold applies the ContainsFold predicate on the "digest_zone" field.
+func DigestZoneContainsFold(v string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldDigestZone), v))
+	})
+}
+
 // HasShopping applies the HasEdge predicate on the "shopping" edge.
 func HasShopping() predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -50,6 +50,90 @@ func (uc *UserCreate) SetChatID(i int64) *UserCreate {
 	return uc
 }
 
+// SetLanguage sets the "language" field.
+func (uc *UserCreate) SetLanguage(s string) *UserCreate {
+	uc.mutation.SetLanguage(s)
+	return uc
+}
+
+// SetNillableLanguage sets the "language" field if the given value is not nil.
+func (uc *UserCreate) SetNillableLanguage(s *string) *UserCreate {
+	if s != nil {
+		uc.SetLanguage(*s)
+	}
+	return uc
+}
+
+// SetActive sets the "active" field.
+func (uc *UserCreate) SetActive(b bool) *UserCreate {
+	uc.mutation.SetActive(b)
+	return uc
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (uc *UserCreate) SetNillableActive(b *bool) *UserCreate {
+	if b != nil {
+		uc.SetActive(*b)
+	}
+	return uc
+}
+
+// SetNotify sets the "notify" field.
+func (uc *UserCreate) SetNotify(u user.Notify) *UserCreate {
+	uc.mutation.SetNotify(u)
+	return uc
+}
+
+// SetNillableNotify sets the "notify" field if the given value is not nil.
+func (uc *UserCreate) SetNillableNotify(u *user.Notify) *UserCreate {
+	if u != nil {
+		uc.SetNotify(*u)
+	}
+	return uc
+}
+
+// SetDigestMinutes sets the "digest_minutes" field.
+func (uc *UserCreate) SetDigestMinutes(i int) *UserCreate {
+	uc.mutation.SetDigestMinutes(i)
+	return uc
+}
+
+// SetNillableDigestMinutes sets the "digest_minutes" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDigestMinutes(i *int) *UserCreate {
+	if i != nil {
+		uc.SetDigestMinutes(*i)
+	}
+	return uc
+}
+
+// SetDigestTime sets the "digest_time" field.
+func (uc *UserCreate) SetDigestTime(s string) *UserCreate {
+	uc.mutation.SetDigestTime(s)
+	return uc
+}
+
+// SetNillableDigestTime sets the "digest_time" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDigestTime(s *string) *UserCreate {
+	if s != nil {
+		uc.SetDigestTime(*s)
+	}
+	return uc
+}
+
+// SetDigestZone sets the "digest_zone" field.
+func (uc *UserCreate) SetDigestZone(s string) *UserCreate {
+	uc.mutation.SetDigestZone(s)
+	return uc
+}
+
+// SetNillableDigestZone sets the "digest_zone" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDigestZone(s *string) *UserCreate {
+	if s != nil {
+		uc.SetDigestZone(*s)
+	}
+	return uc
+}
+
 // AddShoppingIDs adds the "shopping" edge to the Shopping entity by IDs.
 func (uc *UserCreate) AddShoppingIDs(ids ...int) *UserCreate {
 	uc.mutation.AddShoppingIDs(ids...)
@@ -76,6 +160,7 @@ func (uc *UserCreate) Save(ctx context.Context) (*User, error) {
 		err  error
 		node *User
 	)
+	uc.defaults()
 	if len(uc.hooks) == 0 {
 		if err = uc.check(); err != nil {
 			return nil, err
@@ -139,6 +224,22 @@ func (uc *UserCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (uc *UserCreate) defaults() {
+	if _, ok := uc.mutation.Active(); !ok {
+		v := user.DefaultActive
+		uc.mutation.SetActive(v)
+	}
+	if _, ok := uc.mutation.Notify(); !ok {
+		v := user.DefaultNotify
+		uc.mutation.SetNotify(v)
+	}
+	if _, ok := uc.mutation.DigestMinutes(); !ok {
+		v := user.DefaultDigestMinutes
+		uc.mutation.SetDigestMinutes(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (uc *UserCreate) check() error {
 	if _, ok := uc.mutation.TelegramID(); !ok {
@@ -166,6 +267,20 @@ func (uc *UserCreate) check() error {
 	if _, ok := uc.mutation.ChatID(); !ok {
 		return &ValidationError{Name: "chat_id", err: errors.New(`ent: missing required field "User.chat_id"`)}
 	}
+	if _, ok := uc.mutation.Active(); !ok {
+		return &ValidationError{Name: "active", err: errors.New(`ent: missing required field "User.active"`)}
+	}
+	if _, ok := uc.mutation.Notify(); !ok {
+		return &ValidationError{Name: "notify", err: errors.New(`ent: missing required field "User.notify"`)}
+	}
+	if v, ok := uc.mutation.Notify(); ok {
+		if err := user.NotifyValidator(v); err != nil {
+			return &ValidationError{Name: "notify", err: fmt.Errorf(`ent: validator failed for field "User.notify": %w`, err)}
+		}
+	}
+	if _, ok := uc.mutation.DigestMinutes(); !ok {
+		return &ValidationError{Name: "digest_minutes", err: errors.New(`ent: missing required field "User.digest_minutes"`)}
+	}
 	return nil
 }
 
@@ -233,6 +348,54 @@ func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		})
 		_node.ChatID = value
 	}
+	if value, ok := uc.mutation.Language(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldLanguage,
+		})
+		_node.Language = value
+	}
+	if value, ok := uc.mutation.Active(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: user.FieldActive,
+		})
+		_node.Active = value
+	}
+	if value, ok := uc.mutation.Notify(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: user.FieldNotify,
+		})
+		_node.Notify = value
+	}
+	if value, ok := uc.mutation.DigestMinutes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: user.FieldDigestMinutes,
+		})
+		_node.DigestMinutes = value
+	}
+	if value, ok := uc.mutation.DigestTime(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDigestTime,
+		})
+		_node.DigestTime = value
+	}
+	if value, ok := uc.mutation.DigestZone(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDigestZone,
+		})
+		_node.DigestZone = value
+	}
 	if nodes := uc.mutation.ShoppingIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -269,6 +432,7 @@ func (ucb *UserCreateBulk) Save(ctx context.Context) ([]*User, error) {
 	for i := range ucb.builders {
 		func(i int, root context.Context) {
 			builder := ucb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*UserMutation)
 				if !ok {
@@ -40,6 +40,115 @@ func (uu *UserUpdate) SetComunityID(s string) *UserUpdate {
 	return uu
 }
 
+// SetLanguage sets the "language" field.
+func (uu *UserUpdate) SetLanguage(s string) *UserUpdate {
+	uu.mutation.SetLanguage(s)
+	return uu
+}
+
+// SetNillableLanguage sets the "language" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableLanguage(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetLanguage(*s)
+	}
+	return uu
+}
+
+// ClearLanguage clears the value of the "language" field.
+func (uu *UserUpdate) ClearLanguage() *UserUpdate {
+	uu.mutation.ClearLanguage()
+	return uu
+}
+
+// SetActive sets the "active" field.
+func (uu *UserUpdate) SetActive(b bool) *UserUpdate {
+	uu.mutation.SetActive(b)
+	return uu
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableActive(b *bool) *UserUpdate {
+	if b != nil {
+		uu.SetActive(*b)
+	}
+	return uu
+}
+
+// SetNotify sets the "notify" field.
+func (uu *UserUpdate) SetNotify(u user.Notify) *UserUpdate {
+	uu.mutation.SetNotify(u)
+	return uu
+}
+
+// SetNillableNotify sets the "notify" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableNotify(u *user.Notify) *UserUpdate {
+	if u != nil {
+		uu.SetNotify(*u)
+	}
+	return uu
+}
+
+// SetDigestMinutes sets the "digest_minutes" field.
+func (uu *UserUpdate) SetDigestMinutes(i int) *UserUpdate {
+	uu.mutation.ResetDigestMinutes()
+	uu.mutation.SetDigestMinutes(i)
+	return uu
+}
+
+// SetNillableDigestMinutes sets the "digest_minutes" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDigestMinutes(i *int) *UserUpdate {
+	if i != nil {
+		uu.SetDigestMinutes(*i)
+	}
+	return uu
+}
+
+// AddDigestMinutes adds i to the "digest_minutes" field.
+func (uu *UserUpdate) AddDigestMinutes(i int) *UserUpdate {
+	uu.mutation.AddDigestMinutes(i)
+	return uu
+}
+
+// SetDigestTime sets the "digest_time" field.
+func (uu *UserUpdate) SetDigestTime(s string) *UserUpdate {
+	uu.mutation.SetDigestTime(s)
+	return uu
+}
+
+// SetNillableDigestTime sets the "digest_time" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDigestTime(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetDigestTime(*s)
+	}
+	return uu
+}
+
+// ClearDigestTime clears the value of the "digest_time" field.
+func (uu *UserUpdate) ClearDigestTime() *UserUpdate {
+	uu.mutation.ClearDigestTime()
+	return uu
+}
+
+// SetDigestZone sets the "digest_zone" field.
+func (uu *UserUpdate) SetDigestZone(s string) *UserUpdate {
+	uu.mutation.SetDigestZone(s)
+	return uu
+}
+
+// SetNillableDigestZone sets the "digest_zone" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDigestZone(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetDigestZone(*s)
+	}
+	return uu
+}
+
+// ClearDigestZone clears the value of the "digest_zone" field.
+func (uu *UserUpdate) ClearDigestZone() *UserUpdate {
+	uu.mutation.ClearDigestZone()
+	return uu
+}
+
 // AddShoppingIDs adds the "shopping" edge to the Shopping entity by IDs.
 func (uu *UserUpdate) AddShoppingIDs(ids ...int) *UserUpdate {
 	uu.mutation.AddShoppingIDs(ids...)
@@ -148,6 +257,11 @@ func (uu *UserUpdate) check() error {
 			return &ValidationError{Name: "comunity_id", err: fmt.Errorf(`ent: validator failed for field "User.comunity_id": %w`, err)}
 		}
 	}
+	if v, ok := uu.mutation.Notify(); ok {
+		if err := user.NotifyValidator(v); err != nil {
+			return &ValidationError{Name: "notify", err: fmt.Errorf(`ent: validator failed for field "User.notify": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -183,6 +297,73 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: user.FieldComunityID,
 		})
 	}
+	if value, ok := uu.mutation.Language(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldLanguage,
+		})
+	}
+	if uu.mutation.LanguageCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldLanguage,
+		})
+	}
+	if value, ok := uu.mutation.Active(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: user.FieldActive,
+		})
+	}
+	if value, ok := uu.mutation.Notify(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: user.FieldNotify,
+		})
+	}
+	if value, ok := uu.mutation.DigestMinutes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: user.FieldDigestMinutes,
+		})
+	}
+	if value, ok := uu.mutation.AddedDigestMinutes(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: user.FieldDigestMinutes,
+		})
+	}
+	if value, ok := uu.mutation.DigestTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDigestTime,
+		})
+	}
+	if uu.mutation.DigestTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldDigestTime,
+		})
+	}
+	if value, ok := uu.mutation.DigestZone(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDigestZone,
+		})
+	}
+	if uu.mutation.DigestZoneCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldDigestZone,
+		})
+	}
 	if uu.mutation.ShoppingCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -268,6 +449,115 @@ func (uuo *UserUpdateOne) SetComunityID(s string) *UserUpdateOne {
 	return uuo
 }
 
+// SetLanguage sets the "language" field.
+func (uuo *UserUpdateOne) SetLanguage(s string) *UserUpdateOne {
+	uuo.mutation.SetLanguage(s)
+	return uuo
+}
+
+// SetNillableLanguage sets the "language" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableLanguage(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetLanguage(*s)
+	}
+	return uuo
+}
+
+// ClearLanguage clears the value of the "language" field.
+func (uuo *UserUpdateOne) ClearLanguage() *UserUpdateOne {
+	uuo.mutation.ClearLanguage()
+	return uuo
+}
+
+// SetActive sets the "active" field.
+func (uuo *UserUpdateOne) SetActive(b bool) *UserUpdateOne {
+	uuo.mutation.SetActive(b)
+	return uuo
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableActive(b *bool) *UserUpdateOne {
+	if b != nil {
+		uuo.SetActive(*b)
+	}
+	return uuo
+}
+
+// SetNotify sets the "notify" field.
+func (uuo *UserUpdateOne) SetNotify(u user.Notify) *UserUpdateOne {
+	uuo.mutation.SetNotify(u)
+	return uuo
+}
+
+// SetNillableNotify sets the "notify" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableNotify(u *user.Notify) *UserUpdateOne {
+	if u != nil {
+		uuo.SetNotify(*u)
+	}
+	return uuo
+}
+
+// SetDigestMinutes sets the "digest_minutes" field.
+func (uuo *UserUpdateOne) SetDigestMinutes(i int) *UserUpdateOne {
+	uuo.mutation.ResetDigestMinutes()
+	uuo.mutation.SetDigestMinutes(i)
+	return uuo
+}
+
+// SetNillableDigestMinutes sets the "digest_minutes" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDigestMinutes(i *int) *UserUpdateOne {
+	if i != nil {
+		uuo.SetDigestMinutes(*i)
+	}
+	return uuo
+}
+
+// AddDigestMinutes adds i to the "digest_minutes" field.
+func (uuo *UserUpdateOne) AddDigestMinutes(i int) *UserUpdateOne {
+	uuo.mutation.AddDigestMinutes(i)
+	return uuo
+}
+
+// SetDigestTime sets the "digest_time" field.
+func (uuo *UserUpdateOne) SetDigestTime(s string) *UserUpdateOne {
+	uuo.mutation.SetDigestTime(s)
+	return uuo
+}
+
+// SetNillableDigestTime sets the "digest_time" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDigestTime(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetDigestTime(*s)
+	}
+	return uuo
+}
+
+// ClearDigestTime clears the value of the "digest_time" field.
+func (uuo *UserUpdateOne) ClearDigestTime() *UserUpdateOne {
+	uuo.mutation.ClearDigestTime()
+	return uuo
+}
+
+// SetDigestZone sets the "digest_zone" field.
+func (uuo *UserUpdateOne) SetDigestZone(s string) *UserUpdateOne {
+	uuo.mutation.SetDigestZone(s)
+	return uuo
+}
+
+// SetNillableDigestZone sets the "digest_zone" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDigestZone(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetDigestZone(*s)
+	}
+	return uuo
+}
+
+// ClearDigestZone clears the value of the "digest_zone" field.
+func (uuo *UserUpdateOne) ClearDigestZone() *UserUpdateOne {
+	uuo.mutation.ClearDigestZone()
+	return uuo
+}
+
 // AddShoppingIDs adds the "shopping" edge to the Shopping entity by IDs.
 func (uuo *UserUpdateOne) AddShoppingIDs(ids ...int) *UserUpdateOne {
 	uuo.mutation.AddShoppingIDs(ids...)
@@ -389,6 +679,11 @@ func (uuo *UserUpdateOne) check() error {
 			return &ValidationError{Name: "comunity_id", err: fmt.Errorf(`ent: validator failed for field "User.comunity_id": %w`, err)}
 		}
 	}
+	if v, ok := uuo.mutation.Notify(); ok {
+		if err := user.NotifyValidator(v); err != nil {
+			return &ValidationError{Name: "notify", err: fmt.Errorf(`ent: validator failed for field "User.notify": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -441,6 +736,73 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 			Column: user.FieldComunityID,
 		})
 	}
+	if value, ok := uuo.mutation.Language(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldLanguage,
+		})
+	}
+	if uuo.mutation.LanguageCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldLanguage,
+		})
+	}
+	if value, ok := uuo.mutation.Active(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: user.FieldActive,
+		})
+	}
+	if value, ok := uuo.mutation.Notify(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: user.FieldNotify,
+		})
+	}
+	if value, ok := uuo.mutation.DigestMinutes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: user.FieldDigestMinutes,
+		})
+	}
+	if value, ok := uuo.mutation.AddedDigestMinutes(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: user.FieldDigestMinutes,
+		})
+	}
+	if value, ok := uuo.mutation.DigestTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDigestTime,
+		})
+	}
+	if uuo.mutation.DigestTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldDigestTime,
+		})
+	}
+	if value, ok := uuo.mutation.DigestZone(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDigestZone,
+		})
+	}
+	if uuo.mutation.DigestZoneCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldDigestZone,
+		})
+	}
 	if uuo.mutation.ShoppingCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -0,0 +1,87 @@
+package logic
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// ActionType is the kind of outbound telegram action
+type ActionType int
+
+const (
+	// ActionEdit edits the current message of the session
+	ActionEdit ActionType = iota
+	// ActionSend sends a new message, it becomes the current message
+	ActionSend
+	// ActionPhoto sends a photo
+	ActionPhoto
+	// ActionDocument sends a document
+	ActionDocument
+	// ActionAnswer answers the callback query with a toast or an alert
+	ActionAnswer
+	// ActionDelete deletes a message
+	ActionDelete
+)
+
+// Action is the outbound action the update handler executes for the user chat
+type Action struct {
+	Type      ActionType
+	Text      string
+	Keyboard  *tgbotapi.InlineKeyboardMarkup
+	ParseMode string
+	// File is the photo or document, e.g. tgbotapi.FileBytes
+	File interface{}
+	// Alert shows the callback answer as an alert instead of a toast
+	Alert bool
+	// MessageID is the message to delete, zero is the current message
+	MessageID int
+}
+
+// Edit returns action which edits the current message
+func Edit(text string, keyboard *tgbotapi.InlineKeyboardMarkup) Action {
+	return Action{Type: ActionEdit, Text: text, Keyboard: keyboard}
+}
+
+// Send returns action which sends a new message
+func Send(text string, keyboard *tgbotapi.InlineKeyboardMarkup) Action {
+	return Action{Type: ActionSend, Text: text, Keyboard: keyboard}
+}
+
+// Photo returns action which sends a photo with caption
+func Photo(file interface{}, caption string) Action {
+	return Action{Type: ActionPhoto, File: file, Text: caption}
+}
+
+// Document returns action which sends a document with caption
+func Document(file interface{}, caption string) Action {
+	return Action{Type: ActionDocument, File: file, Text: caption}
+}
+
+// Toast returns action which answers the callback with a short notification
+func Toast(text string) Action {
+	return Action{Type: ActionAnswer, Text: text}
+}
+
+// Alert returns action which answers the callback with an alert
+func Alert(text string) Action {
+	return Action{Type: ActionAnswer, Text: text, Alert: true}
+}
+
+// Delete returns action which deletes the message, zero is the current message
+func Delete(messageID int) Action {
+	return Action{Type: ActionDelete, MessageID: messageID}
+}
+
+// Plan returns all actions of the output. Message and keyboard go first:
+// callback edits the current message, text message gets a new one.
+func (o Output) Plan(isCallback bool) []Action {
+	actions := []Action{}
+	if o.Message != "" || o.Keyboard != nil {
+		main := Send(o.Message, o.Keyboard)
+		if isCallback {
+			main.Type = ActionEdit
+		}
+		main.ParseMode = o.ParseMode
+		actions = append(actions, main)
+	}
+	return append(actions, o.Actions...)
+}
@@ -0,0 +1,33 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/logic"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	keyboard := &tgbotapi.InlineKeyboardMarkup{}
+	output := logic.Output{
+		Message:   "*list*",
+		Keyboard:  keyboard,
+		ParseMode: tgbotapi.ModeMarkdown,
+		Actions:   []logic.Action{logic.Toast("added")},
+	}
+
+	callbackPlan := output.Plan(true)
+	require.Len(t, callbackPlan, 2)
+	require.Equal(t, logic.ActionEdit, callbackPlan[0].Type)
+	require.Equal(t, tgbotapi.ModeMarkdown, callbackPlan[0].ParseMode)
+	require.Equal(t, keyboard, callbackPlan[0].Keyboard)
+	require.Equal(t, logic.Toast("added"), callbackPlan[1])
+
+	messagePlan := output.Plan(false)
+	require.Equal(t, logic.ActionSend, messagePlan[0].Type)
+
+	// output with actions only doesn't touch the current message
+	onlyActions := logic.Output{Actions: []logic.Action{logic.Delete(0)}}
+	require.Equal(t, []logic.Action{logic.Delete(0)}, onlyActions.Plan(true))
+}
@@ -11,23 +11,15 @@ import (
 	"context"
 
 	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
-	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
-	"github.com/spf13/viper"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
-const (
-	bugetTxt = `Бюджет: '%s', освоение: %d%%, остаток %d
-	Пример добавления категории: "25000 продукты"
-	Пример добавления бюджета: "!Июнь"`
-	backText   = "⬅ Назад"
-	emptyItems = "Нет категорий для отображения"
-)
-
 var (
 	timeout = time.Second * 5
 
@@ -36,8 +28,7 @@ var (
 )
 
 type buget struct {
-	sessionItem *session.SessionItem
-	storage     bugetstorage.Storage
+	storage bugetstorage.Storage
 }
 
 func New(storage bugetstorage.Storage) *buget {
@@ -46,16 +37,12 @@ func New(storage bugetstorage.Storage) *buget {
 	}
 }
 
-func (d *buget) SetSession(sessionItem *session.SessionItem) {
-	d.sessionItem = sessionItem
-}
-
-func (c *buget) GetCallbackOutput(command string) (logic.Output, error) {
-	log.Println("** message callback:", command)
-	return c.getOutput()
+func (c *buget) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	log.Println("** message callback:", data.Command())
+	return c.getOutput(sessionItem)
 }
 
-func (c *buget) GetMessageOutput(curData string, msg string) (logic.Output, error) {
+func (c *buget) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	//parse msg to budget
@@ -71,33 +58,20 @@ func (c *buget) GetMessageOutput(curData string, msg string) (logic.Output, erro
 	//parse msg to category
 	m = patternNewCategory.FindStringSubmatch(msg)
 	if len(m) != 3 {
-		return c.getOutput()
+		return c.getOutput(sessionItem)
 	}
 	title := m[2]
 	targetSum, _ := strconv.Atoi(m[1])
 
 	//debug
 	fmt.Printf("m=%#v, title=%#v, sum=%#vn\n", m, title, targetSum)
-	//create keyboard and add back button to keyboard
-	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backText, consts.FirstPageStart),
-	}
-	emptyOut := logic.Output{
-		Message: emptyItems,
-		Keyboard: &tgbotapi.InlineKeyboardMarkup{
-			InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
-				controlButtons,
-			},
-		},
-	}
-
-	lastBuget, err := c.storage.GetLastBugets(ctx, 1)
+	lastBuget, err := c.storage.GetLastBugets(ctx, sessionItem.User.ComunityID, 1)
 	if err != nil && err != sql.ErrNoRows {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetWord, err)
 	}
 	if err == sql.ErrNoRows {
 		// no buget, maybe db is empty
-		return emptyOut, nil
+		return c.emptyOutput(sessionItem), nil
 	}
 	newCategory := bugetstorage.Category{
 		BugetID: lastBuget[0].ID,
@@ -110,34 +84,18 @@ func (c *buget) GetMessageOutput(curData string, msg string) (logic.Output, erro
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetWord, err)
 	}
-	return c.getOutput()
+	return c.getOutput(sessionItem)
 }
 
-func (c *buget) getOutput() (logic.Output, error) {
-	bugetCommunity := viper.GetString("SHOPLIST-BUDGET_COMMUNITY")
-	if c.sessionItem.User.ComunityID != bugetCommunity {
-		log.Println("ACCESS DENIED: ", c.sessionItem.User, c.sessionItem.User.ComunityID)
-
-		return logic.Output{}, nil
-	}
-
+func (c *buget) getOutput(sessionItem *session.SessionItem) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	//create keyboard and add back button to keyboard
-	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backText, consts.FirstPageStart),
-	}
-	emptyOut := logic.Output{
-		Message: emptyItems,
-		Keyboard: &tgbotapi.InlineKeyboardMarkup{
-			InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
-				controlButtons,
-			},
-		},
-	}
+	lang := sessionItem.Lang
+	controlButtons := c.controlButtons(sessionItem)
+	emptyOut := c.emptyOutput(sessionItem)
 
-	lastBuget, err := c.storage.GetLastBugets(ctx, 1)
+	lastBuget, err := c.storage.GetLastBugets(ctx, sessionItem.User.ComunityID, 1)
 	if err != nil && err != sql.ErrNoRows {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetWord, err)
 	}
@@ -146,7 +104,7 @@ func (c *buget) getOutput() (logic.Output, error) {
 		return emptyOut, nil
 	}
 
-	categories, err := c.storage.GetBugetCategories(ctx, lastBuget[0].ID)
+	categories, err := c.storage.GetBugetCategories(ctx, sessionItem.User.ComunityID, lastBuget[0].ID)
 	if err != nil && err != sql.ErrNoRows {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetWord, err)
 	}
@@ -166,12 +124,11 @@ func (c *buget) getOutput() (logic.Output, error) {
 		itemIDStr := strconv.Itoa(category.ID)
 		itemName := category.Title
 
-		itemData := consts.ListItemSymbol + itemIDStr
-
-		//make item buttom param with shopping id, removed item ids + id curItem to remove
-		param := helpers.GetParam(
+		//make item button param with item id to show
+		param := callback.New(
 			consts.BugetCategoryWord,
-			itemData,
+			callback.OpShow,
+			itemIDStr,
 		)
 
 		var fillPercent int64
@@ -180,12 +137,12 @@ func (c *buget) getOutput() (logic.Output, error) {
 		}
 		remainder := category.Target - category.Current
 
-		btnTxt := fmt.Sprintf("%d. %s (%d%%), ост: %dр.", i+1, itemName, fillPercent, remainder)
+		btnTxt := i18n.T(lang, i18n.BudgetCategoryButton, i+1, itemName, fillPercent, remainder)
 		//debug
 		fmt.Printf("btnTxt=%s\n", btnTxt)
 
 		row := []tgbotapi.InlineKeyboardButton{
-			tgbotapi.NewInlineKeyboardButtonData(btnTxt, param),
+			callback.Button(btnTxt, param),
 		}
 		column = append(column, row)
 	}
@@ -202,7 +159,7 @@ func (c *buget) getOutput() (logic.Output, error) {
 	}
 	remainder := targetSum - curSum
 
-	outTxt := fmt.Sprintf(bugetTxt, lastBuget[0].Title, totalPercent, remainder)
+	outTxt := i18n.T(lang, i18n.BudgetText, lastBuget[0].Title, totalPercent, remainder)
 
 	output := logic.Output{
 		Message:  outTxt,
@@ -211,3 +168,21 @@ func (c *buget) getOutput() (logic.Output, error) {
 
 	return output, nil
 }
+
+// controlButtons returns keyboard row with back button
+func (c *buget) controlButtons(sessionItem *session.SessionItem) []tgbotapi.InlineKeyboardButton {
+	return []tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(sessionItem.Lang, i18n.BackButton), consts.FirstPageStart),
+	}
+}
+
+func (c *buget) emptyOutput(sessionItem *session.SessionItem) logic.Output {
+	return logic.Output{
+		Message: i18n.T(sessionItem.Lang, i18n.EmptyCategories),
+		Keyboard: &tgbotapi.InlineKeyboardMarkup{
+			InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
+				c.controlButtons(sessionItem),
+			},
+		},
+	}
+}
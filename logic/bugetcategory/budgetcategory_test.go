@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,7 +42,7 @@ func TestCheckSpend(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
-			res := checkSpend(test.cat, test.now)
+			res := checkSpend(i18n.RU, test.cat, test.now)
 			require.Equal(t, test.exp, res)
 		})
 	}
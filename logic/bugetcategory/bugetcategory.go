@@ -1,7 +1,6 @@
 package bugetcategory
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -11,10 +10,12 @@ import (
 	"context"
 
 	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
-	"github.com/spf13/viper"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
@@ -22,23 +23,17 @@ import (
 const (
 	bugetCategoryWord    = "bugetCategory"
 	dateLayout           = "02.01.2006 15:04"
-	backText             = "⬅ Назад"
 	newbugetCategoryText = "*** Создать новый ***"
-	emptyItems           = "Нет категорий для отображения"
-
-	catText = "Категория: %s освоение %d%% (%d/%d):\n"
 )
 
 var (
 	timeout = time.Second * 5
 
-	patternCallback = regexp.MustCompile(`i(\d+)`)
-	patternNewNote  = regexp.MustCompile(`(-?)(\d+)\s+(.+)`)
+	patternNewNote = regexp.MustCompile(`(-?)(\d+)\s+(.+)`)
 )
 
 type bugetCategory struct {
-	sessionItem *session.SessionItem
-	storage     bugetstorage.Storage
+	storage bugetstorage.Storage
 }
 
 func New(storage bugetstorage.Storage) *bugetCategory {
@@ -47,56 +42,38 @@ func New(storage bugetstorage.Storage) *bugetCategory {
 	}
 }
 
-func (d *bugetCategory) SetSession(sessionItem *session.SessionItem) {
-	d.sessionItem = sessionItem
-}
-
-func (c *bugetCategory) GetCallbackOutput(command string) (logic.Output, error) {
-	log.Println("** message callback:", command)
+func (c *bugetCategory) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	log.Println("** message callback:", data.Command())
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	categoryID, err := parseCurData(command)
+	categoryID, err := data.IntArg(0)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, err)
 	}
-	category, err := c.storage.GetCategory(ctx, categoryID)
+	category, err := c.storage.GetCategory(ctx, sessionItem.User.ComunityID, categoryID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, err)
 	}
 
-	return c.getOutput(category)
+	return c.getOutput(sessionItem, category)
 }
 
-// returns categoryID
-func parseCurData(data string) (int, error) {
-	//parse msg to category
-	m := patternCallback.FindStringSubmatch(data)
-	if len(m) != 2 {
-		return 0, errors.New("parsing error")
-	}
-	categoryID, err := strconv.Atoi(m[1])
-	if err != nil {
-		return 0, err
-	}
-	return categoryID, nil
-}
-
-func (c *bugetCategory) GetMessageOutput(curData string, msg string) (logic.Output, error) {
+func (c *bugetCategory) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	categoryID, err := parseCurData(curData)
+	categoryID, err := curData.IntArg(0)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, err)
 	}
-	category, err := c.storage.GetCategory(ctx, categoryID)
+	category, err := c.storage.GetCategory(ctx, sessionItem.User.ComunityID, categoryID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, err)
 	}
 
 	m := patternNewNote.FindStringSubmatch(msg)
 	if len(m) != 4 {
-		return c.getOutput(category)
+		return c.getOutput(sessionItem, category)
 	}
 	noteTitle := m[3]
 	noteSum, _ := strconv.Atoi(m[2])
@@ -111,7 +88,7 @@ func (c *bugetCategory) GetMessageOutput(curData string, msg string) (logic.Outp
 
 	if category.Target != 0 &&
 		(category.Current-category.Target) > 0 {
-		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, errors.New("В категории не осталось средств!"))
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, logic.NewUserError(i18n.T(sessionItem.Lang, i18n.NoFunds)))
 	}
 	//update category
 	if err := c.storage.UpdateCategory(ctx, categoryID, int(newCurrent)); err != nil {
@@ -124,27 +101,22 @@ func (c *bugetCategory) GetMessageOutput(curData string, msg string) (logic.Outp
 		Title:      noteTitle,
 		Created:    time.Now().Unix(),
 	}
-	if err := c.storage.InsertNote(ctx, note); err != nil {
+	// the author is kept in the audit log to undo the note
+	if err := c.storage.InsertNote(events.WithAuthor(ctx, sessionItem.Author()), note); err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, err)
 	}
 
-	return c.getOutput(category)
+	return c.getOutput(sessionItem, category)
 }
 
-func (c *bugetCategory) getOutput(category bugetstorage.Category) (logic.Output, error) {
+func (c *bugetCategory) getOutput(sessionItem *session.SessionItem, category bugetstorage.Category) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-
-	bugetCommunity := viper.GetString("SHOPLIST-BUDGET_COMMUNITY")
-	if c.sessionItem.User.ComunityID != bugetCommunity {
-		log.Println("ACCESS DENIED: ", c.sessionItem.User, c.sessionItem.User.ComunityID)
-
-		return logic.Output{}, nil
-	}
+	lang := sessionItem.Lang
 
 	//create keyboard and add back button to keyboard
 	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backText, consts.BugetStart),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BackButton), consts.BugetStart),
 	}
 
 	column := [][]tgbotapi.InlineKeyboardButton{controlButtons}
@@ -153,7 +125,7 @@ func (c *bugetCategory) getOutput(category bugetstorage.Category) (logic.Output,
 	keyboard := &tgbotapi.InlineKeyboardMarkup{
 		InlineKeyboard: column,
 	}
-	notes, err := c.storage.GetCategoryNotes(ctx, category.ID)
+	notes, err := c.storage.GetCategoryNotes(ctx, sessionItem.User.ComunityID, category.ID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.BugetCategoryWord, err)
 	}
@@ -162,14 +134,14 @@ func (c *bugetCategory) getOutput(category bugetstorage.Category) (logic.Output,
 		fillPercent = int64(category.Current * 100 / category.Target)
 	}
 
-	outTxt := fmt.Sprintf(catText, category.Title, fillPercent, category.Current, category.Target)
+	outTxt := i18n.T(lang, i18n.CategoryText, category.Title, fillPercent, category.Current, category.Target)
 	for i, v := range notes {
 		t := time.Unix(v.Created, 0).Format(dateLayout)
-		noteTxt := fmt.Sprintf("%d) %s -> %dр. - %s\n", i+1, t, v.Sum, v.Title)
+		noteTxt := i18n.T(lang, i18n.CategoryNote, i+1, t, v.Sum, v.Title)
 		outTxt += noteTxt
 	}
 
-	spendInfo := checkSpend(category, time.Now())
+	spendInfo := checkSpend(lang, category, time.Now())
 	if spendInfo != "" {
 		outTxt = outTxt + "\n" + spendInfo
 	}
@@ -186,7 +158,7 @@ func daysIn(m time.Month, year int) int {
 	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
 }
 
-func checkSpend(category bugetstorage.Category, now time.Time) string {
+func checkSpend(lang i18n.Lang, category bugetstorage.Category, now time.Time) string {
 	if category.Target < 10000 {
 		return ""
 	}
@@ -205,8 +177,7 @@ func checkSpend(category bugetstorage.Category, now time.Time) string {
 	}
 
 	if daysOver != 0 {
-		txt := fmt.Sprintf("🤬 Тормозни! Перерасход на %d дня", daysOver)
-		return txt
+		return i18n.N(lang, i18n.Overspend, daysOver)
 	}
 
 	return ""
@@ -7,8 +7,10 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
 	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 
@@ -17,31 +19,19 @@ import (
 
 const (
 	CalendarWord = "calendar"
-	backWord     = "back"
 	timeLimit    = 8760 // one year
 
-	emptyLabel      = " "
-	leftLabel       = "<"
-	rightLabel      = ">"
-	calendarMessage = "calendar"
+	emptyLabel = " "
+	leftLabel  = "<"
+	rightLabel = ">"
 )
 
-var (
-	weekDays = []string{"ПН", "ВТ", "СР", "ЧТ", "ПТ", "СБ", "ВС"}
-)
-
-type calendar struct {
-	sessionItem *session.SessionItem
-}
+type calendar struct{}
 
 func New() *calendar {
 	return &calendar{}
 }
 
-func (c *calendar) SetSession(sessionItem *session.SessionItem) {
-	c.sessionItem = sessionItem
-}
-
 func getFirstDayDate(date time.Time) time.Time {
 	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
 }
@@ -49,7 +39,7 @@ func getFirstDayDate(date time.Time) time.Time {
 //GetCalendar returns
 // calendar_prev, calendar_next, calendar_<1-31>, calendar_back
 //
-func GetCalendar(date time.Time, shoppingDays []int) tgbotapi.InlineKeyboardMarkup {
+func GetCalendar(lang i18n.Lang, date time.Time, shoppingDays []int) tgbotapi.InlineKeyboardMarkup {
 	var numericKeyboard tgbotapi.InlineKeyboardMarkup
 	var rows [][]tgbotapi.InlineKeyboardButton
 	var row []tgbotapi.InlineKeyboardButton
@@ -63,12 +53,12 @@ func GetCalendar(date time.Time, shoppingDays []int) tgbotapi.InlineKeyboardMark
 		return n - 1
 	}
 
-	curMonthParam := CalendarWord + "_" + helpers.Time2MonthCode(date)
+	curMonthParam := monthParam(date)
 
-	monthBtn := tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%s'%v", date.Month(), date.Year()), "0"))
+	monthBtn := tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%s'%v", i18n.Month(lang, date.Month()), date.Year()), curMonthParam))
 
 	days := []tgbotapi.InlineKeyboardButton{}
-	for _, day := range weekDays {
+	for _, day := range i18n.Weekdays(lang) {
 		days = append(days, tgbotapi.NewInlineKeyboardButtonData(day, curMonthParam))
 	}
 	weekDaysBtns := tgbotapi.NewInlineKeyboardRow(days...)
@@ -76,20 +66,18 @@ func GetCalendar(date time.Time, shoppingDays []int) tgbotapi.InlineKeyboardMark
 	prevMonthPtr := TryGetPrevMonthDate(date)
 	leftBtn := tgbotapi.NewInlineKeyboardButtonData(emptyLabel, curMonthParam)
 	if prevMonthPtr != nil {
-		prevMonthParam := CalendarWord + "_" + helpers.Time2MonthCode(*prevMonthPtr)
-		leftBtn = tgbotapi.NewInlineKeyboardButtonData(leftLabel, prevMonthParam)
+		leftBtn = tgbotapi.NewInlineKeyboardButtonData(leftLabel, monthParam(*prevMonthPtr))
 	}
 
 	nextMonthPtr := TryGetNextMonthDate(date)
 	rightBtn := tgbotapi.NewInlineKeyboardButtonData(emptyLabel, curMonthParam)
 	if nextMonthPtr != nil {
-		nextMonthParam := CalendarWord + "_" + helpers.Time2MonthCode(*nextMonthPtr)
-		rightBtn = tgbotapi.NewInlineKeyboardButtonData(rightLabel, nextMonthParam)
+		rightBtn = tgbotapi.NewInlineKeyboardButtonData(rightLabel, monthParam(*nextMonthPtr))
 	}
 
 	navBtns := tgbotapi.NewInlineKeyboardRow(
 		leftBtn,
-		tgbotapi.NewInlineKeyboardButtonData(backWord, consts.FirstPageStart),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BackButton), consts.FirstPageStart),
 		rightBtn,
 	)
 	rows = append(rows, monthBtn)
@@ -116,8 +104,9 @@ func GetCalendar(date time.Time, shoppingDays []int) tgbotapi.InlineKeyboardMark
 		}
 		if lShift(int(curDay.Weekday())) == weekDay {
 			label := strconv.Itoa(curDay.Day())
-			param := helpers.GetParam(
+			param := callback.New(
 				consts.DayshoppingsWord,
+				callback.OpShow,
 				helpers.Time2DayCode(curDay),
 			)
 
@@ -128,7 +117,7 @@ func GetCalendar(date time.Time, shoppingDays []int) tgbotapi.InlineKeyboardMark
 				}
 			}
 
-			row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, param))
+			row = append(row, callback.Button(label, param))
 			curDay = curDay.AddDate(0, 0, 1)
 		} else {
 			// if not controll button - callBackData == curMonthParam
@@ -141,6 +130,11 @@ func GetCalendar(date time.Time, shoppingDays []int) tgbotapi.InlineKeyboardMark
 	return numericKeyboard
 }
 
+// monthParam returns callback data which shows the month of the date
+func monthParam(date time.Time) string {
+	return callback.New(CalendarWord, callback.OpShow, helpers.Time2MonthCode(date)).MustEncode()
+}
+
 func getHoursDuration(date time.Time) float64 {
 	duration := time.Now().Sub(date)
 	diff := duration.Hours()
@@ -173,34 +167,34 @@ func TryGetNextMonthDate(date time.Time) *time.Time {
 	return &date
 }
 
-func (c *calendar) getOutputByDate(date time.Time) (logic.Output, error) {
-	days, err := c.sessionItem.SListAPI.GetShoppingDays(date)
+func (c *calendar) getOutputByDate(sessionItem *session.SessionItem, date time.Time) (logic.Output, error) {
+	days, err := sessionItem.SListAPI.GetShoppingDays(date)
 	if err != nil {
 		log.Println(err.Error())
 		return logic.Output{}, fmt.Errorf("shoplist api error: %w", err)
 
 	}
-	keyboard := GetCalendar(date, days)
+	keyboard := GetCalendar(sessionItem.Lang, date, days)
 	return logic.Output{
-		Message:  calendarMessage,
+		Message:  i18n.T(sessionItem.Lang, i18n.CalendarTitle),
 		Keyboard: &keyboard,
 	}, nil
 }
 
-func (c *calendar) GetCallbackOutput(command string) (logic.Output, error) {
-	switch command {
-	case consts.Start:
-		return c.getOutputByDate(time.Now())
+func (c *calendar) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	switch data.Op {
+	case callback.OpStart:
+		return c.getOutputByDate(sessionItem, time.Now())
 	default:
-		nextMonth, err := helpers.MonthCode2Time(command)
+		nextMonth, err := helpers.MonthCode2Time(data.Arg(0))
 		if err != nil {
 			return logic.Output{}, consts.ErrUnknownCommand
 		}
-		return c.getOutputByDate(nextMonth)
+		return c.getOutputByDate(sessionItem, nextMonth)
 	}
 }
 
-func (c *calendar) GetMessageOutput(curData string, msg string) (logic.Output, error) {
+func (c *calendar) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
 	return logic.Output{
 		Message: "msg",
 	}, nil
@@ -1,342 +1,318 @@
 package checklist
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
-	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
 	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/quantity"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
-const (
-	backSumbol   = "⬅ Меню"
-	inputMsg     = "Чек-лист. Введите товар для добавления"
-	removeMsg    = "Удалить"
-	copyMsg      = "В текущий список"
-	selectAllMsg = "Выделить все"
-	emptyItems   = "Чек-лист пока что пуст. Для добавления введите название товара."
-	copiedSucess = "Товары скопированы."
-	noNewItems   = "Нет новых товаров для добавления. "
-)
-
-type checklist struct {
-	sessionItem *session.SessionItem
-}
+type checklist struct{}
 
 func New() *checklist {
 	return &checklist{}
 }
 
-func (c *checklist) SetSession(sessionItem *session.SessionItem) {
-	c.sessionItem = sessionItem
-}
-
-func (c *checklist) GetCallbackOutput(command string) (logic.Output, error) {
+func (c *checklist) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
 	var checklistShoppingID int
 	var err error
 
+	state := sessionItem.State(consts.ChecklistWord)
+
 	// if first start of checklist page we will get checklist shoppingID
-	if command == consts.Start {
+	if data.Op == callback.OpStart {
 		// delete items
-		c.sessionItem.ClearDataArray()
+		state.ClearSelected()
+		state.Search = ""
 		// get checklist shopping ID
-		checklistShoppingID, err = c.sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCheckList)
-		switch {
-		case err == consts.ErrNotFound:
-			checklistShoppingID, err = c.sessionItem.SListAPI.AddShoppingWithType(
-				time.Now(),
-				consts.ChecklistWord,
-				consts.ShoppingTypeCheckList,
-			)
-			if err != nil {
-				return logic.Output{}, err
-			}
-		case err != nil:
+		checklistShoppingID, err = sessionItem.SListAPI.GetOrAddSpecialShopping(consts.ShoppingTypeCheckList, consts.ChecklistWord)
+		if err != nil {
 			return logic.Output{}, err
 		}
 
-		parseObject := helpers.ParseResult{
-			ShoppingID: checklistShoppingID,
-		}
-
-		return c.getOutput(&parseObject, "", nil)
+		return c.getOutput(sessionItem, checklistShoppingID, "")
 	}
 
-	// parse command
-	parseResult, err := helpers.ParseCommand(command)
+	shoppingID, err := data.IntArg(0)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
 	}
 
-	// item button is pressed add itemID to session data storage
-	if parseResult.SelectItem != nil {
-		selectedItems := c.sessionItem.GetDataAsArray()
-		if helpers.IsInArray(*parseResult.SelectItem, selectedItems) {
-			// unselect item
-			c.sessionItem.DeleteValueInArray(*parseResult.SelectItem)
-		} else {
-			c.sessionItem.AddIntDataToArray(*parseResult.SelectItem)
+	switch data.Op {
+	case callback.OpSelect:
+		// item button is pressed add itemID to node state or unselect it
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
 		}
-	}
-
-	// any of start operation buttons was pressed
-	if parseResult.StartOperation {
-		switch parseResult.OperType {
-		case consts.TypeOperationDelete:
-			//remove items and get output
-			selectItems := c.sessionItem.GetDataAsArray()
-			err = c.sessionItem.SListAPI.RemoveItems(selectItems)
-			if err != nil {
-				return logic.Output{}, err
-			}
-			// delete items
-			c.sessionItem.ClearDataArray()
-
-			return c.getOutput(parseResult, "", nil)
-		case consts.TypeOperationSelectAll:
-			// select all
-			checklistItems, err := c.sessionItem.SListAPI.GetShoppingItems(parseResult.ShoppingID)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-			}
-			arItemIDs := []int{}
-			for _, v := range checklistItems {
-				arItemIDs = append(arItemIDs, v.ID)
-			}
-			c.sessionItem.SetDataArrayValue(arItemIDs)
-		case consts.TypeOperationCopy:
-			// copy items to current list
-			checklistItems, err := c.sessionItem.SListAPI.GetShoppingItems(parseResult.ShoppingID)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-			}
-			//get currentlist shoppingID
-			currentlistShoppingID, err := c.sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCurrentList)
-			switch {
-			case err == consts.ErrNotFound:
-				currentlistShoppingID, err = c.sessionItem.SListAPI.AddShoppingWithType(
-					time.Now(),
-					consts.CurrentlistWord,
-					consts.ShoppingTypeCurrentList,
-				)
-				if err != nil {
-					return logic.Output{}, err
-				}
-			case err != nil:
-				return logic.Output{}, err
-			}
-			// get currentlist items
-			currentlistItems, err := c.sessionItem.SListAPI.GetShoppingItems(currentlistShoppingID)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-			}
-			//add checklist items to current list with check of duplicates
-
-			alreadyExist := func(itemName string) bool {
-				for _, currentlistItem := range currentlistItems {
-					if itemName == currentlistItem.ProductName {
-						return true
-					}
-				}
-				return false
-			}
-
-			notSelected := func(itemID int) bool {
-				selectedItems := c.sessionItem.GetDataAsArray()
-				for _, id := range selectedItems {
-					if itemID == id {
-						return false
-					}
-				}
-				return true
-			}
-
-			itemsToAdd := []string{}
-			for _, checklistItem := range checklistItems {
-				if alreadyExist(checklistItem.ProductName) || notSelected(checklistItem.ID) {
-					continue
-				}
-				itemsToAdd = append(itemsToAdd, checklistItem.ProductName)
-			}
+		state.ToggleSelected(itemID)
+	case callback.OpCategory:
+		// category picker of the selected item
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		item, err := sessionItem.SListAPI.GetItem(itemID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		categories, err := sessionItem.SListAPI.GetItemCategories()
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		return logic.CategoryPicker(sessionItem.Lang, consts.ChecklistWord, shoppingID, item, categories), nil
+	case callback.OpSetCategory:
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		categoryID, err := data.IntArg(2)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		err = sessionItem.SListAPI.SetItemCategory(itemID, categoryID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+	case callback.OpQuickAdd:
+		// suggested product is added
+		productID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		err = sessionItem.SListAPI.AddProduct(shoppingID, productID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		state.Search = ""
+	case callback.OpIncrease, callback.OpDecrease:
+		// quantity of the selected item is changed
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		err = sessionItem.SListAPI.ChangeItemQuantity(itemID, logic.QuantityDelta(data.Op))
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+	case callback.OpDelete:
+		//remove items and get output
+		err = sessionItem.SListAPI.RemoveItems(state.Selected)
+		if err != nil {
+			return logic.Output{}, err
+		}
+		// delete items
+		state.ClearSelected()
 
-			if len(itemsToAdd) > 0 {
-				for _, item := range itemsToAdd {
-					err = c.sessionItem.SListAPI.AddItem(currentlistShoppingID, item)
-					if err != nil {
-						return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-					}
-				}
+		return c.getOutput(sessionItem, shoppingID, "")
+	case callback.OpSelectAll:
+		// select all
+		checklistItems, err := sessionItem.SListAPI.GetShoppingItems(shoppingID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
+		arItemIDs := []int{}
+		for _, v := range checklistItems {
+			arItemIDs = append(arItemIDs, v.ID)
+		}
+		state.SetSelected(arItemIDs)
+	case callback.OpCopy:
+		// copy selected items to current list
+		//get currentlist shoppingID
+		currentlistShoppingID, err := sessionItem.SListAPI.GetOrAddSpecialShopping(consts.ShoppingTypeCurrentList, consts.CurrentlistWord)
+		if err != nil {
+			return logic.Output{}, err
+		}
+		//add checklist items to current list, quantities of duplicates are summed
+		copied, err := sessionItem.SListAPI.CopyItems(shoppingID, currentlistShoppingID, state.Selected)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+		}
 
-				// clear
-				c.sessionItem.ClearDataArray()
+		if copied > 0 {
+			// clear
+			state.ClearSelected()
 
-				return c.getOutput(parseResult, copiedSucess, nil)
-			}
-			// no new items to add message
-			return c.getOutput(parseResult, noNewItems, nil)
+			return c.getOutput(sessionItem, shoppingID, i18n.T(sessionItem.Lang, i18n.ItemsCopied))
 		}
+		// no new items to add message
+		return c.getOutput(sessionItem, shoppingID, i18n.T(sessionItem.Lang, i18n.NoNewItems))
 	}
 
-	return c.getOutput(parseResult, "", nil)
+	return c.getOutput(sessionItem, shoppingID, "")
 }
 
-func (c *checklist) GetMessageOutput(curData string, msg string) (logic.Output, error) {
-	var result *helpers.ParseResult
+// ReopenCommand shows the list without repeating the last operation
+func (c *checklist) ReopenCommand(currentData callback.Data) callback.Data {
+	return callback.New(consts.ChecklistWord, callback.OpStart)
+}
+
+func (c *checklist) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
+	var shoppingID int
 	var err error
 	// if first start of checklist page
-	if curData == consts.Start {
+	if curData.Op == callback.OpStart {
 		// get checklist shopping ID
-		checklistShoppingID, err := c.sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCheckList)
-		if err != nil {
-			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-		}
-
-		result = &helpers.ParseResult{
-			ShoppingID: checklistShoppingID,
-		}
+		shoppingID, err = sessionItem.SListAPI.GetOrAddSpecialShopping(consts.ShoppingTypeCheckList, consts.ChecklistWord)
 	} else {
-		result, err = helpers.ParseCommand(curData)
-		if err != nil {
-			return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-		}
+		shoppingID, err = curData.IntArg(0)
+	}
+	if err != nil {
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
 	}
 
-	err = c.sessionItem.SListAPI.AddItem(result.ShoppingID, msg)
+	if search, ok := logic.SearchText(msg); ok {
+		// the message searches the products to add
+		sessionItem.State(consts.ChecklistWord).Search = search
+		return c.getOutput(sessionItem, shoppingID, "")
+	}
+	sessionItem.State(consts.ChecklistWord).Search = ""
+
+	// every line or comma separated entry of the message is the item
+	_, err = sessionItem.SListAPI.AddItems(shoppingID, logic.SplitItems(msg))
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
 	}
 
-	return c.getOutput(result, "", &msg)
+	return c.getOutput(sessionItem, shoppingID, "")
 }
 
-func (c *checklist) getOutput(parseObject *helpers.ParseResult, additionalMessage string, addedItems *string) (logic.Output, error) {
-	shoppingIDStr := strconv.Itoa(parseObject.ShoppingID)
-	selectedItems := c.sessionItem.GetDataAsArray()
-	//listStr := ""
-
-	// strArrayNums := []string{}
-	// for _, v := range parseObject.Items {
-	// 	strArrayNums = append(strArrayNums, strconv.Itoa(v))
-	// }
+func (c *checklist) getOutput(sessionItem *session.SessionItem, shoppingID int, additionalMessage string) (logic.Output, error) {
+	shoppingIDStr := strconv.Itoa(shoppingID)
+	state := sessionItem.State(consts.ChecklistWord)
+	lang := sessionItem.Lang
 
-	// if parseObject.Items != nil {
-	// 	listStr = consts.ListItemSymbol + strings.Join(strArrayNums, ",")
-	// }
-	shoppingData, err := c.sessionItem.SListAPI.GetShopping(parseObject.ShoppingID)
+	_, err := sessionItem.SListAPI.GetShopping(shoppingID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
 	}
+	// message is refreshed on changes of other members
+	sessionItem.ShoppingID = shoppingID
 
 	//create keyboard and add back button to keyboard
 	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backSumbol, consts.FirstPageStart),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.MenuButton), consts.FirstPageStart),
 	}
 
-	items, err := c.sessionItem.SListAPI.GetShoppingItems(parseObject.ShoppingID)
+	// suggested products of the catalog
+	products, err := sessionItem.SListAPI.SuggestProducts(shoppingID, state.Search, logic.SuggestionsLimit)
+	if err != nil {
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+	}
+	quickAddButtons, searchNote := logic.QuickAddButtons(lang, consts.ChecklistWord, shoppingID, state.Search, products)
+
+	items, err := sessionItem.SListAPI.GetShoppingItems(shoppingID)
 	if err != nil {
 		//if get empty items list
-		// heroku's go not understand errors.Is
-		if err == consts.ErrNotFound {
+		if errors.Is(err, consts.ErrNotFound) {
+			message := i18n.T(lang, i18n.ChecklistEmpty)
+			if searchNote != "" {
+				message = searchNote
+			}
 			return logic.Output{
-				Message: emptyItems,
+				Message: message,
 				Keyboard: &tgbotapi.InlineKeyboardMarkup{
-					InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
-						controlButtons,
-					},
+					InlineKeyboard: append(quickAddButtons, controlButtons),
 				},
 			}, nil
 		}
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
 	}
 
+	categories, err := sessionItem.SListAPI.GetItemCategories()
+	if err != nil {
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
+	}
+	// items are grouped by categories in the aisle order
+	categorized := logic.Categorize(items, categories)
+
 	column := [][]tgbotapi.InlineKeyboardButton{}
 
 	// create items list to show
-	for i, data := range items {
+	for i, data := range categorized.Items {
 		itemIDStr := strconv.Itoa(data.ID)
-		itemName := data.ProductName
+		itemName := quantity.Format(data.ProductName, data.Quantity, data.Unit)
 		// underlined item name
-		if helpers.IsInArray(data.ID, selectedItems) {
+		if state.IsSelected(data.ID) {
 			itemName = helpers.GetUnderlinedText(itemName)
 		}
+		itemName = categorized.Mark(data) + itemName
 
-		// add itemID to itemsIDs string
-		// listData := consts.ListItemSymbol + itemIDStr
-		// if parseObject.Items != nil {
-		// 	listData = listStr + "," + itemIDStr
-		// }
-		itemData := consts.ListItemSymbol + itemIDStr
-
-		//make item buttom param with shopping id, removed item ids + id curItem to remove
-		//as example: "checklist_123i45"
-		param := helpers.GetParam(
+		//make item button param with shopping id and item id to select
+		//as example: "1:checklist:sel:123:45"
+		param := callback.New(
 			consts.ChecklistWord,
+			callback.OpSelect,
 			shoppingIDStr,
-			itemData,
+			itemIDStr,
 		)
 		row := []tgbotapi.InlineKeyboardButton{
-			tgbotapi.NewInlineKeyboardButtonData(strconv.Itoa(i+1)+". "+itemName, param),
+			callback.Button(strconv.Itoa(i+1)+". "+itemName, param),
 		}
 		column = append(column, row)
 	}
 
+	// quantity buttons of the only selected item
+	if len(state.Selected) == 1 {
+		for _, data := range items {
+			if data.ID == state.Selected[0] {
+				column = append(column, logic.ItemButtons(consts.ChecklistWord, shoppingID, data))
+			}
+		}
+	}
+
 	if len(items) > 0 {
 		// select all button
-		selectAllButton := tgbotapi.NewInlineKeyboardButtonData(selectAllMsg,
-			helpers.GetParam(
+		selectAllButton := callback.Button(i18n.T(lang, i18n.SelectAllButton),
+			callback.New(
 				consts.ChecklistWord,
+				callback.OpSelectAll,
 				shoppingIDStr,
-				consts.ListStartSelectAllSymbol,
 			))
 		controlButtons = append(controlButtons, selectAllButton)
 	}
 
 	// add remove and copy buttons to keyboard
-	if len(selectedItems) > 0 {
+	if len(state.Selected) > 0 {
 		//remove button
-		removeButton := tgbotapi.NewInlineKeyboardButtonData(removeMsg,
-			helpers.GetParam(
+		removeButton := callback.Button(i18n.T(lang, i18n.RemoveButton),
+			callback.New(
 				consts.ChecklistWord,
+				callback.OpDelete,
 				shoppingIDStr,
-				consts.ListStartRemoveSymbol,
 			))
 		// copy button
-		copyButton := tgbotapi.NewInlineKeyboardButtonData(copyMsg,
-			helpers.GetParam(
+		copyButton := callback.Button(i18n.T(lang, i18n.CopyToCurrentButton),
+			callback.New(
 				consts.ChecklistWord,
+				callback.OpCopy,
 				shoppingIDStr,
-				consts.ListStartCopySymbol,
 			))
 		controlButtons = append(controlButtons, removeButton, copyButton)
 	}
 
+	column = append(column, quickAddButtons...)
 	//final keyboard
 	column = append(column, controlButtons)
 	keyboard := &tgbotapi.InlineKeyboardMarkup{
 		InlineKeyboard: column,
 	}
 
-	output := logic.Output{
-		Message:  fmt.Sprintf("%s %s", additionalMessage, inputMsg),
-		Keyboard: keyboard,
-	}
-
-	if addedItems != nil {
-		msg := fmt.Sprintf(
-			"Пользователь %s(%v) добавил '%s' в '%s'(%s)",
-			c.sessionItem.User.TelegramUsername,
-			c.sessionItem.User.TelegramID,
-			*addedItems,
-			shoppingData.Edges.Shop.Name,
-			shoppingData.Date,
-		)
-		output.MessageToCommunity = &msg
+	message := fmt.Sprintf("%s %s", additionalMessage, i18n.T(lang, i18n.ChecklistInput))
+	if searchNote != "" {
+		message = searchNote
 	}
-
-	return output, nil
+	return logic.Output{
+		Message:  message,
+		Keyboard: keyboard,
+	}, nil
 }
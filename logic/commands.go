@@ -0,0 +1,99 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+)
+
+const (
+	commandPrefix = "/"
+
+	HelpCommand = "help"
+)
+
+// Command maps telegram command to the node operation,
+// command arguments are passed to the node as a message
+type Command struct {
+	Name string
+	// Description is translated to the user language
+	Description i18n.Key
+	Node        string
+	Operation   string
+}
+
+// AddCommand adds command, name is without slash
+func (l *Logic) AddCommand(command Command) *Logic {
+	l.commands = append(l.commands, command)
+	return l
+}
+
+// Commands returns all commands with help command
+func (l *Logic) Commands() []Command {
+	return append(
+		append([]Command{}, l.commands...),
+		Command{
+			Name:        HelpCommand,
+			Description: i18n.CommandHelp,
+		},
+	)
+}
+
+func (l *Logic) getCommand(name string) (Command, bool) {
+	for _, command := range l.commands {
+		if command.Name == name {
+			return command, true
+		}
+	}
+	return Command{}, false
+}
+
+// ParseCommand parses "/add@bot milk" message to "add" command and "milk" arguments
+func ParseCommand(msg string) (name, args string, ok bool) {
+	if !strings.HasPrefix(msg, commandPrefix) {
+		return "", "", false
+	}
+
+	// command is separated from arguments by any space, e.g. "/add\nmilk\nbread"
+	text := strings.TrimPrefix(msg, commandPrefix)
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(text, fields[0]) {
+		return "", "", false
+	}
+	name = fields[0]
+	args = strings.TrimSpace(strings.TrimPrefix(text, name))
+	// command may be addressed to the bot in group chats
+	if index := strings.Index(name, "@"); index >= 0 {
+		name = name[:index]
+	}
+	if name == "" {
+		return "", "", false
+	}
+	return strings.ToLower(name), args, true
+}
+
+// input switches session to the command node and returns input for the node operation,
+// arguments are passed as a message and the operation as current data
+func (c Command) input(i Input, args string, sessionItem *session.SessionItem) Input {
+	sessionItem.UpdateCallbackData(&c.Node, &c.Operation)
+
+	if args != "" {
+		return Input{UpdateID: i.UpdateID, Message: &args}
+	}
+	return Input{UpdateID: i.UpdateID, CallbackData: &c.Operation}
+}
+
+// help returns command list, name is the requested command
+func (l *Logic) help(lang i18n.Lang, name string) Output {
+	lines := []string{i18n.T(lang, i18n.HelpTitle)}
+	if name != HelpCommand {
+		lines = append([]string{i18n.T(lang, i18n.UnknownCommand, name)}, lines...)
+	}
+	for _, command := range l.Commands() {
+		lines = append(lines, fmt.Sprintf("%s%s - %s", commandPrefix, command.Name, i18n.T(lang, command.Description)))
+	}
+
+	return menuOutput(lang, strings.Join(lines, "\n"))
+}
@@ -1,218 +1,338 @@
 package currentlist
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/category"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
 	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/quantity"
+	"github.com/Frosin/shoplist-telegram-bot/restock"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
-const (
-	backSumbol = "⬅ Меню"
-	inputMsg   = "Текущий список. Введите товар для добавления"
-	removeMsg  = "Удалить"
-	emptyItems = "Текущий список пока что пуст. Для добавления введите название товара."
-)
-
-type currentlist struct {
-	sessionItem *session.SessionItem
-}
+type currentlist struct{}
 
 func New() *currentlist {
 	return &currentlist{}
 }
 
-func (c *currentlist) SetSession(sessionItem *session.SessionItem) {
-	c.sessionItem = sessionItem
-}
-
-func (c *currentlist) GetCallbackOutput(command string) (logic.Output, error) {
+func (c *currentlist) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
 	var currentlistShoppingID int
 	var err error
 
 	// if first start of current page
-	if command == consts.Start {
+	if data.Op == callback.OpStart {
+		sessionItem.State(consts.CurrentlistWord).Search = ""
 		// get currentlist shopping ID
-		currentlistShoppingID, err = c.sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCurrentList)
-		switch {
-		case err == consts.ErrNotFound:
-			currentlistShoppingID, err = c.sessionItem.SListAPI.AddShoppingWithType(
-				time.Now(),
-				consts.CurrentlistWord,
-				consts.ShoppingTypeCurrentList,
-			)
-			if err != nil {
-				return logic.Output{}, err
-			}
-		case err != nil:
+		currentlistShoppingID, err = sessionItem.SListAPI.GetOrAddSpecialShopping(consts.ShoppingTypeCurrentList, consts.CurrentlistWord)
+		if err != nil {
 			return logic.Output{}, err
 		}
 
-		parseObject := helpers.ParseResult{
-			ShoppingID: currentlistShoppingID,
-		}
-
-		return c.getOutput(&parseObject, nil)
+		return c.getOutput(sessionItem, currentlistShoppingID)
 	}
 
-	// parse command
-	parseResult, err := helpers.ParseCommand(command)
+	shoppingID, err := data.IntArg(0)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
 	}
 
-	// item button is pressed add itemID to session data storage
-	if parseResult.SelectItem != nil {
-		selectedItems := c.sessionItem.GetDataAsArray()
-		if helpers.IsInArray(*parseResult.SelectItem, selectedItems) {
-			// unselect item
-			c.sessionItem.DeleteValueInArray(*parseResult.SelectItem)
-		} else {
-			c.sessionItem.AddIntDataToArray(*parseResult.SelectItem)
-		}
-
-	}
-
-	if parseResult.StartOperation {
-		switch parseResult.OperType {
-		case consts.TypeOperationDelete:
-			//remove items and get output
-			selectItems := c.sessionItem.GetDataAsArray()
-			err = c.sessionItem.SListAPI.RemoveItems(selectItems)
-			if err != nil {
-				return logic.Output{}, err
-			}
-			// delete items
-			c.sessionItem.ClearDataArray()
+	state := sessionItem.State(consts.CurrentlistWord)
 
-			return c.getOutput(parseResult, nil)
+	switch data.Op {
+	case callback.OpSelect:
+		// item button is pressed add itemID to node state or unselect it
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		state.ToggleSelected(itemID)
+	case callback.OpCategory:
+		// category picker of the selected item
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		item, err := sessionItem.SListAPI.GetItem(itemID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		categories, err := sessionItem.SListAPI.GetItemCategories()
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		return logic.CategoryPicker(sessionItem.Lang, consts.CurrentlistWord, shoppingID, item, categories), nil
+	case callback.OpSetCategory:
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		categoryID, err := data.IntArg(2)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		err = sessionItem.SListAPI.SetItemCategory(itemID, categoryID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+	case callback.OpQuickAdd:
+		// suggested product is added
+		productID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		err = sessionItem.SListAPI.AddProduct(shoppingID, productID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		state.Search = ""
+	case callback.OpRestock:
+		// product due to buy again is added like its last purchase
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		err = sessionItem.SListAPI.CopyItem(shoppingID, itemID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+	case callback.OpIncrease, callback.OpDecrease:
+		// quantity of the selected item is changed
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+		err = sessionItem.SListAPI.ChangeItemQuantity(itemID, logic.QuantityDelta(data.Op))
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
+	case callback.OpDelete:
+		//remove items and get output
+		err = sessionItem.SListAPI.RemoveItems(state.Selected)
+		if err != nil {
+			return logic.Output{}, err
 		}
+		// delete items
+		state.ClearSelected()
 	}
 
-	return c.getOutput(parseResult, nil)
+	return c.getOutput(sessionItem, shoppingID)
 }
 
-func (c *currentlist) GetMessageOutput(curData string, msg string) (logic.Output, error) {
-	var result *helpers.ParseResult
+// ReopenCommand shows the list without repeating the last operation
+func (c *currentlist) ReopenCommand(currentData callback.Data) callback.Data {
+	return callback.New(consts.CurrentlistWord, callback.OpStart)
+}
+
+func (c *currentlist) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
+	var shoppingID int
 	var err error
 	// if first start of currentlist page
-	if curData == consts.Start {
+	if curData.Op == callback.OpStart {
 		// get current shopping ID
-		currentlistShoppingID, err := c.sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCurrentList)
-		if err != nil {
-			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
-		}
-
-		result = &helpers.ParseResult{
-			ShoppingID: currentlistShoppingID,
-		}
+		shoppingID, err = sessionItem.SListAPI.GetOrAddSpecialShopping(consts.ShoppingTypeCurrentList, consts.CurrentlistWord)
 	} else {
-		result, err = helpers.ParseCommand(curData)
-		if err != nil {
-			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
-		}
+		shoppingID, err = curData.IntArg(0)
+	}
+	if err != nil {
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
 	}
 
-	err = c.sessionItem.SListAPI.AddItem(result.ShoppingID, msg)
+	if search, ok := logic.SearchText(msg); ok {
+		// the message searches the products to add
+		sessionItem.State(consts.CurrentlistWord).Search = search
+		return c.getOutput(sessionItem, shoppingID)
+	}
+	sessionItem.State(consts.CurrentlistWord).Search = ""
+
+	// every line or comma separated entry of the message is the item
+	_, err = sessionItem.SListAPI.AddItems(shoppingID, logic.SplitItems(msg))
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
 	}
 
-	return c.getOutput(result, &msg)
+	return c.getOutput(sessionItem, shoppingID)
 }
 
-func (c *currentlist) getOutput(parseObject *helpers.ParseResult, addedItemName *string) (logic.Output, error) {
-	shoppingIDStr := strconv.Itoa(parseObject.ShoppingID)
-	selectedItems := c.sessionItem.GetDataAsArray()
+func (c *currentlist) getOutput(sessionItem *session.SessionItem, shoppingID int) (logic.Output, error) {
+	shoppingIDStr := strconv.Itoa(shoppingID)
+	state := sessionItem.State(consts.CurrentlistWord)
+	lang := sessionItem.Lang
 
-	_, err := c.sessionItem.SListAPI.GetShopping(parseObject.ShoppingID)
+	_, err := sessionItem.SListAPI.GetShopping(shoppingID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
 	}
+	// message is refreshed on changes of other members
+	sessionItem.ShoppingID = shoppingID
 
 	//create keyboard and add back button to keyboard
 	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backSumbol, consts.FirstPageStart),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.MenuButton), consts.FirstPageStart),
+	}
+
+	items, err := sessionItem.SListAPI.GetShoppingItems(shoppingID)
+	if err != nil && !errors.Is(err, consts.ErrNotFound) {
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+	}
+
+	// products due to buy again are shown while nothing is searched
+	due := []restock.Suggestion{}
+	if state.Search == "" {
+		due, err = c.dueProducts(sessionItem, items)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		}
 	}
+	restockButtons, restockSection := logic.RestockButtons(lang, consts.CurrentlistWord, shoppingID, due)
 
-	items, err := c.sessionItem.SListAPI.GetShoppingItems(parseObject.ShoppingID)
+	// suggested products of the catalog
+	products, err := sessionItem.SListAPI.SuggestProducts(shoppingID, state.Search, logic.SuggestionsLimit)
 	if err != nil {
-		//if get empty items list
-		// heroku's go not understand errors.Is
-		if err == consts.ErrNotFound {
-			return logic.Output{
-				Message: emptyItems,
-				Keyboard: &tgbotapi.InlineKeyboardMarkup{
-					InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
-						controlButtons,
-					},
-				},
-			}, nil
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+	}
+	quickAddButtons, searchNote := logic.QuickAddButtons(lang, consts.CurrentlistWord, shoppingID, state.Search, withoutDue(products, due))
+	suggestionButtons := append(restockButtons, quickAddButtons...)
+
+	//if get empty items list
+	if len(items) == 0 {
+		message := i18n.T(lang, i18n.CurrentListEmpty)
+		if searchNote != "" {
+			message = searchNote
 		}
+		return logic.Output{
+			Message: withSection(message, restockSection),
+			Keyboard: &tgbotapi.InlineKeyboardMarkup{
+				InlineKeyboard: append(suggestionButtons, controlButtons),
+			},
+		}, nil
+	}
+
+	categories, err := sessionItem.SListAPI.GetItemCategories()
+	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
 	}
+	// items are grouped by categories in the aisle order
+	categorized := logic.Categorize(items, categories)
 
 	column := [][]tgbotapi.InlineKeyboardButton{}
 
 	// create items list to show
-	for i, data := range items {
+	for i, data := range categorized.Items {
 		itemIDStr := strconv.Itoa(data.ID)
-		itemName := data.ProductName
+		itemName := quantity.Format(data.ProductName, data.Quantity, data.Unit)
 		// strikethrough item name
-		if helpers.IsInArray(data.ID, selectedItems) {
+		if state.IsSelected(data.ID) {
 			itemName = helpers.GetStrikeThroughText(itemName)
 		}
+		itemName = categorized.Mark(data) + itemName
 
-		itemData := consts.ListItemSymbol + itemIDStr
-
-		//make item buttom param with shopping id, removed item ids + id curItem to remove
-		param := helpers.GetParam(
+		//make item button param with shopping id and item id to select
+		param := callback.New(
 			consts.CurrentlistWord,
+			callback.OpSelect,
 			shoppingIDStr,
-			itemData,
+			itemIDStr,
 		)
 		row := []tgbotapi.InlineKeyboardButton{
-			tgbotapi.NewInlineKeyboardButtonData(strconv.Itoa(i+1)+". "+itemName, param),
+			callback.Button(strconv.Itoa(i+1)+". "+itemName, param),
 		}
 		column = append(column, row)
 	}
 
+	// quantity buttons of the only selected item
+	if len(state.Selected) == 1 {
+		for _, data := range items {
+			if data.ID == state.Selected[0] {
+				column = append(column, logic.ItemButtons(consts.CurrentlistWord, shoppingID, data))
+			}
+		}
+	}
+
 	// add remove and copy buttons to keyboard
-	if len(selectedItems) > 0 {
+	if len(state.Selected) > 0 {
 		//remove button
-		removeButton := tgbotapi.NewInlineKeyboardButtonData(removeMsg,
-			helpers.GetParam(
+		removeButton := callback.Button(i18n.T(lang, i18n.RemoveButton),
+			callback.New(
 				consts.CurrentlistWord,
+				callback.OpDelete,
 				shoppingIDStr,
-				consts.ListStartRemoveSymbol,
 			))
 		controlButtons = append(controlButtons, removeButton)
 	}
 
+	column = append(column, suggestionButtons...)
 	//final keyboard
 	column = append(column, controlButtons)
 	keyboard := &tgbotapi.InlineKeyboardMarkup{
 		InlineKeyboard: column,
 	}
 
-	output := logic.Output{
-		Message:  inputMsg,
+	message := i18n.T(lang, i18n.CurrentListInput)
+	if searchNote != "" {
+		message = searchNote
+	}
+	return logic.Output{
+		Message:  withSection(message, restockSection),
 		Keyboard: keyboard,
+	}, nil
+}
+
+// dueProducts returns the products due to buy again by the purchase history of
+// the community, products of the list are skipped
+func (c *currentlist) dueProducts(sessionItem *session.SessionItem, items []*ent.Item) ([]restock.Suggestion, error) {
+	now := time.Now()
+	purchases, err := sessionItem.SListAPI.GetPurchases(now.AddDate(0, 0, -logic.RestockDays))
+	if err != nil {
+		return nil, err
 	}
 
-	if addedItemName != nil {
-		msg := fmt.Sprintf(
-			"Арларлов(а) добавил(а) '%s' в свой текущий",
-			*addedItemName,
-		)
-		output.MessageToCommunity = &msg
+	inList := map[string]bool{}
+	for _, v := range items {
+		inList[category.Normalize(v.ProductName)] = true
 	}
+	due := []restock.Suggestion{}
+	for _, v := range restock.Due(purchases, now) {
+		if inList[category.Normalize(v.Name)] {
+			continue
+		}
+		due = append(due, v)
+		if len(due) == logic.SuggestionsLimit {
+			break
+		}
+	}
+	return due, nil
+}
 
-	return output, nil
+// withoutDue skips the catalog products which are suggested as due to buy again
+func withoutDue(products []*ent.Product, due []restock.Suggestion) []*ent.Product {
+	dueNames := map[string]bool{}
+	for _, v := range due {
+		dueNames[category.Normalize(v.Name)] = true
+	}
+	result := []*ent.Product{}
+	for _, v := range products {
+		if !dueNames[v.Name] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// withSection appends the section to the message
+func withSection(message, section string) string {
+	if section == "" {
+		return message
+	}
+	return message + "\n\n" + section
 }
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
 	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
@@ -16,42 +18,33 @@ import (
 )
 
 const (
-	DayshoppingsWord  = "dayshoppings"
-	dateLayout        = "02.01.2006"
-	toCalendarText    = "⬅ Смотреть календарь"
-	dayshoppingsText  = "Покупки в этот день. Для добавления введите название места."
-	dayshoppingsEmpty = "Покупок в этот день нет. Для добавления введите название места."
+	DayshoppingsWord = "dayshoppings"
+	dateLayout       = "02.01.2006"
 )
 
-type dayshoppings struct {
-	sessionItem *session.SessionItem
-}
+type dayshoppings struct{}
 
 func New() *dayshoppings {
 	return &dayshoppings{}
 }
 
-func (d *dayshoppings) SetSession(sessionItem *session.SessionItem) {
-	d.sessionItem = sessionItem
-}
-
-func getCalendarBtn(day time.Time) tgbotapi.InlineKeyboardButton {
-	btnParam := helpers.GetParam(consts.CalendarWord, helpers.Time2MonthCode(day))
-	return tgbotapi.NewInlineKeyboardButtonData(toCalendarText, btnParam)
+func getCalendarBtn(lang i18n.Lang, day time.Time) tgbotapi.InlineKeyboardButton {
+	btnParam := callback.New(consts.CalendarWord, callback.OpShow, helpers.Time2MonthCode(day))
+	return callback.Button(i18n.T(lang, i18n.ToCalendarButton), btnParam)
 }
 
 // keyboard with one button - to calendar
-func getToCalendarKeyboard(day time.Time) *tgbotapi.InlineKeyboardMarkup {
+func getToCalendarKeyboard(lang i18n.Lang, day time.Time) *tgbotapi.InlineKeyboardMarkup {
 	return &tgbotapi.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
 			[]tgbotapi.InlineKeyboardButton{
-				getCalendarBtn(day),
+				getCalendarBtn(lang, day),
 			},
 		},
 	}
 }
 
-func getButtonsByData(sList []*ent.Shopping, day time.Time) *tgbotapi.InlineKeyboardMarkup {
+func getButtonsByData(lang i18n.Lang, sList []*ent.Shopping, day time.Time) *tgbotapi.InlineKeyboardMarkup {
 	column := [][]tgbotapi.InlineKeyboardButton{}
 
 	for i, sh := range sList {
@@ -59,18 +52,19 @@ func getButtonsByData(sList []*ent.Shopping, day time.Time) *tgbotapi.InlineKeyb
 		fmt.Println("\n shopping=", sh.ID)
 		fmt.Println("\n shopping=", sh)
 		//
-		param := helpers.GetParam(
+		param := callback.New(
 			consts.ShoppingitemsWord,
+			callback.OpShow,
 			strconv.Itoa(sh.ID),
 		)
 		row := []tgbotapi.InlineKeyboardButton{
-			tgbotapi.NewInlineKeyboardButtonData(strconv.Itoa(i+1)+". "+sh.Edges.Shop.Name, param),
+			callback.Button(strconv.Itoa(i+1)+". "+sh.Edges.Shop.Name, param),
 		}
 		column = append(column, row)
 	}
 	// add last button - back to calendar
 	column = append(column, []tgbotapi.InlineKeyboardButton{
-		getCalendarBtn(day),
+		getCalendarBtn(lang, day),
 	})
 
 	return &tgbotapi.InlineKeyboardMarkup{
@@ -78,48 +72,49 @@ func getButtonsByData(sList []*ent.Shopping, day time.Time) *tgbotapi.InlineKeyb
 	}
 }
 
-func (d *dayshoppings) getOutput(day time.Time) (logic.Output, error) {
-	sList, err := d.sessionItem.SListAPI.GetShoppingsByDay(day)
+func (d *dayshoppings) getOutput(sessionItem *session.SessionItem, day time.Time) (logic.Output, error) {
+	sList, err := sessionItem.SListAPI.GetShoppingsByDay(day)
 	if err != nil {
 		return logic.Output{}, err
 	}
 
-	getMsg := func(template string) string {
+	lang := sessionItem.Lang
+	getMsg := func(template i18n.Key) string {
 		return strings.Join([]string{
 			day.Format(dateLayout),
-			template,
+			i18n.T(lang, template),
 		}, " ")
 	}
 
 	if len(sList) == 0 {
 		return logic.Output{
-			Message:  getMsg(dayshoppingsEmpty),
-			Keyboard: getToCalendarKeyboard(day),
+			Message:  getMsg(i18n.DayShoppingsEmpty),
+			Keyboard: getToCalendarKeyboard(lang, day),
 		}, nil
 	}
 
 	return logic.Output{
-		Message:  getMsg(dayshoppingsText),
-		Keyboard: getButtonsByData(sList, day),
+		Message:  getMsg(i18n.DayShoppingsTitle),
+		Keyboard: getButtonsByData(lang, sList, day),
 	}, nil
 }
 
-func (d *dayshoppings) GetCallbackOutput(command string) (logic.Output, error) {
-	day, err := helpers.DayCode2Time(command)
+func (d *dayshoppings) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	day, err := helpers.DayCode2Time(data.Arg(0))
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.DayshoppingsWord, consts.ErrUnknownCommand)
 	}
-	return d.getOutput(day)
+	return d.getOutput(sessionItem, day)
 }
 
-func (d *dayshoppings) GetMessageOutput(curData string, msg string) (logic.Output, error) {
-	day, err := helpers.DayCode2Time(curData)
+func (d *dayshoppings) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
+	day, err := helpers.DayCode2Time(curData.Arg(0))
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.DayshoppingsWord, consts.ErrUnknownCommand)
 	}
-	err = d.sessionItem.SListAPI.AddShopping(day, msg)
+	err = sessionItem.SListAPI.AddShopping(day, msg)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.DayshoppingsWord, err)
 	}
-	return d.getOutput(day)
+	return d.getOutput(sessionItem, day)
 }
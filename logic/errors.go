@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"errors"
+	"log"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// UserError is the error which text is shown to the user as is, e.g. wrong input
+type UserError struct {
+	Text string
+	Err  error
+}
+
+// NewUserError returns error with text for the user
+func NewUserError(text string) error {
+	return &UserError{Text: text}
+}
+
+// WrapUserError returns error with text for the user, err is only logged
+func WrapUserError(text string, err error) error {
+	return &UserError{Text: text, Err: err}
+}
+
+func (e *UserError) Error() string {
+	if e.Err == nil {
+		return e.Text
+	}
+	return e.Text + ": " + e.Err.Error()
+}
+
+func (e *UserError) Unwrap() error {
+	return e.Err
+}
+
+// Reporter reports internal error with request context, e.g. to sentry
+type Reporter func(err error, r Request)
+
+// Errors renders node errors. User errors and not found or forbidden targets
+// are shown with the menu button, other errors are internal: they are reported
+// and the user gets generic text with the retry button.
+func (l *Logic) Errors(report Reporter) Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) (Output, error) {
+			output, err := next(r)
+			if err == nil {
+				return output, nil
+			}
+
+			lang := r.Session.Lang
+			var userErr *UserError
+			switch {
+			case errors.As(err, &userErr):
+				return menuOutput(lang, userErr.Text), nil
+			case errors.Is(err, consts.ErrNotFound):
+				return menuOutput(lang, i18n.T(lang, i18n.NotFound)), nil
+			case errors.Is(err, consts.ErrForbidden):
+				return menuOutput(lang, i18n.T(lang, i18n.AccessDenied)), nil
+			}
+
+			log.Printf("internal error: update_id=%d telegram_id=%d node=%s data=%q: %v\n",
+				r.Input.UpdateID, telegramID(r.Session), r.Node, r.Session.CurrentData, err)
+			if report != nil {
+				report(err, r)
+			}
+			return l.retryOutput(r), nil
+		}
+	}
+}
+
+// Operation returns operation of the request, it is empty for the bad data
+func (r Request) Operation() string {
+	data, err := callback.ParseCommand(r.Node, r.Session.CurrentData)
+	if err != nil {
+		return ""
+	}
+	return data.Op
+}
+
+// retryOutput returns generic error text with the button which shows the
+// node again, the failed command is not repeated as it may change data
+func (l *Logic) retryOutput(r Request) Output {
+	lang := r.Session.Lang
+	output := menuOutput(lang, i18n.T(lang, i18n.InternalError))
+
+	retryData := l.ReopenData(r.Session)
+	if retryData == consts.FirstPageStart {
+		// the menu button is already there
+		return output
+	}
+	output.Keyboard.InlineKeyboard[0] = append(
+		[]tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.RetryButton), retryData)},
+		output.Keyboard.InlineKeyboard[0]...,
+	)
+	return output
+}
@@ -0,0 +1,76 @@
+package logic_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	"github.com/stretchr/testify/require"
+)
+
+// errorNode returns its error on every callback
+type errorNode struct {
+	fakeNode
+	err error
+}
+
+func (e *errorNode) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	return logic.Output{}, e.err
+}
+
+// ReopenCommand shows the node without repeating the failed command
+func (e *errorNode) ReopenCommand(currentData callback.Data) callback.Data {
+	return callback.New("broken", callback.OpShow, currentData.Args...)
+}
+
+func TestErrors(t *testing.T) {
+	internalErr := errors.New("sql: database is locked")
+
+	tests := []struct {
+		name        string
+		err         error
+		expMessage  string
+		expReported bool
+		expButtons  int
+	}{
+		{name: "user error", err: fmt.Errorf("node: %w", logic.NewUserError("Неверная сумма")), expMessage: "Неверная сумма", expButtons: 1},
+		{name: "not found", err: fmt.Errorf("GetShopping: %w", consts.ErrNotFound), expButtons: 1},
+		{name: "forbidden", err: consts.ErrForbidden, expButtons: 1},
+		{name: "internal", err: internalErr, expMessage: i18n.T(i18n.RU, i18n.InternalError), expReported: true, expButtons: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var reported error
+			var reportedOp string
+			l := newTestLogic().
+				AddNode("broken", &errorNode{err: test.err})
+			l.Use(l.Errors(func(err error, r logic.Request) {
+				reported = err
+				reportedOp = r.Operation()
+			}))
+
+			data := "1:broken:del:12"
+			output, err := l.GetOutput(logic.Input{CallbackData: &data}, &session.SessionItem{CurrentNode: "broken", CurrentData: "del:12"})
+			require.NoError(t, err)
+			if test.expMessage != "" {
+				require.Equal(t, test.expMessage, output.Message)
+			}
+			require.NotContains(t, output.Message, test.err.Error())
+			require.Len(t, output.Keyboard.InlineKeyboard[0], test.expButtons)
+
+			if !test.expReported {
+				require.Nil(t, reported)
+				return
+			}
+			require.Equal(t, internalErr, reported)
+			require.Equal(t, callback.OpDelete, reportedOp)
+			// retry shows the node again instead of repeating the failed command
+			require.Equal(t, "1:broken:show:12", *output.Keyboard.InlineKeyboard[0][0].CallbackData)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package firstpage
 
 import (
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 
@@ -11,21 +13,13 @@ import (
 const (
 	FirstpageWord = "firstpage"
 
-	curList   = "Текущий список"
-	checkList = "Чек-лист"
-	settings  = "Настройки"
-	calendar  = "Календарь"
-	buget     = "Бюджет"
-	funds     = "Фонды"
-	iot       = "iot"
+	iot = "iot"
 
 	CurListCmd   = "curlist"
 	CheckListCmd = "checklist"
 	SettingsCmd  = "settings"
 	CalendarCmd  = "calendar"
 	BugetCmd     = "buget"
-
-	firstPageMessage = "firstPage"
 )
 
 type firstpage struct{}
@@ -34,40 +28,36 @@ func New() *firstpage {
 	return &firstpage{}
 }
 
-func (f *firstpage) SetSession(session *session.SessionItem) {
-	//silence is gold
-}
-
-func (f *firstpage) GetCallbackOutput(command string) (logic.Output, error) {
-	switch command {
-	case consts.Start:
-		return getOutput()
+func (f *firstpage) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	switch data.Op {
+	case callback.OpStart:
+		return getOutput(sessionItem.Lang)
 	default:
 		return logic.Output{}, consts.ErrUnknownCommand
 	}
 }
 
-func (f *firstpage) GetMessageOutput(curData string, msg string) (logic.Output, error) {
-	return getOutput()
+func (f *firstpage) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
+	return getOutput(sessionItem.Lang)
 }
 
-func getButtons() *tgbotapi.InlineKeyboardMarkup {
+func getButtons(lang i18n.Lang) *tgbotapi.InlineKeyboardMarkup {
 	return &tgbotapi.InlineKeyboardMarkup{
 		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
-			{tgbotapi.NewInlineKeyboardButtonData(curList, consts.CurrentListStart)}, //TODO add correct param
-			{tgbotapi.NewInlineKeyboardButtonData(checkList, consts.ChecklistStart)},
-			{tgbotapi.NewInlineKeyboardButtonData(settings, consts.SettingsStart)},
-			{tgbotapi.NewInlineKeyboardButtonData(calendar, consts.CalendarStart)},
-			{tgbotapi.NewInlineKeyboardButtonData(buget, consts.BugetStart)},
-			{tgbotapi.NewInlineKeyboardButtonData(funds, consts.FundsStart)},
+			{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.CurrentListButton), consts.CurrentListStart)}, //TODO add correct param
+			{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.ChecklistButton), consts.ChecklistStart)},
+			{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.SettingsButton), consts.SettingsStart)},
+			{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.CalendarButton), consts.CalendarStart)},
+			{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BudgetButton), consts.BugetStart)},
+			{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.FundsButton), consts.FundsStart)},
 			{tgbotapi.NewInlineKeyboardButtonData(iot, consts.IOTStart)},
 		},
 	}
 }
 
-func getOutput() (logic.Output, error) {
+func getOutput(lang i18n.Lang) (logic.Output, error) {
 	return logic.Output{
-		Message:  firstPageMessage,
-		Keyboard: getButtons(),
+		Message:  i18n.T(lang, i18n.FirstPageTitle),
+		Keyboard: getButtons(lang),
 	}, nil
 }
@@ -1,7 +1,6 @@
 package fund
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -12,10 +11,12 @@ import (
 	"context"
 
 	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
-	"github.com/spf13/viper"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
@@ -23,11 +24,7 @@ import (
 const (
 	bugetCategoryWord    = "fund"
 	dateLayout           = "02.01.06"
-	backText             = "⬅ Назад"
 	newbugetCategoryText = "*** Создать новый ***"
-	emptyItems           = "Нет фондов для отображения"
-
-	fundText = "Фонд: %s состояние (%dр):\n"
 
 	maxHistoryNotes = 10
 )
@@ -35,13 +32,11 @@ const (
 var (
 	timeout = time.Second * 5
 
-	patternCallback = regexp.MustCompile(`i(\d+)`)
-	patternNewNote  = regexp.MustCompile(`(-?)(\d+)\s+(.+)`)
+	patternNewNote = regexp.MustCompile(`(-?)(\d+)\s+(.+)`)
 )
 
 type bugetCategory struct {
-	sessionItem *session.SessionItem
-	storage     bugetstorage.Storage
+	storage bugetstorage.Storage
 }
 
 func New(storage bugetstorage.Storage) *bugetCategory {
@@ -50,59 +45,40 @@ func New(storage bugetstorage.Storage) *bugetCategory {
 	}
 }
 
-func (d *bugetCategory) SetSession(sessionItem *session.SessionItem) {
-	d.sessionItem = sessionItem
-}
-
-func (c *bugetCategory) GetCallbackOutput(command string) (logic.Output, error) {
-	log.Println("** message callback:", command)
+func (c *bugetCategory) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	log.Println("** message callback:", data.Command())
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	fundID, err := parseCurData(command)
+	fundID, err := data.IntArg(0)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundWord, err)
 	}
-	fund, err := c.storage.GetFund(ctx, fundID)
+	fund, err := c.storage.GetFund(ctx, sessionItem.User.ComunityID, fundID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundWord, err)
 	}
 
-	return c.getOutput(fund)
-}
-
-// returns fundID
-func parseCurData(data string) (int, error) {
-	//parse msg to category
-	m := patternCallback.FindStringSubmatch(data)
-	if len(m) != 2 {
-		return 0, errors.New("parsing error")
-	}
-
-	fundID, err := strconv.Atoi(m[1])
-	if err != nil {
-		return 0, err
-	}
-	return fundID, nil
+	return c.getOutput(sessionItem, fund)
 }
 
-func (c *bugetCategory) GetMessageOutput(curData string, msg string) (logic.Output, error) {
+func (c *bugetCategory) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	fundID, err := parseCurData(curData)
+	fundID, err := curData.IntArg(0)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundWord, err)
 	}
 
-	fund, err := c.storage.GetFund(ctx, fundID)
+	fund, err := c.storage.GetFund(ctx, sessionItem.User.ComunityID, fundID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundWord, err)
 	}
 
 	m := patternNewNote.FindStringSubmatch(msg)
 	if len(m) != 4 {
-		return c.getOutput(fund)
+		return c.getOutput(sessionItem, fund)
 	}
 	noteTitle := m[3]
 	noteSum, _ := strconv.Atoi(m[2])
@@ -125,27 +101,22 @@ func (c *bugetCategory) GetMessageOutput(curData string, msg string) (logic.Outp
 		Title:      noteTitle,
 		Created:    time.Now().Unix(),
 	}
-	if err := c.storage.InsertNote(ctx, note); err != nil {
+	// the author is kept in the audit log to undo the note
+	if err := c.storage.InsertNote(events.WithAuthor(ctx, sessionItem.Author()), note); err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundWord, err)
 	}
 
-	return c.getOutput(fund)
+	return c.getOutput(sessionItem, fund)
 }
 
-func (c *bugetCategory) getOutput(category bugetstorage.Category) (logic.Output, error) {
+func (c *bugetCategory) getOutput(sessionItem *session.SessionItem, category bugetstorage.Category) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-
-	bugetCommunity := viper.GetString("SHOPLIST-BUDGET_COMMUNITY")
-	if c.sessionItem.User.ComunityID != bugetCommunity {
-		log.Println("ACCESS DENIED: ", c.sessionItem.User, c.sessionItem.User.ComunityID)
-
-		return logic.Output{}, nil
-	}
+	lang := sessionItem.Lang
 
 	//create keyboard and add back button to keyboard
 	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backText, consts.FundsStart),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BackButton), consts.FundsStart),
 	}
 
 	column := [][]tgbotapi.InlineKeyboardButton{controlButtons}
@@ -154,19 +125,19 @@ func (c *bugetCategory) getOutput(category bugetstorage.Category) (logic.Output,
 	keyboard := &tgbotapi.InlineKeyboardMarkup{
 		InlineKeyboard: column,
 	}
-	notes, err := c.storage.GetCategoryNotes(ctx, category.ID)
+	notes, err := c.storage.GetCategoryNotes(ctx, sessionItem.User.ComunityID, category.ID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundWord, err)
 	}
 
-	outTxt := []string{fmt.Sprintf(fundText, category.Title, category.Current)}
+	outTxt := []string{i18n.T(lang, i18n.FundText, category.Title, category.Current)}
 	for i, v := range notes {
 		t := time.Unix(v.Created, 0).Format(dateLayout)
 		plus := ""
 		if v.Sum > 0 {
 			plus = "+"
 		}
-		noteTxt := fmt.Sprintf("%d) %s -> %s%dр. - %s\n", i+1, t, plus, v.Sum, v.Title)
+		noteTxt := i18n.T(lang, i18n.FundNote, i+1, t, plus, v.Sum, v.Title)
 		outTxt = append(outTxt, noteTxt)
 	}
 
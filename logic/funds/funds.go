@@ -11,22 +11,15 @@ import (
 	"context"
 
 	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
-	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
-	"github.com/spf13/viper"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
-const (
-	fundTxt = `Виртуальные фонды, всего: %d,
-	Пример добавления фонда: "25000 фонд подарков"`
-	backText   = "⬅ Назад"
-	emptyItems = "Нет фондов для отображения"
-)
-
 var (
 	timeout = time.Second * 5
 
@@ -34,8 +27,7 @@ var (
 )
 
 type buget struct {
-	sessionItem *session.SessionItem
-	storage     bugetstorage.Storage
+	storage bugetstorage.Storage
 }
 
 func New(storage bugetstorage.Storage) *buget {
@@ -44,23 +36,19 @@ func New(storage bugetstorage.Storage) *buget {
 	}
 }
 
-func (d *buget) SetSession(sessionItem *session.SessionItem) {
-	d.sessionItem = sessionItem
+func (c *buget) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	log.Println("** message callback:", data.Command())
+	return c.getOutput(sessionItem)
 }
 
-func (c *buget) GetCallbackOutput(command string) (logic.Output, error) {
-	log.Println("** message callback:", command)
-	return c.getOutput()
-}
-
-func (c *buget) GetMessageOutput(curData string, msg string) (logic.Output, error) {
+func (c *buget) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	//parse msg to fund
 	m := patternNewFund.FindStringSubmatch(msg)
 	if len(m) != 3 {
-		return c.getOutput()
+		return c.getOutput(sessionItem)
 	}
 	title := m[2]
 	fundSum, _ := strconv.Atoi(m[1])
@@ -79,26 +67,21 @@ func (c *buget) GetMessageOutput(curData string, msg string) (logic.Output, erro
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundsWord, err)
 	}
-	return c.getOutput()
+	return c.getOutput(sessionItem)
 }
 
-func (c *buget) getOutput() (logic.Output, error) {
-	bugetCommunity := viper.GetString("SHOPLIST-BUDGET_COMMUNITY")
-	if c.sessionItem.User.ComunityID != bugetCommunity {
-		log.Println("ACCESS DENIED: ", c.sessionItem.User, c.sessionItem.User.ComunityID)
-
-		return logic.Output{}, nil
-	}
-
+func (c *buget) getOutput(sessionItem *session.SessionItem) (logic.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	lang := sessionItem.Lang
+
 	//create keyboard and add back button to keyboard
 	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backText, consts.FirstPageStart),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BackButton), consts.FirstPageStart),
 	}
 	emptyOut := logic.Output{
-		Message: emptyItems,
+		Message: i18n.T(lang, i18n.EmptyFunds),
 		Keyboard: &tgbotapi.InlineKeyboardMarkup{
 			InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
 				controlButtons,
@@ -106,7 +89,7 @@ func (c *buget) getOutput() (logic.Output, error) {
 		},
 	}
 
-	funds, err := c.storage.GetFunds(ctx)
+	funds, err := c.storage.GetFunds(ctx, sessionItem.User.ComunityID)
 	if err != nil && err != sql.ErrNoRows {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.FundsWord, err)
 	}
@@ -125,20 +108,19 @@ func (c *buget) getOutput() (logic.Output, error) {
 		itemIDStr := strconv.Itoa(fund.ID)
 		itemName := fund.Title
 
-		itemData := consts.ListItemSymbol + itemIDStr
-
-		//make item buttom param with shopping id, removed item ids + id curItem to remove
-		param := helpers.GetParam(
+		//make item button param with item id to show
+		param := callback.New(
 			consts.FundWord,
-			itemData,
+			callback.OpShow,
+			itemIDStr,
 		)
 
-		btnTxt := fmt.Sprintf("%d. %s, ост: %dр.", i+1, itemName, fund.Current)
+		btnTxt := i18n.T(lang, i18n.FundButton, i+1, itemName, fund.Current)
 		//debug
 		fmt.Printf("btnTxt=%s\n", btnTxt)
 
 		row := []tgbotapi.InlineKeyboardButton{
-			tgbotapi.NewInlineKeyboardButtonData(btnTxt, param),
+			callback.Button(btnTxt, param),
 		}
 		column = append(column, row)
 	}
@@ -149,7 +131,7 @@ func (c *buget) getOutput() (logic.Output, error) {
 		InlineKeyboard: column,
 	}
 
-	outTxt := fmt.Sprintf(fundTxt, curSum)
+	outTxt := i18n.T(lang, i18n.FundsText, curSum)
 
 	output := logic.Output{
 		Message:  outTxt,
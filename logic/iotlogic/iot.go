@@ -8,11 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 	"github.com/google/uuid"
-	"github.com/spf13/viper"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
@@ -23,7 +24,6 @@ import (
 )
 
 const (
-	backText   = "⬅ Назад"
 	timeLayout = "15:04"
 )
 
@@ -32,8 +32,7 @@ var (
 )
 
 type iotLogic struct {
-	sessionItem *session.SessionItem
-	storage     iot.IOTStorage
+	storage iot.IOTStorage
 }
 
 func New(storage iot.IOTStorage) *iotLogic {
@@ -42,17 +41,13 @@ func New(storage iot.IOTStorage) *iotLogic {
 	}
 }
 
-func (d *iotLogic) SetSession(sessionItem *session.SessionItem) {
-	d.sessionItem = sessionItem
+func (c *iotLogic) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	log.Println("** message callback:", data.Command())
+	return c.getOutput(sessionItem)
 }
 
-func (c *iotLogic) GetCallbackOutput(command string) (logic.Output, error) {
-	log.Println("** message callback:", command)
-	return c.getOutput()
-}
-
-func (c *iotLogic) GetMessageOutput(curData string, msg string) (logic.Output, error) {
-	return c.getOutput()
+func (c *iotLogic) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
+	return c.getOutput(sessionItem)
 }
 
 func newErrorOut(msg string, controlButtons []tgbotapi.InlineKeyboardButton) logic.Output {
@@ -66,15 +61,12 @@ func newErrorOut(msg string, controlButtons []tgbotapi.InlineKeyboardButton) log
 	}
 }
 
-func (c *iotLogic) getOutput() (logic.Output, error) {
-	iotCommunity := viper.GetString("SHOPLIST-BUDGET_COMMUNITY")
-	if c.sessionItem.User.ComunityID != iotCommunity {
-		return logic.Output{}, nil
-	}
+func (c *iotLogic) getOutput(sessionItem *session.SessionItem) (logic.Output, error) {
+	lang := sessionItem.Lang
 
 	//create keyboard and add back button to keyboard
 	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backText, consts.FirstPageStart),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BackButton), consts.FirstPageStart),
 	}
 
 	dayValues, err := c.storage.GetDayValues(time.Now())
@@ -83,7 +75,7 @@ func (c *iotLogic) getOutput() (logic.Output, error) {
 	}
 
 	if len(dayValues) == 0 {
-		return newErrorOut("no new values", controlButtons), nil
+		return newErrorOut(i18n.T(lang, i18n.IOTNoValues), controlButtons), nil
 	}
 
 	msg := getMessage(dayValues)
@@ -109,7 +101,6 @@ func (c *iotLogic) getOutput() (logic.Output, error) {
 	}
 
 	bytes := tgbotapi.FileBytes{Name: name, Bytes: content}
-	img := tgbotapi.NewPhotoUpload(c.sessionItem.ChatID, bytes)
 
 	out := logic.Output{
 		Message: msg,
@@ -118,7 +109,7 @@ func (c *iotLogic) getOutput() (logic.Output, error) {
 				controlButtons,
 			},
 		},
-		Image: &img,
+		Actions: []logic.Action{logic.Photo(bytes, "")},
 	}
 
 	return out, nil
@@ -0,0 +1,228 @@
+package logic
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
+	"github.com/Frosin/shoplist-telegram-bot/quantity"
+	"github.com/Frosin/shoplist-telegram-bot/restock"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	// categoryColumns is the count of the category buttons in the picker row
+	categoryColumns = 2
+	// productColumns is the count of the quick add buttons in the row
+	productColumns = 2
+
+	// SuggestionsLimit is the count of the suggested products of the list
+	SuggestionsLimit = 4
+	// RestockDays is the count of the days of the purchase history
+	RestockDays = 180
+	// searchSuffix ends the message which searches the products, e.g. "мол?"
+	searchSuffix = "?"
+)
+
+// patternListMarker is the bullet or the number of the pasted list line, e.g. "- ", "• " or "2) "
+var patternListMarker = regexp.MustCompile(`^(?:[-–—*•·]+|\d+[.)])\s+`)
+
+// SplitItems splits the message into item texts by lines and commas, commas
+// between digits are kept, e.g. "картошка 2,5 кг". Blank texts are skipped.
+func SplitItems(msg string) []string {
+	texts := []string{}
+	for _, line := range strings.Split(msg, "\n") {
+		for _, text := range splitCommas(line) {
+			text = strings.TrimSpace(text)
+			text = strings.TrimSpace(patternListMarker.ReplaceAllString(text, ""))
+			if text != "" {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return texts
+}
+
+func splitCommas(line string) []string {
+	runes := []rune(line)
+	parts := []string{}
+	start := 0
+	for i, r := range runes {
+		if r != ',' && r != ';' {
+			continue
+		}
+		// decimal comma
+		if r == ',' && i > 0 && i+1 < len(runes) && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1]) {
+			continue
+		}
+		parts = append(parts, string(runes[start:i]))
+		start = i + 1
+	}
+	return append(parts, string(runes[start:]))
+}
+
+// ItemButtons returns the row of the selected item which decreases and increases
+// its quantity and chooses its category, as example: "1:checklist:inc:123:45"
+func ItemButtons(node string, shoppingID int, item *ent.Item) []tgbotapi.InlineKeyboardButton {
+	shoppingIDStr := strconv.Itoa(shoppingID)
+	itemIDStr := strconv.Itoa(item.ID)
+	return []tgbotapi.InlineKeyboardButton{
+		callback.Button("➖", callback.New(node, callback.OpDecrease, shoppingIDStr, itemIDStr)),
+		callback.Button(quantity.Format(item.ProductName, item.Quantity, item.Unit),
+			callback.New(node, callback.OpSelect, shoppingIDStr, itemIDStr)),
+		callback.Button("➕", callback.New(node, callback.OpIncrease, shoppingIDStr, itemIDStr)),
+		callback.Button("🏷", callback.New(node, callback.OpCategory, shoppingIDStr, itemIDStr)),
+	}
+}
+
+// QuantityDelta returns the quantity change of the increase and decrease operations
+func QuantityDelta(op string) int {
+	if op == callback.OpDecrease {
+		return -1
+	}
+	return 1
+}
+
+// Categorized are the items in the aisle order of their categories,
+// items without category are the last
+type Categorized struct {
+	Items  []*ent.Item
+	emojis map[int]string
+}
+
+// Categorize returns items sorted by categories, categories are in the aisle order
+func Categorize(items []*ent.Item, categories []*ent.ItemCategory) Categorized {
+	order := map[int]int{}
+	emojis := map[int]string{}
+	for i, v := range categories {
+		order[v.ID] = i
+		emojis[v.ID] = v.Emoji
+	}
+	position := func(item *ent.Item) int {
+		if i, ok := order[item.CategoryID]; ok {
+			return i
+		}
+		return len(categories)
+	}
+
+	sorted := make([]*ent.Item, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return position(sorted[i]) < position(sorted[j])
+	})
+	return Categorized{Items: sorted, emojis: emojis}
+}
+
+// Mark returns the emoji of the item category with space to show before
+// the item name, it is empty for the item without category
+func (c Categorized) Mark(item *ent.Item) string {
+	if emoji := c.emojis[item.CategoryID]; emoji != "" {
+		return emoji + " "
+	}
+	return ""
+}
+
+// CategoryPicker returns the output which chooses the category of the item,
+// the back button shows the list of the node
+func CategoryPicker(lang i18n.Lang, node string, shoppingID int, item *ent.Item, categories []*ent.ItemCategory) Output {
+	shoppingIDStr := strconv.Itoa(shoppingID)
+	itemIDStr := strconv.Itoa(item.ID)
+	choose := func(text string, categoryID int) tgbotapi.InlineKeyboardButton {
+		if categoryID == item.CategoryID {
+			text = "✔ " + text
+		}
+		return callback.Button(text, callback.New(node, callback.OpSetCategory,
+			shoppingIDStr, itemIDStr, strconv.Itoa(categoryID)))
+	}
+
+	column := [][]tgbotapi.InlineKeyboardButton{}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, v := range categories {
+		row = append(row, choose(strings.TrimSpace(v.Emoji+" "+v.Name), v.ID))
+		if len(row) == categoryColumns {
+			column = append(column, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	row = append(row, choose(i18n.T(lang, i18n.NoCategoryButton), 0))
+	column = append(column, row, []tgbotapi.InlineKeyboardButton{
+		callback.Button(i18n.T(lang, i18n.BackButton), callback.New(node, callback.OpShow, shoppingIDStr)),
+	})
+
+	return Output{
+		Message: i18n.T(lang, i18n.ChooseCategory, quantity.Format(item.ProductName, item.Quantity, item.Unit)),
+		Keyboard: &tgbotapi.InlineKeyboardMarkup{
+			InlineKeyboard: column,
+		},
+	}
+}
+
+// SearchText returns the search text of the message which searches the catalog
+// products instead of adding the item, e.g. "мол?"
+func SearchText(msg string) (string, bool) {
+	msg = strings.TrimSpace(msg)
+	if !strings.HasSuffix(msg, searchSuffix) || strings.Contains(msg, "\n") {
+		return "", false
+	}
+	search := strings.TrimSpace(strings.TrimSuffix(msg, searchSuffix))
+	return search, search != ""
+}
+
+// QuickAddButtons returns the rows of the buttons which add the products to the
+// shopping and the note about the search result, it is empty without search
+func QuickAddButtons(lang i18n.Lang, node string, shoppingID int, search string, products []*ent.Product) ([][]tgbotapi.InlineKeyboardButton, string) {
+	shoppingIDStr := strconv.Itoa(shoppingID)
+	column := [][]tgbotapi.InlineKeyboardButton{}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, v := range products {
+		row = append(row, callback.Button("➕ "+v.Title,
+			callback.New(node, callback.OpQuickAdd, shoppingIDStr, strconv.Itoa(v.ID))))
+		if len(row) == productColumns {
+			column = append(column, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		column = append(column, row)
+	}
+
+	switch {
+	case search == "":
+		return column, ""
+	case len(products) == 0:
+		return column, i18n.T(lang, i18n.SearchNotFound, search)
+	}
+	return column, i18n.T(lang, i18n.SearchFound, search)
+}
+
+// RestockButtons returns the rows of the buttons which add the products due to
+// buy again to the shopping and the section listing them, it is empty without
+// products
+func RestockButtons(lang i18n.Lang, node string, shoppingID int, due []restock.Suggestion) ([][]tgbotapi.InlineKeyboardButton, string) {
+	if len(due) == 0 {
+		return nil, ""
+	}
+
+	shoppingIDStr := strconv.Itoa(shoppingID)
+	column := [][]tgbotapi.InlineKeyboardButton{}
+	row := []tgbotapi.InlineKeyboardButton{}
+	names := make([]string, 0, len(due))
+	for _, v := range due {
+		names = append(names, v.Name)
+		row = append(row, callback.Button("💡 "+v.Name,
+			callback.New(node, callback.OpRestock, shoppingIDStr, strconv.Itoa(v.ItemID))))
+		if len(row) == productColumns {
+			column = append(column, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		column = append(column, row)
+	}
+	return column, i18n.T(lang, i18n.RestockTitle, strings.Join(names, ", "))
+}
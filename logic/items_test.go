@@ -0,0 +1,68 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
+	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitItems(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want []string
+	}{
+		{"milk", []string{"milk"}},
+		{"milk, bread,eggs x10", []string{"milk", "bread", "eggs x10"}},
+		{"- milk\n\n  • bread  \n2) eggs\n3 батона", []string{"milk", "bread", "eggs", "3 батона"}},
+		{"картошка 2,5 кг; лук", []string{"картошка 2,5 кг", "лук"}},
+		{" ,\n ", []string{}},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, logic.SplitItems(tt.msg), tt.msg)
+	}
+}
+
+func TestSearchText(t *testing.T) {
+	tests := []struct {
+		msg    string
+		search string
+		ok     bool
+	}{
+		{"мол?", "мол", true},
+		{"  Молоко ? ", "Молоко", true},
+		{"?", "", false},
+		{"milk", "", false},
+		{"milk?\nbread?", "", false},
+	}
+	for _, tt := range tests {
+		search, ok := logic.SearchText(tt.msg)
+		require.Equal(t, tt.search, search, tt.msg)
+		require.Equal(t, tt.ok, ok, tt.msg)
+	}
+}
+
+func TestCategorize(t *testing.T) {
+	categories := []*ent.ItemCategory{
+		{ID: 5, Emoji: "🥦"},
+		{ID: 3, Emoji: "🥛"},
+	}
+	items := []*ent.Item{
+		{ID: 1, ProductName: "lamp"},
+		{ID: 2, ProductName: "milk", CategoryID: 3},
+		{ID: 3, ProductName: "apples", Quantity: 2, Unit: "kg", CategoryID: 5},
+		{ID: 4, ProductName: "cheese", CategoryID: 3},
+	}
+
+	categorized := logic.Categorize(items, categories)
+	ids := []int{}
+	for _, v := range categorized.Items {
+		ids = append(ids, v.ID)
+	}
+	require.Equal(t, []int{3, 2, 4, 1}, ids)
+	require.Equal(t, "🥛 ", categorized.Mark(items[1]))
+	require.Equal(t, "", categorized.Mark(items[0]))
+	// items are not changed
+	require.Equal(t, 1, items[0].ID)
+}
@@ -2,31 +2,47 @@ package logic
 
 import (
 	"fmt"
+	"log"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
 type Input struct {
+	// UpdateID is the telegram update id, it is used for logging
+	UpdateID     int
 	CallbackData *string
 	Message      *string
 }
 
 type Output struct {
-	Message            string
-	Keyboard           *tgbotapi.InlineKeyboardMarkup
-	MessageToCommunity *string
-	Image              *tgbotapi.PhotoConfig
+	Message   string
+	Keyboard  *tgbotapi.InlineKeyboardMarkup
+	ParseMode string
+	// Actions are executed after the message, see Plan
+	Actions []Action
 }
 
+// Node renders the page of the bot, nodes are shared by all users and updates
+// of different users are executed concurrently, so the node keeps no session
+// and gets it with every input
 type Node interface {
-	GetCallbackOutput(command string) (Output, error)
-	GetMessageOutput(currentData, msg string) (Output, error)
-	SetSession(sessionItem *session.SessionItem)
+	GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (Output, error)
+	GetMessageOutput(sessionItem *session.SessionItem, currentData callback.Data, msg string) (Output, error)
+}
+
+// Reopener is implemented by nodes which commands may change data,
+// it returns the data which only shows the node
+type Reopener interface {
+	ReopenCommand(currentData callback.Data) callback.Data
 }
 
 type Logic struct {
-	nodes map[string]Node
+	nodes       map[string]Node
+	commands    []Command
+	middlewares []Middleware
 }
 
 func New() *Logic {
@@ -44,20 +60,84 @@ func (l *Logic) GetOutput(
 	i Input,
 	sessionItem *session.SessionItem,
 ) (Output, error) {
-	node, ok := l.nodes[sessionItem.CurrentNode]
+	handler := l.execute
+	// slash commands switch to their own nodes
+	if i.Message != nil {
+		if name, args, ok := ParseCommand(*i.Message); ok {
+			command, ok := l.getCommand(name)
+			if ok {
+				i = command.input(i, args, sessionItem)
+			} else {
+				// help goes through the middlewares like the nodes
+				handler = func(r Request) (Output, error) {
+					return l.help(r.Session.Lang, name), nil
+				}
+			}
+		}
+	}
+
+	return l.chain(handler)(Request{
+		Input:   i,
+		Session: sessionItem,
+		Node:    sessionItem.CurrentNode,
+	})
+}
+
+// execute passes request input to the node
+func (l *Logic) execute(r Request) (Output, error) {
+	node, ok := l.nodes[r.Node]
 	if !ok {
-		return Output{}, fmt.Errorf("node %s not found", sessionItem.CurrentNode)
+		return Output{}, fmt.Errorf("node %s not found", r.Node)
+	}
+	data, err := callback.ParseCommand(r.Node, r.Session.CurrentData)
+	if err != nil {
+		// unknown data, e.g. of the session stored by the previous version
+		log.Printf("parse node=%s data=%q error=%v, start menu is shown\n", r.Node, r.Session.CurrentData, err)
+		return l.start(r.Session)
 	}
-	//set current session for logic
-	node.SetSession(sessionItem)
-	//debug
-	fmt.Printf("IN LOGIC: i.message=%v, i/callbackdata=%v\n", i.Message, i.CallbackData)
-	//
+
 	switch {
-	case i.Message != nil:
-		return node.GetMessageOutput(sessionItem.CurrentData, *i.Message)
-	case i.CallbackData != nil:
-		return node.GetCallbackOutput(sessionItem.CurrentData)
+	case r.Input.Message != nil:
+		return node.GetMessageOutput(r.Session, data, *r.Input.Message)
+	case r.Input.CallbackData != nil:
+		return node.GetCallbackOutput(r.Session, data)
 	}
 	return Output{}, fmt.Errorf("bad input (fields are nills)")
 }
+
+// start shows the start menu and switches the session to it
+func (l *Logic) start(sessionItem *session.SessionItem) (Output, error) {
+	node, ok := l.nodes[consts.FirstpageWord]
+	if !ok {
+		return Output{}, fmt.Errorf("node %s not found", consts.FirstpageWord)
+	}
+	data := callback.New(consts.FirstpageWord, callback.OpStart)
+	currentData := data.Command()
+	sessionItem.UpdateCallbackData(&data.Node, &currentData)
+	return node.GetCallbackOutput(sessionItem, data)
+}
+
+// ReopenData returns callback data which shows the current node of the session again
+func (l *Logic) ReopenData(sessionItem *session.SessionItem) string {
+	node, ok := l.nodes[sessionItem.CurrentNode]
+	if !ok {
+		return consts.FirstPageStart
+	}
+	data, err := callback.ParseCommand(sessionItem.CurrentNode, sessionItem.CurrentData)
+	if err != nil {
+		return consts.FirstPageStart
+	}
+	encoded, err := reopenCommand(node, data).Encode()
+	if err != nil {
+		return consts.FirstPageStart
+	}
+	return encoded
+}
+
+// reopenCommand returns the command which only shows the node
+func reopenCommand(node Node, data callback.Data) callback.Data {
+	if reopener, ok := node.(Reopener); ok {
+		return reopener.ReopenCommand(data)
+	}
+	return data
+}
@@ -0,0 +1,173 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNode returns the called method and its params as output message
+type fakeNode struct {
+	name string
+}
+
+func (f *fakeNode) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	return logic.Output{Message: f.name + " callback " + data.Command()}, nil
+}
+
+func (f *fakeNode) GetMessageOutput(sessionItem *session.SessionItem, currentData callback.Data, msg string) (logic.Output, error) {
+	return logic.Output{Message: f.name + " message " + currentData.Command() + " " + msg}, nil
+}
+
+func newTestLogic() *logic.Logic {
+	return logic.New().
+		AddNode("firstpage", &fakeNode{name: "firstpage"}).
+		AddNode("currentlist", &fakeNode{name: "currentlist"}).
+		AddNode("checklist", &fakeNode{name: "checklist"}).
+		AddCommand(logic.Command{Name: "start", Description: "menu", Node: "firstpage", Operation: "start"}).
+		AddCommand(logic.Command{Name: "list", Description: "list", Node: "currentlist", Operation: "start"}).
+		AddCommand(logic.Command{Name: "add", Description: "add", Node: "currentlist", Operation: "start"})
+}
+
+func TestGetOutputCommands(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		currentNode string
+		message     string
+		expMessage  string
+		expNode     string
+		expData     string
+	}{
+		{
+			name:        "plain message goes to current node",
+			currentNode: "checklist",
+			message:     "milk",
+			expMessage:  "checklist message show:12 milk",
+			expNode:     "checklist",
+			expData:     "show:12",
+		},
+		{
+			name:        "start command",
+			currentNode: "checklist",
+			message:     "/start",
+			expMessage:  "firstpage callback start",
+			expNode:     "firstpage",
+			expData:     "start",
+		},
+		{
+			name:        "command with bot name",
+			currentNode: "firstpage",
+			message:     "/list@shoplist_bot",
+			expMessage:  "currentlist callback start",
+			expNode:     "currentlist",
+			expData:     "start",
+		},
+		{
+			name:        "command with arguments",
+			currentNode: "checklist",
+			message:     "/add молоко 2л",
+			expMessage:  "currentlist message start молоко 2л",
+			expNode:     "currentlist",
+			expData:     "start",
+		},
+		{
+			name:        "help command",
+			currentNode: "checklist",
+			message:     "/help",
+			expMessage:  "Доступные команды:\n/start - menu\n/list - list\n/add - add\n/help - Список команд",
+			expNode:     "checklist",
+			expData:     "show:12",
+		},
+		{
+			name:        "unknown command",
+			currentNode: "checklist",
+			message:     "/unknown",
+			expMessage:  "Неизвестная команда /unknown.\nДоступные команды:\n/start - menu\n/list - list\n/add - add\n/help - Список команд",
+			expNode:     "checklist",
+			expData:     "show:12",
+		},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			sessionItem := &session.SessionItem{
+				CurrentNode: test.currentNode,
+				CurrentData: "show:12",
+			}
+			output, err := newTestLogic().GetOutput(logic.Input{Message: &test.message}, sessionItem)
+			require.NoError(t, err)
+			require.Equal(t, test.expMessage, output.Message)
+			require.Equal(t, test.expNode, sessionItem.CurrentNode)
+			require.Equal(t, test.expData, sessionItem.CurrentData)
+		})
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		msg     string
+		expName string
+		expArgs string
+		expOk   bool
+	}{
+		{msg: "/start", expName: "start", expOk: true},
+		{msg: "/Add  молоко ", expName: "add", expArgs: "молоко", expOk: true},
+		{msg: "/add@bot молоко", expName: "add", expArgs: "молоко", expOk: true},
+		{msg: "/add\nмолоко\nхлеб", expName: "add", expArgs: "молоко\nхлеб", expOk: true},
+		{msg: "/add\tмолоко", expName: "add", expArgs: "молоко", expOk: true},
+		{msg: "/ add", expOk: false},
+		{msg: "молоко", expOk: false},
+		{msg: "/", expOk: false},
+		{msg: "", expOk: false},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.msg, func(t *testing.T) {
+			t.Parallel()
+
+			name, args, ok := logic.ParseCommand(test.msg)
+			require.Equal(t, test.expOk, ok)
+			require.Equal(t, test.expName, name)
+			require.Equal(t, test.expArgs, args)
+		})
+	}
+}
+
+// fakeListNode repeats nothing on reopen
+type fakeListNode struct {
+	fakeNode
+}
+
+func (f *fakeListNode) ReopenCommand(currentData callback.Data) callback.Data {
+	return callback.New(currentData.Node, callback.OpStart)
+}
+
+func TestGetOutputUnknownData(t *testing.T) {
+	// data of the session stored before versioned format shows the start menu
+	sessionItem := &session.SessionItem{CurrentNode: "checklist", CurrentData: "12i45"}
+	message := "milk"
+	output, err := newTestLogic().GetOutput(logic.Input{Message: &message}, sessionItem)
+	require.NoError(t, err)
+	require.Equal(t, "firstpage callback start", output.Message)
+	require.Equal(t, "firstpage", sessionItem.CurrentNode)
+	require.Equal(t, "start", sessionItem.CurrentData)
+}
+
+func TestReopenData(t *testing.T) {
+	l := newTestLogic().AddNode("shoppingitems", &fakeListNode{})
+
+	require.Equal(t, "1:checklist:sel:12:45", l.ReopenData(&session.SessionItem{CurrentNode: "checklist", CurrentData: "sel:12:45"}))
+	require.Equal(t, "1:shoppingitems:start", l.ReopenData(&session.SessionItem{CurrentNode: "shoppingitems", CurrentData: "del:12"}))
+	// data of the session stored before versioned format
+	require.Equal(t, "1:firstpage:start", l.ReopenData(&session.SessionItem{CurrentNode: "checklist", CurrentData: "12i45"}))
+	require.Equal(t, "1:firstpage:start", l.ReopenData(&session.SessionItem{CurrentNode: "unknown"}))
+}
@@ -0,0 +1,156 @@
+package logic
+
+import (
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Request is the node execution request, node is the session node
+// the input is passed to
+type Request struct {
+	Input   Input
+	Session *session.SessionItem
+	Node    string
+}
+
+// Handler executes request
+type Handler func(r Request) (Output, error)
+
+// Middleware wraps node execution, it may stop the request by returning
+// its own output without calling next handler
+type Middleware func(next Handler) Handler
+
+// Use adds middlewares, the first one is the outermost
+func (l *Logic) Use(middlewares ...Middleware) *Logic {
+	l.middlewares = append(l.middlewares, middlewares...)
+	return l
+}
+
+func (l *Logic) chain(handler Handler) Handler {
+	for i := len(l.middlewares) - 1; i >= 0; i-- {
+		handler = l.middlewares[i](handler)
+	}
+	return handler
+}
+
+// Recover turns node panic into the message with menu button
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) (output Output, err error) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.Printf("panic: update_id=%d node=%s data=%q: %v\n%s",
+						r.Input.UpdateID, r.Node, r.Session.CurrentData, rec, debug.Stack())
+					output, err = menuOutput(r.Session.Lang, i18n.T(r.Session.Lang, i18n.InternalError)), nil
+				}
+			}()
+			return next(r)
+		}
+	}
+}
+
+// Logging logs every request with its result and duration
+func Logging() Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) (Output, error) {
+			start := time.Now()
+			output, err := next(r)
+
+			log.Printf("update_id=%d telegram_id=%d node=%s data=%q input=%s duration=%s error=%v\n",
+				r.Input.UpdateID, telegramID(r.Session), r.Node, r.Session.CurrentData,
+				r.Input.kind(), time.Since(start), err)
+			return output, err
+		}
+	}
+}
+
+// Timing passes node execution duration to the observe func, e.g. to metrics
+func Timing(observe func(node string, duration time.Duration)) Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) (Output, error) {
+			start := time.Now()
+			defer func() {
+				observe(r.Node, time.Since(start))
+			}()
+			return next(r)
+		}
+	}
+}
+
+// Policy decides whether the session user can use the node
+type Policy interface {
+	Allowed(node string, sessionItem *session.SessionItem) bool
+}
+
+// PolicyFunc is the func adapter for the Policy
+type PolicyFunc func(node string, sessionItem *session.SessionItem) bool
+
+func (f PolicyFunc) Allowed(node string, sessionItem *session.SessionItem) bool {
+	return f(node, sessionItem)
+}
+
+// CommunityPolicy allows nodes only to the users of the community,
+// other nodes are allowed to everyone
+func CommunityPolicy(community string, nodes ...string) Policy {
+	restricted := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		restricted[node] = struct{}{}
+	}
+
+	return PolicyFunc(func(node string, sessionItem *session.SessionItem) bool {
+		if _, ok := restricted[node]; !ok {
+			return true
+		}
+		return community != "" &&
+			sessionItem.User != nil &&
+			sessionItem.User.ComunityID == community
+	})
+}
+
+// Authorize doesn't run the node if the policy denies access to it
+func Authorize(policy Policy) Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) (Output, error) {
+			if !policy.Allowed(r.Node, r.Session) {
+				log.Printf("ACCESS DENIED: update_id=%d telegram_id=%d node=%s\n",
+					r.Input.UpdateID, telegramID(r.Session), r.Node)
+				return menuOutput(r.Session.Lang, i18n.T(r.Session.Lang, i18n.AccessDenied)), nil
+			}
+			return next(r)
+		}
+	}
+}
+
+func menuOutput(lang i18n.Lang, message string) Output {
+	return Output{
+		Message: message,
+		Keyboard: &tgbotapi.InlineKeyboardMarkup{
+			InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
+				{tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.MenuButton), consts.FirstPageStart)},
+			},
+		},
+	}
+}
+
+func telegramID(sessionItem *session.SessionItem) int64 {
+	if sessionItem.User == nil {
+		return 0
+	}
+	return sessionItem.User.TelegramID
+}
+
+func (i Input) kind() string {
+	switch {
+	case i.Message != nil:
+		return "message"
+	case i.CallbackData != nil:
+		return "callback"
+	}
+	return "empty"
+}
@@ -0,0 +1,119 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
+	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	"github.com/stretchr/testify/require"
+)
+
+// panicNode panics on every input
+type panicNode struct {
+	fakeNode
+}
+
+func (p *panicNode) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	panic("boom")
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) logic.Middleware {
+		return func(next logic.Handler) logic.Handler {
+			return func(r logic.Request) (logic.Output, error) {
+				calls = append(calls, name+" "+r.Node)
+				return next(r)
+			}
+		}
+	}
+
+	l := newTestLogic().Use(trace("first"), trace("second"))
+
+	msg := "/list"
+	output, err := l.GetOutput(logic.Input{Message: &msg}, &session.SessionItem{CurrentNode: "checklist", CurrentData: "start"})
+	require.NoError(t, err)
+	require.Equal(t, "currentlist callback start", output.Message)
+	require.Equal(t, []string{"first currentlist", "second currentlist"}, calls)
+
+	// help of the unknown command goes through the middlewares too
+	calls = nil
+	msg = "/unknown"
+	output, err = l.GetOutput(logic.Input{Message: &msg}, &session.SessionItem{CurrentNode: "checklist", CurrentData: "start"})
+	require.NoError(t, err)
+	require.Contains(t, output.Message, "/unknown")
+	require.Equal(t, []string{"first checklist", "second checklist"}, calls)
+}
+
+func TestRecover(t *testing.T) {
+	l := newTestLogic().
+		AddNode("panic", &panicNode{}).
+		Use(logic.Recover())
+
+	data := "1:panic:start"
+	output, err := l.GetOutput(logic.Input{CallbackData: &data}, &session.SessionItem{CurrentNode: "panic", CurrentData: "start"})
+	require.NoError(t, err)
+	require.NotEmpty(t, output.Message)
+	require.NotNil(t, output.Keyboard)
+}
+
+func TestTiming(t *testing.T) {
+	observed := map[string]int{}
+	l := newTestLogic().Use(logic.Timing(func(node string, duration time.Duration) {
+		observed[node]++
+	}))
+
+	data := "1:checklist:start"
+	_, err := l.GetOutput(logic.Input{CallbackData: &data}, &session.SessionItem{CurrentNode: "checklist", CurrentData: "start"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"checklist": 1}, observed)
+}
+
+func TestAuthorizeCommunityPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		community  string
+		node       string
+		message    string
+		expAllowed bool
+	}{
+		{name: "member", community: "family", node: "checklist", expAllowed: true},
+		{name: "stranger", community: "other", node: "checklist", expAllowed: false},
+		{name: "stranger via command", community: "other", node: "firstpage", message: "/checklist", expAllowed: false},
+		{name: "not restricted node", community: "other", node: "currentlist", expAllowed: true},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newTestLogic().
+				AddCommand(logic.Command{Name: "checklist", Description: "checklist", Node: "checklist", Operation: "start"}).
+				Use(logic.Authorize(logic.CommunityPolicy("family", "checklist")))
+
+			sessionItem := &session.SessionItem{
+				CurrentNode: test.node,
+				CurrentData: "start",
+				User:        &ent.User{ComunityID: test.community},
+			}
+			input := logic.Input{CallbackData: &sessionItem.CurrentData}
+			if test.message != "" {
+				input = logic.Input{Message: &test.message}
+			}
+
+			output, err := l.GetOutput(input, sessionItem)
+			require.NoError(t, err)
+			if test.expAllowed {
+				require.Equal(t, test.node+" callback start", output.Message)
+				return
+			}
+			require.NotContains(t, output.Message, "callback")
+			require.NotNil(t, output.Keyboard)
+		})
+	}
+}
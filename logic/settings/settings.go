@@ -4,12 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
-	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/user"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/outbox"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 	"github.com/dchest/uniuri"
 	"github.com/spf13/viper"
@@ -18,85 +23,85 @@ import (
 )
 
 const (
-	backBtnText          = "Назад"
-	versionText          = "Версия бота: \n"
-	IDUserText           = "ID текущего пользователя"
-	NoComunityText       = "Вы не состоите в группе, для вступления в группу, введите ID участника"
-	InYourGroupText      = "В вашей группе"
-	YouCanText           = "вы можете создавать общие списки покупок"
-	LeaveSuccessText     = "<Вы вышли из группы>"
-	AlreadyInGroupText   = "<Вы уже состоите в группе>"
-	InvalidUserIDText    = "<ID участника должен состоять из цифр>"
-	UserNotFound         = "<Участник не найден. Проверьте корректность ID>"
-	JoinGroupSuccessText = "<Вы вступили в группу>"
-
-	leaveComunityText = "Покинуть группу"
-	LeaveCommand      = "leave"
+	LeaveCommand    = "leave"
+	LanguageCommand = "lang"
+	NotifyCommand   = "notify"
+
+	checkMark = "✓ "
 )
 
 var (
 	ErrBadComunityUsersCount = errors.New("comunity users not found")
+
+	// digestMinutes are the digest intervals to choose
+	digestMinutes = []int{30, 60, 180}
+	// digestTimeRegexp matches the message with the daily digest time and
+	// optional time zone, e.g. "20:00 Europe/Moscow"
+	digestTimeRegexp = regexp.MustCompile(`^(\d{1,2}:\d{2})(?:\s+(\S+))?$`)
 )
 
-type settings struct {
-	sessionItem *session.SessionItem
-}
+type settings struct{}
 
 func New() *settings {
 	return &settings{}
 }
 
-func (s *settings) SetSession(sessionItem *session.SessionItem) {
-	s.sessionItem = sessionItem
-}
-
-func (s *settings) getStartPage(withMessage string) (logic.Output, error) {
+func (s *settings) getStartPage(sessionItem *session.SessionItem, withMessage i18n.Key) (logic.Output, error) {
+	lang := sessionItem.Lang
 	//debug
-	log.Printf("curItem: sAPI=%v, userID=%v, communityID=%v", s.sessionItem.SListAPI, s.sessionItem.User.ID, s.sessionItem.User.ComunityID)
+	log.Printf("curItem: sAPI=%v, userID=%v, communityID=%v", sessionItem.SListAPI, sessionItem.User.ID, sessionItem.User.ComunityID)
 	//
-	comunityUsers, err := s.sessionItem.SListAPI.GetUsersByComunityID(s.sessionItem.User.ComunityID)
+	comunityUsers, err := sessionItem.SListAPI.GetUsersByComunityID(sessionItem.User.ComunityID)
 	if err != nil {
 		return logic.Output{}, err
 	}
 	comunityUsersCount := len(comunityUsers)
 
 	buttonsRow := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backBtnText, consts.FirstPageStart), //back Btn
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BackButton), consts.FirstPageStart), //back Btn
 	}
 
 	version := viper.GetString("SHOPLIST-BOT_SERVICE_VERSION")
-	message := fmt.Sprintf("%s%s: \"%v\".", versionText+version, IDUserText, s.sessionItem.User.TelegramID)
+	message := i18n.T(lang, i18n.SettingsInfo, version, sessionItem.User.TelegramID)
 	switch {
 	case comunityUsersCount > 1:
 		users := []string{}
 		for _, v := range comunityUsers {
-			userName := "no username"
+			userName := i18n.T(lang, i18n.NoUsername)
 			if v.TelegramUsername != "" {
 				userName = v.TelegramUsername
 			}
 			user := fmt.Sprintf("ID=%v(%s)", v.TelegramID, userName)
 			users = append(users, user)
 		}
-		message += fmt.Sprintf(
-			"\n%s: %s, %s",
-			InYourGroupText,
-			strings.Join(users, ", "),
-			YouCanText,
-		)
-		leaveParam := helpers.GetParam(consts.SettingsWord, LeaveCommand)
-		leaveBtn := tgbotapi.NewInlineKeyboardButtonData(leaveComunityText, leaveParam) // leaveComunity btn
+		message += "\n" + i18n.T(lang, i18n.InYourGroup, strings.Join(users, ", "))
+		leaveParam := callback.New(consts.SettingsWord, LeaveCommand)
+		leaveBtn := callback.Button(i18n.T(lang, i18n.LeaveGroupButton), leaveParam) // leaveComunity btn
 		buttonsRow = append(buttonsRow, leaveBtn)
 
 	case comunityUsersCount == 1:
-		message += "\n" + NoComunityText
+		message += "\n" + i18n.T(lang, i18n.NoCommunity)
 	default:
 		return logic.Output{}, ErrBadComunityUsersCount
 	}
 
-	message = withMessage + "\n" + message
+	message += "\n" + i18n.T(lang, i18n.NotifyInfo, s.notifyText(sessionItem))
+
+	prefix := ""
+	if withMessage != "" {
+		prefix = i18n.T(lang, withMessage)
+	}
+	message = prefix + "\n" + message
+
+	rows := [][]tgbotapi.InlineKeyboardButton{buttonsRow, s.notifyButtons(sessionItem)}
+	if sessionItem.User.Notify == user.NotifyDigest {
+		rows = append(rows, s.digestButtons(sessionItem))
+		message += "\n" + i18n.T(lang, i18n.DigestTimeHint)
+	}
+	rows = append(rows, s.languageButtons(sessionItem))
 
 	keyboard := &tgbotapi.InlineKeyboardMarkup{
-		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{buttonsRow},
+		InlineKeyboard: rows,
 	}
 	return logic.Output{
 		Message:  message,
@@ -104,13 +109,13 @@ func (s *settings) getStartPage(withMessage string) (logic.Output, error) {
 	}, nil
 }
 
-func (s *settings) GetCallbackOutput(command string) (logic.Output, error) {
-	switch command {
+func (s *settings) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	switch data.Op {
 	case LeaveCommand:
 		// leave comunity handler
 		newComunityID := uniuri.New()
-		err := s.sessionItem.SListAPI.UpdateUser(
-			s.sessionItem.User.ID,
+		err := sessionItem.SListAPI.UpdateUser(
+			sessionItem.User.ID,
 			&newComunityID,
 			nil,
 		)
@@ -118,38 +123,183 @@ func (s *settings) GetCallbackOutput(command string) (logic.Output, error) {
 			return logic.Output{}, err
 		}
 		// update in session
-		s.sessionItem.User.ComunityID = newComunityID
-		return s.getStartPage(LeaveSuccessText)
+		sessionItem.User.ComunityID = newComunityID
+		return s.getStartPage(sessionItem, i18n.LeaveSuccess)
+	case LanguageCommand:
+		if len(data.Args) == 0 {
+			return logic.Output{}, fmt.Errorf("language is missing: %w", callback.ErrInvalid)
+		}
+		lang, ok := i18n.Parse(data.Args[0])
+		if !ok {
+			return logic.Output{}, fmt.Errorf("unknown language %q: %w", data.Args[0], callback.ErrInvalid)
+		}
+		err := sessionItem.SListAPI.UpdateUserLanguage(sessionItem.User.ID, string(lang))
+		if err != nil {
+			return logic.Output{}, err
+		}
+		// update in session
+		sessionItem.User.Language = string(lang)
+		sessionItem.Lang = lang
+		return s.getStartPage(sessionItem, i18n.LanguageChanged)
+	case NotifyCommand:
+		if len(data.Args) == 0 {
+			return logic.Output{}, fmt.Errorf("notify mode is missing: %w", callback.ErrInvalid)
+		}
+		mode := user.Notify(data.Args[0])
+		if err := user.NotifyValidator(mode); err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", err, callback.ErrInvalid)
+		}
+		minutes := sessionItem.User.DigestMinutes
+		at := sessionItem.User.DigestTime
+		if len(data.Args) > 1 {
+			var err error
+			minutes, err = data.IntArg(1)
+			if err != nil || minutes <= 0 {
+				return logic.Output{}, fmt.Errorf("digest minutes %q: %w", data.Args[1], callback.ErrInvalid)
+			}
+			// interval replaces the daily time
+			at = ""
+		}
+		return s.updateNotify(sessionItem, mode, minutes, at, sessionItem.User.DigestZone)
+	}
+	return s.getStartPage(sessionItem, "")
+}
+
+func (s *settings) updateNotify(sessionItem *session.SessionItem, mode user.Notify, minutes int, at, zone string) (logic.Output, error) {
+	err := sessionItem.SListAPI.UpdateUserNotify(sessionItem.User.ID, mode, minutes, at, zone)
+	if err != nil {
+		return logic.Output{}, err
 	}
-	return s.getStartPage("")
+	// update in session
+	sessionItem.User.Notify = mode
+	sessionItem.User.DigestMinutes = minutes
+	sessionItem.User.DigestTime = at
+	sessionItem.User.DigestZone = zone
+	return s.getStartPage(sessionItem, i18n.NotifyChanged)
 }
 
-func (s *settings) GetMessageOutput(curData string, msg string) (logic.Output, error) {
-	comunityUsers, err := s.sessionItem.SListAPI.GetUsersByComunityID(s.sessionItem.User.ComunityID)
+// notifyText describes the current notification mode
+func (s *settings) notifyText(sessionItem *session.SessionItem) string {
+	lang := sessionItem.Lang
+	switch sessionItem.User.Notify {
+	case user.NotifyOff:
+		return i18n.T(lang, i18n.NotifyOff)
+	case user.NotifyDigest:
+		if sessionItem.User.DigestTime != "" {
+			at := sessionItem.User.DigestTime
+			if sessionItem.User.DigestZone != "" {
+				at += " " + sessionItem.User.DigestZone
+			}
+			return i18n.T(lang, i18n.NotifyDigestAt, at)
+		}
+		return i18n.T(lang, i18n.NotifyDigestEvery, s.digestMinutes(sessionItem))
+	}
+	return i18n.T(lang, i18n.NotifyImmediate)
+}
+
+// notifyButtons returns buttons of the notification modes, the current one is checked
+func (s *settings) notifyButtons(sessionItem *session.SessionItem) []tgbotapi.InlineKeyboardButton {
+	modes := []struct {
+		mode user.Notify
+		text i18n.Key
+	}{
+		{user.NotifyImmediate, i18n.NotifyImmediateButton},
+		{user.NotifyDigest, i18n.NotifyDigestButton},
+		{user.NotifyOff, i18n.NotifyOffButton},
+	}
+	buttons := []tgbotapi.InlineKeyboardButton{}
+	for _, m := range modes {
+		text := i18n.T(sessionItem.Lang, m.text)
+		if m.mode == sessionItem.User.Notify {
+			text = checkMark + text
+		}
+		buttons = append(buttons, callback.Button(text, callback.New(consts.SettingsWord, NotifyCommand, m.mode.String())))
+	}
+	return buttons
+}
+
+// digestButtons returns buttons of the digest intervals, the current one is checked
+func (s *settings) digestButtons(sessionItem *session.SessionItem) []tgbotapi.InlineKeyboardButton {
+	buttons := []tgbotapi.InlineKeyboardButton{}
+	for _, minutes := range digestMinutes {
+		text := i18n.T(sessionItem.Lang, i18n.DigestMinutesButton, minutes)
+		if sessionItem.User.DigestTime == "" && minutes == s.digestMinutes(sessionItem) {
+			text = checkMark + text
+		}
+		param := callback.New(consts.SettingsWord, NotifyCommand, user.NotifyDigest.String(), strconv.Itoa(minutes))
+		buttons = append(buttons, callback.Button(text, param))
+	}
+	return buttons
+}
+
+func (s *settings) digestMinutes(sessionItem *session.SessionItem) int {
+	if sessionItem.User.DigestMinutes <= 0 {
+		return outbox.DefaultDigestMinutes
+	}
+	return sessionItem.User.DigestMinutes
+}
+
+// languageButtons returns buttons of the languages except the current one
+func (s *settings) languageButtons(sessionItem *session.SessionItem) []tgbotapi.InlineKeyboardButton {
+	buttons := []tgbotapi.InlineKeyboardButton{}
+	for _, lang := range i18n.Langs {
+		if lang == sessionItem.Lang {
+			continue
+		}
+		text := i18n.T(sessionItem.Lang, i18n.LanguageButton, i18n.T(lang, i18n.LanguageName))
+		buttons = append(buttons, callback.Button(text, callback.New(consts.SettingsWord, LanguageCommand, string(lang))))
+	}
+	return buttons
+}
+
+// ReopenCommand shows the settings page without leaving the group again
+func (s *settings) ReopenCommand(currentData callback.Data) callback.Data {
+	return callback.New(consts.SettingsWord, callback.OpStart)
+}
+
+func (s *settings) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
+	// time of the daily digest
+	if m := digestTimeRegexp.FindStringSubmatch(msg); m != nil {
+		at, err := time.Parse(outbox.DigestTimeLayout, m[1])
+		if err != nil {
+			return s.getStartPage(sessionItem, i18n.InvalidDigestTime)
+		}
+		// the zone is kept until the other one is entered
+		zone := sessionItem.User.DigestZone
+		if m[2] != "" {
+			if _, err := time.LoadLocation(m[2]); err != nil {
+				return s.getStartPage(sessionItem, i18n.InvalidDigestZone)
+			}
+			zone = m[2]
+		}
+		return s.updateNotify(sessionItem, user.NotifyDigest, s.digestMinutes(sessionItem), at.Format(outbox.DigestTimeLayout), zone)
+	}
+
+	comunityUsers, err := sessionItem.SListAPI.GetUsersByComunityID(sessionItem.User.ComunityID)
 	if err != nil {
 		return logic.Output{}, err
 	}
 	comunityUsersCount := len(comunityUsers)
 	if comunityUsersCount > 1 {
-		return s.getStartPage(AlreadyInGroupText)
+		return s.getStartPage(sessionItem, i18n.AlreadyInGroup)
 	}
 
 	intUserID, err := strconv.Atoi(msg)
 	if err != nil {
-		return s.getStartPage(InvalidUserIDText)
+		return s.getStartPage(sessionItem, i18n.InvalidUserID)
 	}
 
-	groupOwner, err := s.sessionItem.SListAPI.GetUserByTelegramID(intUserID)
+	groupOwner, err := sessionItem.SListAPI.GetUserByTelegramID(intUserID)
 	switch {
-	case err == consts.ErrNotFound:
-		return s.getStartPage(UserNotFound)
+	case errors.Is(err, consts.ErrNotFound):
+		return s.getStartPage(sessionItem, i18n.UserNotFound)
 	case err != nil:
 		return logic.Output{}, err
 	}
 
 	// update user comunityID
-	err = s.sessionItem.SListAPI.UpdateUser(
-		s.sessionItem.User.ID,
+	err = sessionItem.SListAPI.UpdateUser(
+		sessionItem.User.ID,
 		&groupOwner.ComunityID,
 		nil,
 	)
@@ -157,6 +307,6 @@ func (s *settings) GetMessageOutput(curData string, msg string) (logic.Output, e
 		return logic.Output{}, err
 	}
 	// update in session
-	s.sessionItem.User.ComunityID = groupOwner.ComunityID
-	return s.getStartPage(JoinGroupSuccessText)
+	sessionItem.User.ComunityID = groupOwner.ComunityID
+	return s.getStartPage(sessionItem, i18n.JoinGroupSuccess)
 }
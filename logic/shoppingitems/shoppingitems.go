@@ -1,316 +1,421 @@
 package shoppingitems
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/Frosin/shoplist-telegram-bot/callback"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
 	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/quantity"
 	"github.com/Frosin/shoplist-telegram-bot/session"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
 const (
-	backSumbol          = "⬅ Вернуться к "
-	inputMsg            = "Введите товар для добавления"
-	addFromCurrentMsg   = "↑ из текущего"
-	addFromChecklistMsg = "↑ из чек-листа"
-	removeMsg           = "⊗ выбранные"
-	emptyItems          = "Список товаров пока что пуст. Для добавления введите название товара."
+	// completedMark is shown before the bought item name
+	completedMark = "✅ "
+
+	// arguments of the complete operation
+	completeArg   = "1"
+	incompleteArg = "0"
 )
 
-type shoppingItems struct {
-	sessionItem *session.SessionItem
-}
+type shoppingItems struct{}
 
 func New() *shoppingItems {
 	return &shoppingItems{}
 }
 
-func (s *shoppingItems) SetSession(sessionItem *session.SessionItem) {
-	s.sessionItem = sessionItem
-}
-
-func (s *shoppingItems) GetCallbackOutput(command string) (logic.Output, error) {
-	var err error
-
-	shoppingID, err := strconv.Atoi(command)
-	// if first show
-	if err == nil {
-		parseRes := &helpers.ParseResult{
-			ShoppingID: shoppingID,
-		}
-
-		return s.getOutput(parseRes, nil)
-	}
-
-	// parse command
-	parseResult, err := helpers.ParseCommand(command)
+func (s *shoppingItems) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	shoppingID, err := data.IntArg(0)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
 	}
 
-	// item button is pressed add itemID to session data storage
-	if parseResult.SelectItem != nil {
-		selectedItems := s.sessionItem.GetDataAsArray()
-		if helpers.IsInArray(*parseResult.SelectItem, selectedItems) {
-			// unselect item
-			s.sessionItem.DeleteValueInArray(*parseResult.SelectItem)
+	state := sessionItem.State(consts.ShoppingitemsWord)
+
+	switch data.Op {
+	case callback.OpShow:
+		// search of the products is dropped on opening of the shopping
+		state.Search = ""
+	case callback.OpComplete:
+		// item button is pressed while shopping, it is marked as bought for everyone
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		err = sessionItem.SListAPI.SetItemComplete(itemID, data.Arg(2) == completeArg)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+	case callback.OpClearCompleted:
+		err = sessionItem.SListAPI.RemoveCompletedItems(shoppingID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+	case callback.OpHideCompleted:
+		state.HideCompleted = !state.HideCompleted
+	case callback.OpSelectMode:
+		state.Selecting = !state.Selecting
+		state.ClearSelected()
+	case callback.OpSelect:
+		// item button is pressed in selection mode add itemID to node state or unselect it
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		state.ToggleSelected(itemID)
+	case callback.OpCategory:
+		// category picker of the selected item
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		item, err := sessionItem.SListAPI.GetItem(itemID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		categories, err := sessionItem.SListAPI.GetItemCategories()
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		return logic.CategoryPicker(sessionItem.Lang, consts.ShoppingitemsWord, shoppingID, item, categories), nil
+	case callback.OpSetCategory:
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		categoryID, err := data.IntArg(2)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		err = sessionItem.SListAPI.SetItemCategory(itemID, categoryID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+	case callback.OpQuickAdd:
+		// suggested product is added
+		productID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		err = sessionItem.SListAPI.AddProduct(shoppingID, productID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		state.Search = ""
+	case callback.OpIncrease, callback.OpDecrease:
+		// quantity of the selected item is changed
+		itemID, err := data.IntArg(1)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+		err = sessionItem.SListAPI.ChangeItemQuantity(itemID, logic.QuantityDelta(data.Op))
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
+	case callback.OpDelete:
+		//remove items and get output
+		err = sessionItem.SListAPI.RemoveItems(state.Selected)
+		if err != nil {
+			return logic.Output{}, err
+		}
+		// delete items
+		state.ClearSelected()
+		state.Selecting = false
+		return s.getOutput(sessionItem, shoppingID)
+	case callback.OpAddFromCurrent:
+		//get currentlist shoppingID
+		currentlistShoppingID, err := sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCurrentList)
+		switch {
+		case errors.Is(err, consts.ErrNotFound):
+			// no items in checklist shopping
+			return s.getOutput(sessionItem, shoppingID)
+		case err != nil:
+			return logic.Output{}, err
+		}
+		// get currentlist items
+		currentlistItems, err := sessionItem.SListAPI.GetShoppingItems(currentlistShoppingID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
 		}
-		s.sessionItem.AddIntDataToArray(*parseResult.SelectItem)
-	}
-
-	if parseResult.StartOperation {
-		switch parseResult.OperType {
-		case consts.TypeOperationDelete:
-			//remove items and get output
-			selectItems := s.sessionItem.GetDataAsArray()
-			err = s.sessionItem.SListAPI.RemoveItems(selectItems)
-			if err != nil {
-				return logic.Output{}, err
-			}
-			// delete items
-			s.sessionItem.ClearDataArray()
-			return s.getOutput(parseResult, nil)
-		case consts.TypeOperationAddFromCurrent:
-			items, err := s.sessionItem.SListAPI.GetShoppingItems(parseResult.ShoppingID)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-			}
-			//get currentlist shoppingID
-			currentlistShoppingID, err := s.sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCurrentList)
-			switch {
-			case err == consts.ErrNotFound:
-				// no items in checklist shopping
-				return s.getOutput(parseResult, nil)
-			case err != nil:
-				return logic.Output{}, err
-			}
-			// get currentlist items
-			currentlistItems, err := s.sessionItem.SListAPI.GetShoppingItems(currentlistShoppingID)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
-			}
-
-			//add current list items to shopping with duplicate check
-			alreadyExist := func(itemName string) bool {
-				for _, shoppingItem := range items {
-					if itemName == shoppingItem.ProductName {
-						return true
-					}
-				}
-				return false
-			}
-
-			currentItemsIDs := []int{}
-			for _, currentlistItem := range currentlistItems {
-				if alreadyExist(currentlistItem.ProductName) {
-					continue
-				}
-
-				currentItemsIDs = append(currentItemsIDs, currentlistItem.ID)
-
-				err = s.sessionItem.SListAPI.AddItem(parseResult.ShoppingID, currentlistItem.ProductName)
-				if err != nil {
-					return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
-				}
-			}
-
-			// remove current list items after copying
-			err = s.sessionItem.SListAPI.RemoveItems(currentItemsIDs)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
-			}
-
-			// show
-			return s.getOutput(parseResult, nil)
-		case consts.TypeOperationAddFromChecklist:
-			items, err := s.sessionItem.SListAPI.GetShoppingItems(parseResult.ShoppingID)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ChecklistWord, err)
-			}
-			//get checklist shoppingID
-			checklistShoppingID, err := s.sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCheckList)
-			switch {
-			case err == consts.ErrNotFound:
-				// no items in checklist shopping
-				return s.getOutput(parseResult, nil)
-			case err != nil:
-				return logic.Output{}, err
-			}
-			// get currentlist items
-			checklistItems, err := s.sessionItem.SListAPI.GetShoppingItems(checklistShoppingID)
-			if err != nil {
-				return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
-			}
-
-			//add current list items to shopping with duplicate check
-			alreadyExist := func(itemName string) bool {
-				for _, shoppingItem := range items {
-					if itemName == shoppingItem.ProductName {
-						return true
-					}
-				}
-				return false
-			}
-
-			checklistItemsIDs := []int{}
-			for _, checklistItem := range checklistItems {
-				if alreadyExist(checklistItem.ProductName) {
-					continue
-				}
 
-				checklistItemsIDs = append(checklistItemsIDs, checklistItem.ID)
+		//move current list items to shopping, quantities of duplicates are summed
+		currentItemsIDs := []int{}
+		for _, currentlistItem := range currentlistItems {
+			currentItemsIDs = append(currentItemsIDs, currentlistItem.ID)
+		}
+		_, err = sessionItem.SListAPI.MoveItems(currentlistShoppingID, shoppingID, currentItemsIDs)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
 
-				err = s.sessionItem.SListAPI.AddItem(parseResult.ShoppingID, checklistItem.ProductName)
-				if err != nil {
-					return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
-				}
-			}
+		// show
+		return s.getOutput(sessionItem, shoppingID)
+	case callback.OpAddFromChecklist:
+		//get checklist shoppingID
+		checklistShoppingID, err := sessionItem.SListAPI.GetSpecialShopping(consts.ShoppingTypeCheckList)
+		switch {
+		case errors.Is(err, consts.ErrNotFound):
+			// no items in checklist shopping
+			return s.getOutput(sessionItem, shoppingID)
+		case err != nil:
+			return logic.Output{}, err
+		}
+		// get currentlist items
+		checklistItems, err := sessionItem.SListAPI.GetShoppingItems(checklistShoppingID)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+		}
 
-			// show
-			return s.getOutput(parseResult, nil)
+		//add checklist items to shopping, quantities of duplicates are summed
+		checklistItemsIDs := []int{}
+		for _, checklistItem := range checklistItems {
+			checklistItemsIDs = append(checklistItemsIDs, checklistItem.ID)
+		}
+		_, err = sessionItem.SListAPI.CopyItems(checklistShoppingID, shoppingID, checklistItemsIDs)
+		if err != nil {
+			return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
 		}
+
+		// show
+		return s.getOutput(sessionItem, shoppingID)
 	}
 
-	return s.getOutput(parseResult, nil)
+	return s.getOutput(sessionItem, shoppingID)
 }
 
-func (s *shoppingItems) GetMessageOutput(curData string, msg string) (logic.Output, error) {
-	var err error
-	result, err := helpers.ParseCommand(curData)
+// ReopenCommand shows the shopping without repeating the last operation
+func (s *shoppingItems) ReopenCommand(currentData callback.Data) callback.Data {
+	return callback.New(consts.ShoppingitemsWord, callback.OpShow, currentData.Arg(0))
+}
+
+func (s *shoppingItems) GetMessageOutput(sessionItem *session.SessionItem, curData callback.Data, msg string) (logic.Output, error) {
+	shoppingID, err := curData.IntArg(0)
 	if err != nil {
-		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
 	}
-	//debug
-	fmt.Println("\ncurData=", curData)
-	fmt.Println("\nmsg=", msg)
-	fmt.Println("\nresult=", result)
-	//
-	err = s.sessionItem.SListAPI.AddItem(result.ShoppingID, msg)
+
+	if search, ok := logic.SearchText(msg); ok {
+		// the message searches the products to add
+		sessionItem.State(consts.ShoppingitemsWord).Search = search
+		return s.getOutput(sessionItem, shoppingID)
+	}
+	sessionItem.State(consts.ShoppingitemsWord).Search = ""
+
+	// every line or comma separated entry of the message is the item
+	_, err = sessionItem.SListAPI.AddItems(shoppingID, logic.SplitItems(msg))
 	if err != nil {
-		return logic.Output{}, fmt.Errorf("%v: %w", consts.CurrentlistWord, err)
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
 	}
 
-	return s.getOutput(result, &msg)
+	return s.getOutput(sessionItem, shoppingID)
 }
 
-func (s *shoppingItems) getOutput(parseObject *helpers.ParseResult, addedItemName *string) (logic.Output, error) {
-	shoppingIDStr := strconv.Itoa(parseObject.ShoppingID)
-	selectedItems := s.sessionItem.GetDataAsArray()
+func (s *shoppingItems) getOutput(sessionItem *session.SessionItem, shoppingID int) (logic.Output, error) {
+	shoppingIDStr := strconv.Itoa(shoppingID)
+	state := sessionItem.State(consts.ShoppingitemsWord)
+	lang := sessionItem.Lang
 
-	shoppingData, err := s.sessionItem.SListAPI.GetShopping(parseObject.ShoppingID)
+	shoppingData, err := sessionItem.SListAPI.GetShopping(shoppingID)
 	if err != nil {
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
 	}
+	// message is refreshed on changes of other members
+	sessionItem.ShoppingID = shoppingID
 
-	backBtnParam := helpers.GetParam(
+	backBtnParam := callback.New(
 		consts.DayshoppingsWord,
-		"d"+shoppingData.Date.Format("2006-01-02"),
+		callback.OpShow,
+		helpers.Time2DayCode(shoppingData.Date),
 	)
 
 	//create keyboard and add back button to keyboard
 	controlButtons := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(backSumbol, backBtnParam),
+		callback.Button(i18n.T(lang, i18n.BackToButton), backBtnParam),
 	}
 
-	items, err := s.sessionItem.SListAPI.GetShoppingItems(parseObject.ShoppingID)
+	// suggested products of the catalog
+	products, err := sessionItem.SListAPI.SuggestProducts(shoppingID, state.Search, logic.SuggestionsLimit)
+	if err != nil {
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+	}
+	quickAddButtons, searchNote := logic.QuickAddButtons(lang, consts.ShoppingitemsWord, shoppingID, state.Search, products)
+
+	items, err := sessionItem.SListAPI.GetShoppingItems(shoppingID)
 	if err != nil {
 		//if get empty items list
-		// heroku's go not understand errors.Is
-		if err == consts.ErrNotFound {
+		if errors.Is(err, consts.ErrNotFound) {
+			message := i18n.T(lang, i18n.ItemsEmpty)
+			if searchNote != "" {
+				message = searchNote
+			}
 			return logic.Output{
-				Message: emptyItems,
+				Message: message,
 				Keyboard: &tgbotapi.InlineKeyboardMarkup{
-					InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
-						controlButtons,
-					},
+					InlineKeyboard: append(quickAddButtons, controlButtons),
 				},
 			}, nil
 		}
 		return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
 	}
 
+	categories, err := sessionItem.SListAPI.GetItemCategories()
+	if err != nil {
+		return logic.Output{}, fmt.Errorf("%v: %w", consts.ShoppingitemsWord, err)
+	}
+	// items are grouped by categories in the aisle order
+	categorized := logic.Categorize(items, categories)
+
 	column := [][]tgbotapi.InlineKeyboardButton{}
 
 	// create items list to show
-	for i, data := range items {
-		itemIDStr := strconv.Itoa(data.ID)
-		itemName := data.ProductName
-		// strikethrough item name
-		if helpers.IsInArray(data.ID, selectedItems) {
-			itemName = helpers.GetStrikeThroughText(itemName)
+	completed := 0
+	for _, data := range categorized.Items {
+		if data.Complete {
+			completed++
+			if state.HideCompleted && !state.Selecting {
+				continue
+			}
 		}
+		itemIDStr := strconv.Itoa(data.ID)
+		itemName := quantity.Format(data.ProductName, data.Quantity, data.Unit)
 
-		itemData := consts.ListItemSymbol + itemIDStr
-
-		//make item buttom param with shopping id, removed item ids + id curItem to remove
-		param := helpers.GetParam(
+		//make item button param with shopping id and item id to mark it as bought or not
+		complete := completeArg
+		if data.Complete {
+			complete = incompleteArg
+		}
+		param := callback.New(
 			consts.ShoppingitemsWord,
+			callback.OpComplete,
 			shoppingIDStr,
-			itemData,
+			itemIDStr,
+			complete,
 		)
+		if state.Selecting {
+			// underline selected item name
+			if state.IsSelected(data.ID) {
+				itemName = helpers.GetUnderlinedText(itemName)
+			}
+			param = callback.New(
+				consts.ShoppingitemsWord,
+				callback.OpSelect,
+				shoppingIDStr,
+				itemIDStr,
+			)
+		}
+		// bought mark replaces the category one
+		if data.Complete {
+			itemName = completedMark + itemName
+		} else {
+			itemName = categorized.Mark(data) + itemName
+		}
+
 		row := []tgbotapi.InlineKeyboardButton{
-			tgbotapi.NewInlineKeyboardButtonData(strconv.Itoa(i+1)+". "+itemName, param),
+			callback.Button(strconv.Itoa(len(column)+1)+". "+itemName, param),
 		}
 		column = append(column, row)
 	}
 
-	// add remove and copy buttons to keyboard
-	if len(selectedItems) > 0 {
+	// quantity buttons of the only selected item
+	if state.Selecting && len(state.Selected) == 1 {
+		for _, data := range items {
+			if data.ID == state.Selected[0] {
+				column = append(column, logic.ItemButtons(consts.ShoppingitemsWord, shoppingID, data))
+			}
+		}
+	}
+
+	// add remove button to keyboard
+	if state.Selecting && len(state.Selected) > 0 {
 		// remove button
-		removeButton := tgbotapi.NewInlineKeyboardButtonData(removeMsg,
-			helpers.GetParam(
+		removeButton := callback.Button(i18n.T(lang, i18n.RemoveSelectedButton),
+			callback.New(
 				consts.ShoppingitemsWord,
+				callback.OpDelete,
 				shoppingIDStr,
-				consts.ListStartRemoveSymbol,
 			))
 
 		controlButtons = append(controlButtons, removeButton)
 	}
 
 	// add from current list button
-	addFromCurrentButton := tgbotapi.NewInlineKeyboardButtonData(addFromCurrentMsg,
-		helpers.GetParam(
+	addFromCurrentButton := callback.Button(i18n.T(lang, i18n.AddFromCurrentButton),
+		callback.New(
 			consts.ShoppingitemsWord,
+			callback.OpAddFromCurrent,
 			shoppingIDStr,
-			consts.ListStartAddFromCurrentList,
 		))
 	controlButtons = append(controlButtons, addFromCurrentButton)
 
 	// add from checklist button
-	addFromChecklistButton := tgbotapi.NewInlineKeyboardButtonData(addFromChecklistMsg,
-		helpers.GetParam(
+	addFromChecklistButton := callback.Button(i18n.T(lang, i18n.AddFromChecklistButton),
+		callback.New(
 			consts.ShoppingitemsWord,
+			callback.OpAddFromChecklist,
 			shoppingIDStr,
-			consts.ListStartAddFromChecklist,
 		))
 	controlButtons = append(controlButtons, addFromChecklistButton)
 
+	// selection mode and bought items buttons
+	selectModeText := i18n.T(lang, i18n.SelectModeButton)
+	if state.Selecting {
+		selectModeText = i18n.T(lang, i18n.CancelSelectButton)
+	}
+	modeButtons := []tgbotapi.InlineKeyboardButton{
+		callback.Button(selectModeText,
+			callback.New(
+				consts.ShoppingitemsWord,
+				callback.OpSelectMode,
+				shoppingIDStr,
+			)),
+	}
+	if completed > 0 && !state.Selecting {
+		hideText := i18n.T(lang, i18n.HideCompletedButton)
+		if state.HideCompleted {
+			hideText = i18n.T(lang, i18n.ShowCompletedButton, completed)
+		}
+		modeButtons = append(modeButtons,
+			callback.Button(hideText,
+				callback.New(
+					consts.ShoppingitemsWord,
+					callback.OpHideCompleted,
+					shoppingIDStr,
+				)),
+			callback.Button(i18n.T(lang, i18n.ClearCompletedButton),
+				callback.New(
+					consts.ShoppingitemsWord,
+					callback.OpClearCompleted,
+					shoppingIDStr,
+				)),
+		)
+	}
+
+	// suggestions are hidden while bought items are selected
+	if !state.Selecting {
+		column = append(column, quickAddButtons...)
+	}
 	//final keyboard
-	column = append(column, controlButtons)
+	column = append(column, controlButtons, modeButtons)
 	keyboard := &tgbotapi.InlineKeyboardMarkup{
 		InlineKeyboard: column,
 	}
 
-	output := logic.Output{
-		Message:  inputMsg,
-		Keyboard: keyboard,
-	}
-
-	if addedItemName != nil {
-		msg := fmt.Sprintf(
-			"Пользователь %s(%v) добавил '%s' в '%s'(%s)",
-			s.sessionItem.User.TelegramUsername,
-			s.sessionItem.User.TelegramID,
-			*addedItemName,
-			shoppingData.Edges.Shop.Name,
-			shoppingData.Date,
-		)
-		output.MessageToCommunity = &msg
+	message := i18n.T(lang, i18n.ItemsInput)
+	switch {
+	case state.Selecting:
+		message = i18n.T(lang, i18n.SelectItemsInput)
+	case searchNote != "":
+		message = searchNote
 	}
-
-	return output, nil
+	return logic.Output{
+		Message:  message,
+		Keyboard: keyboard,
+	}, nil
 }
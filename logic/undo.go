@@ -0,0 +1,98 @@
+package logic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Undoer reverts the last change of the session user, kind is the kind of
+// the changes the node undoes, e.g. list or budget ones
+type Undoer interface {
+	CanUndo(sessionItem *session.SessionItem, kind string) (bool, error)
+	// Undo returns false if there is nothing to undo
+	Undo(sessionItem *session.SessionItem, kind string) (bool, error)
+}
+
+// Undo adds the undo button to the nodes of kinds (index by node name) while
+// the user has the change to undo. The button keeps the command which shows
+// the node, so the node is shown again after the change is undone.
+func (l *Logic) Undo(undoer Undoer, kinds map[string]string) Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) (Output, error) {
+			kind, ok := kinds[r.Node]
+			if !ok {
+				return next(r)
+			}
+			data, err := callback.ParseCommand(r.Node, r.Session.CurrentData)
+			if err != nil {
+				return next(r)
+			}
+
+			lang := r.Session.Lang
+			var toast string
+			if data.Op == callback.OpUndo {
+				if len(data.Args) == 0 {
+					return Output{}, fmt.Errorf("%v: %w: undo without command", r.Node, callback.ErrInvalid)
+				}
+				undone, err := undoer.Undo(r.Session, kind)
+				if err != nil {
+					return Output{}, fmt.Errorf("%v: %w", r.Node, err)
+				}
+				toast = i18n.T(lang, i18n.NothingToUndo)
+				if undone {
+					toast = i18n.T(lang, i18n.Undone)
+				}
+				// the node only shows the result
+				r.Session.CurrentData = callback.New(r.Node, data.Args[0], data.Args[1:]...).Command()
+			}
+
+			output, err := next(r)
+			if err != nil {
+				return output, err
+			}
+			if toast != "" && r.Input.CallbackData != nil {
+				output.Actions = append(output.Actions, Toast(toast))
+			}
+			if output.Keyboard == nil {
+				return output, nil
+			}
+
+			canUndo, err := undoer.CanUndo(r.Session, kind)
+			if err != nil {
+				log.Printf("can undo: telegram_id=%d node=%s: %v\n", telegramID(r.Session), r.Node, err)
+				return output, nil
+			}
+			if canUndo {
+				output.Keyboard = withUndoButton(lang, output.Keyboard, l.undoCommand(r))
+			}
+			return output, nil
+		}
+	}
+}
+
+// undoCommand returns the undo button data with the command which shows the current node
+func (l *Logic) undoCommand(r Request) callback.Data {
+	data, err := callback.ParseCommand(r.Node, r.Session.CurrentData)
+	if err != nil {
+		return callback.New(r.Node, callback.OpUndo, callback.OpStart)
+	}
+	if node, ok := l.nodes[r.Node]; ok {
+		data = reopenCommand(node, data)
+	}
+	return callback.New(r.Node, callback.OpUndo, append([]string{data.Op}, data.Args...)...)
+}
+
+// withUndoButton returns copy of the keyboard with the undo button row at the end
+func withUndoButton(lang i18n.Lang, keyboard *tgbotapi.InlineKeyboardMarkup, data callback.Data) *tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(keyboard.InlineKeyboard)+1)
+	rows = append(rows, keyboard.InlineKeyboard...)
+	rows = append(rows, []tgbotapi.InlineKeyboardButton{
+		callback.Button(i18n.T(lang, i18n.UndoButton), data),
+	})
+	return &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,85 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+// keyboardNode returns the called command with one button keyboard
+type keyboardNode struct {
+	fakeListNode
+}
+
+func (k *keyboardNode) GetCallbackOutput(sessionItem *session.SessionItem, data callback.Data) (logic.Output, error) {
+	return logic.Output{
+		Message: "list " + data.Command(),
+		Keyboard: &tgbotapi.InlineKeyboardMarkup{
+			InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
+				{tgbotapi.NewInlineKeyboardButtonData("menu", "1:firstpage:start")},
+			},
+		},
+	}, nil
+}
+
+// fakeUndoer keeps the count of the changes to undo
+type fakeUndoer struct {
+	changes int
+	kinds   []string
+}
+
+func (f *fakeUndoer) CanUndo(sessionItem *session.SessionItem, kind string) (bool, error) {
+	return f.changes > 0, nil
+}
+
+func (f *fakeUndoer) Undo(sessionItem *session.SessionItem, kind string) (bool, error) {
+	f.kinds = append(f.kinds, kind)
+	if f.changes == 0 {
+		return false, nil
+	}
+	f.changes--
+	return true, nil
+}
+
+func TestUndo(t *testing.T) {
+	undoer := &fakeUndoer{changes: 1}
+	l := newTestLogic().AddNode("list", &keyboardNode{})
+	l.Use(l.Undo(undoer, map[string]string{"list": "shoplist"}))
+
+	// the button shows the node without repeating the change
+	item := &session.SessionItem{CurrentNode: "list", CurrentData: "del:12"}
+	data := "1:list:del:12"
+	output, err := l.GetOutput(logic.Input{CallbackData: &data}, item)
+	require.NoError(t, err)
+	require.Equal(t, "list del:12", output.Message)
+	require.Len(t, output.Keyboard.InlineKeyboard, 2)
+	undoData := *output.Keyboard.InlineKeyboard[1][0].CallbackData
+	require.Equal(t, "1:list:undo:start", undoData)
+
+	// nodes without kind have no button
+	item = &session.SessionItem{CurrentNode: "checklist", CurrentData: "start"}
+	data = "1:checklist:start"
+	output, err = l.GetOutput(logic.Input{CallbackData: &data}, item)
+	require.NoError(t, err)
+	require.Nil(t, output.Keyboard)
+
+	// undo shows the node with toast and without the button
+	item = &session.SessionItem{CurrentNode: "list", CurrentData: "undo:start"}
+	output, err = l.GetOutput(logic.Input{CallbackData: &undoData}, item)
+	require.NoError(t, err)
+	require.Equal(t, "list start", output.Message)
+	require.Equal(t, "start", item.CurrentData)
+	require.Len(t, output.Keyboard.InlineKeyboard, 1)
+	require.Equal(t, []logic.Action{logic.Toast("Отменено")}, output.Actions)
+	require.Equal(t, []string{"shoplist"}, undoer.kinds)
+
+	// outdated button
+	item = &session.SessionItem{CurrentNode: "list", CurrentData: "undo:start"}
+	output, err = l.GetOutput(logic.Input{CallbackData: &undoData}, item)
+	require.NoError(t, err)
+	require.Equal(t, []logic.Action{logic.Toast("Нечего отменять")}, output.Actions)
+}
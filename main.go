@@ -1,21 +1,40 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/getsentry/sentry-go"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	gommonlog "github.com/labstack/gommon/log"
 	"github.com/spf13/viper"
 
+	"github.com/Frosin/shoplist-telegram-bot/audit"
 	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/console"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/dispatcher"
+	"github.com/Frosin/shoplist-telegram-bot/events"
 	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/user"
 	"github.com/Frosin/shoplist-telegram-bot/iot"
 	"github.com/Frosin/shoplist-telegram-bot/logic"
 	"github.com/Frosin/shoplist-telegram-bot/logic/buget"
@@ -31,13 +50,28 @@ import (
 	"github.com/Frosin/shoplist-telegram-bot/logic/settings"
 	"github.com/Frosin/shoplist-telegram-bot/logic/shoppingitems"
 	"github.com/Frosin/shoplist-telegram-bot/metrics"
+	"github.com/Frosin/shoplist-telegram-bot/outbox"
 	"github.com/Frosin/shoplist-telegram-bot/session"
+	"github.com/Frosin/shoplist-telegram-bot/shoplist"
+	"github.com/Frosin/shoplist-telegram-bot/telegram"
+	"github.com/Frosin/shoplist-telegram-bot/webhook"
 	_ "github.com/mattn/go-sqlite3"
+	// time zones of the daily digests don't depend on the system database
+	_ "time/tzdata"
 )
 
 const (
 	debugMode = false
 	startNode = "firstpage"
+
+	callbackKeyLen = 32
+	// callbackKeyFile is kept next to the shoplist database if the key path is not set
+	callbackKeyFile = "callback.key"
+	// sqliteBusyTimeout is milliseconds to wait for the database lock
+	sqliteBusyTimeout = 5000
+
+	consoleCmd    = "console"
+	consoleUserID = 1
 )
 
 var (
@@ -66,22 +100,71 @@ func initConfig() {
 	}
 }
 
-func sendErrorMessage(bot *tgbotapi.BotAPI, update tgbotapi.Update, err error) {
-	errMsg := err.Error()
-	msg := tgbotapi.NewMessage(update.Message.Chat.ID, "sendErrorMsg"+errMsg)
-	_, err = bot.Send(msg)
-	if err != nil {
-		log.Printf("send error message Err=%v\n", err)
+// sendErrorMessage logs the error, user gets generic text without internals
+func sendErrorMessage(bot telegram.Sender, update tgbotapi.Update, err error) {
+	log.Printf("update_id=%d error=%v\n", update.UpdateID, err)
+
+	var (
+		chatID int64
+		from   *tgbotapi.User
+	)
+	switch {
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil:
+		chatID = update.CallbackQuery.Message.Chat.ID
+		from = update.CallbackQuery.From
+	case update.Message != nil:
+		chatID = update.Message.Chat.ID
+		from = update.Message.From
+	default:
+		return
 	}
+	sendText(bot, chatID, i18n.T(telegramLang(from), i18n.InternalError))
+}
+
+// reportError sends internal error to sentry with node, operation and user
+func reportError(err error, r logic.Request) {
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetTag("node", r.Node)
+		scope.SetTag("operation", r.Operation())
+		if r.Session.User != nil {
+			scope.SetUser(sentry.User{
+				ID:       strconv.FormatInt(r.Session.User.TelegramID, 10),
+				Username: r.Session.User.TelegramUsername,
+			})
+		}
+		scope.SetExtra("update_id", r.Input.UpdateID)
+		scope.SetExtra("data", r.Session.CurrentData)
+		sentry.CaptureException(err)
+	})
 }
 
 func updateHandler(
 	update tgbotapi.Update,
 	sessions *session.SessionStorage,
 	appLogic *logic.Logic,
-	bot *tgbotapi.BotAPI,
+	bot telegram.Sender,
+	signer *callback.Signer,
 	startNode string,
 ) {
+	// callback of the inline mode message has no message and chat to reply to
+	if update.CallbackQuery != nil && update.CallbackQuery.Message == nil {
+		log.Printf("callback without message data=%q user=%d\n",
+			update.CallbackQuery.Data, update.CallbackQuery.From.ID)
+		answerCallback(bot, update.CallbackQuery, i18n.InvalidButton)
+		return
+	}
+
+	// callback data must be signed for the user who pressed the button
+	if update.CallbackQuery != nil {
+		data, err := signer.Verify(int64(update.CallbackQuery.From.ID), update.CallbackQuery.Data)
+		if err != nil {
+			log.Printf("verify callback data=%q user=%d error=%v\n",
+				update.CallbackQuery.Data, update.CallbackQuery.From.ID, err)
+			answerCallback(bot, update.CallbackQuery, i18n.InvalidButton)
+			return
+		}
+		update.CallbackQuery.Data = data
+	}
 
 	// get session by updateData
 	sessionItem, err := sessions.Get(
@@ -90,7 +173,15 @@ func updateHandler(
 	)
 	if err != nil {
 		sendErrorMessage(bot, update, err)
+		return
 	}
+	sessionItem.Lock()
+	defer sessionItem.Unlock()
+	defer func() {
+		if err := sessions.Save(sessionItem); err != nil {
+			log.Printf("save session error=%v\n", err)
+		}
+	}()
 
 	//debug
 	log.Printf("update.CallbackQuery=%v\n", update.CallbackQuery)
@@ -100,120 +191,318 @@ func updateHandler(
 	log.Printf("update.Message=%v\n", update.Message)
 	//
 
-	if sessionItem != nil && sessionItem.LastMsgID != 0 && update.CallbackQuery != nil {
-		currentNode := helpers.GetNodeName(update.CallbackQuery.Data)
-		currentData := helpers.GetOperationName(update.CallbackQuery.Data)
-
-		sessionItem.UpdateCallbackData(&currentNode, &currentData)
+	var (
+		inputData = logic.Input{UpdateID: update.UpdateID}
+		chatID    int64
+		queryID   string
+	)
+	switch {
+	case update.CallbackQuery != nil:
+		chatID = update.CallbackQuery.Message.Chat.ID
+		queryID = update.CallbackQuery.ID
 
-		inputData := logic.Input{
-			CallbackData: &update.CallbackQuery.Data,
-		}
-		output, err := appLogic.GetOutput(
-			inputData,
-			sessionItem,
-		)
+		data, err := callback.Decode(update.CallbackQuery.Data)
 		if err != nil {
-			msg := tgbotapi.NewMessage(update.CallbackQuery.Message.Chat.ID, "error: "+err.Error())
-			_, err = bot.Send(msg)
-			if err != nil {
-				log.Println("error sending error msg")
-			}
+			// unknown data shows the start menu
+			log.Printf("decode callback data=%q error=%v, start menu is shown\n", update.CallbackQuery.Data, err)
+			update.CallbackQuery.Data = consts.FirstPageStart
+			data = callback.New(consts.FirstpageWord, callback.OpStart)
 		}
+		currentData := data.Command()
+		sessionItem.UpdateCallbackData(&data.Node, &currentData)
+
+		inputData.CallbackData = &update.CallbackQuery.Data
+	case update.Message != nil:
+		chatID = update.Message.Chat.ID
+		inputData.Message = &update.Message.Text
+	default:
+		return
+	}
 
-		if output.MessageToCommunity != nil {
-			communityUsers, err := sessionItem.SListAPI.GetUsersByComunityID(sessionItem.User.ComunityID)
-			if err != nil {
-				sendErrorMessage(bot, update, err)
-			}
+	output, err := appLogic.GetOutput(
+		inputData,
+		sessionItem,
+	)
+	if err != nil {
+		sendErrorMessage(bot, update, err)
+	}
 
-			for _, user := range communityUsers {
-				if user.TelegramID == sessionItem.User.TelegramID {
-					continue
-				}
-				newMsg := tgbotapi.NewMessage(user.ChatID, *output.MessageToCommunity)
-				_, err := bot.Send(newMsg)
-				if err != nil {
-					log.Println("error sending community msg")
-				}
-			}
-			//bot.AnswerCallbackQuery(tgbotapi.NewCallback(update.CallbackQuery.ID, "")) // Всплывашка с data нажатой кнопки
-		}
+	actions := output.Plan(queryID != "")
+	if debugMode && queryID != "" && len(actions) > 0 {
+		actions[0].Text = "[" + update.CallbackQuery.Data + "]" + actions[0].Text
+	}
+	executeActions(bot, signer, sessionItem, chatID, queryID, actions)
+}
 
-		if output.Image != nil {
-			_, err := bot.Send(output.Image)
-			if err != nil {
-				log.Println("error while sending image", err)
-			}
-		}
+// replyBusy tells the user that the update is dropped because their previous
+// updates are still handled, the callback query is answered so the button
+// doesn't spin
+func replyBusy(bot telegram.Sender, update tgbotapi.Update) {
+	switch {
+	case update.CallbackQuery != nil:
+		answerCallback(bot, update.CallbackQuery, i18n.Busy)
+	case update.Message != nil:
+		sendText(bot, update.Message.Chat.ID, i18n.T(telegramLang(update.Message.From), i18n.Busy))
+	}
+}
 
-		debugMsg := ""
-		if debugMode {
-			debugMsg = "[" + update.CallbackQuery.Data + "]"
+// answerCallback answers the callback query with the text in the user language
+func answerCallback(bot telegram.Sender, query *tgbotapi.CallbackQuery, key i18n.Key) {
+	answer := tgbotapi.NewCallback(query.ID, i18n.T(telegramLang(query.From), key))
+	if _, err := bot.AnswerCallbackQuery(answer); err != nil {
+		log.Println("error answering callback", err)
+	}
+}
+
+// notifyCommunity returns subscriber which queues added items to the active community
+// users except the author according to their settings, outbox worker delivers them
+func notifyCommunity(notifications outbox.Queue, shoplistAPI *shoplist.Shoplist) events.Handler {
+	return func(event events.Event) {
+		switch e := event.(type) {
+		case events.ItemAdded:
+			queueAddedItems(notifications, shoplistAPI, e)
+		case events.ItemsCopied:
+			// the copied items are added to the shopping for the members
+			queueAddedItems(notifications, shoplistAPI, events.ItemAdded{
+				Author:     e.Author,
+				ShoppingID: e.ShoppingID,
+				Shop:       e.Shop,
+				Date:       e.Date,
+				ItemIDs:    e.ItemIDs,
+				Names:      e.Names,
+			})
 		}
+	}
+}
 
-		editedMessage := tgbotapi.NewEditMessageText(
-			update.CallbackQuery.Message.Chat.ID,
-			sessionItem.LastMsgID,
-			debugMsg+output.Message,
-		)
-		if output.Keyboard != nil {
-			editedMessage.ReplyMarkup = output.Keyboard
+func queueAddedItems(notifications outbox.Queue, shoplistAPI *shoplist.Shoplist, added events.ItemAdded) {
+	communityUsers, err := shoplistAPI.GetUsersByComunityID(added.ComunityID)
+	if err != nil {
+		log.Printf("get community users error=%v\n", err)
+		return
+	}
+	date := added.Date.Format(consts.DateLayout)
+
+	queued := []outbox.Notification{}
+	entries := []outbox.Entry{}
+	now := time.Now()
+	for _, member := range communityUsers {
+		if member.TelegramID == added.TelegramID || !member.Active {
+			continue
 		}
-		_, err = bot.Send(editedMessage)
-		if err != nil {
-			log.Println("error sending msg")
+		lang := i18n.Pick(member.Language, "")
+		switch member.Notify {
+		case user.NotifyOff:
+			continue
+		case user.NotifyDigest:
+			// the batch of items is the one digest entry
+			entries = append(entries, outbox.Entry{
+				TelegramID: member.TelegramID,
+				ChatID:     member.ChatID,
+				Lang:       lang,
+				Author:     added.UserName,
+				ShoppingID: added.ShoppingID,
+				Shop:       added.Shop,
+				Date:       date,
+				Item:       outbox.ItemsSummary(lang, added.Names),
+				Due:        outbox.DigestDue(now, member.DigestMinutes, member.DigestTime, outbox.DigestLocation(member.DigestZone)),
+			})
+		default:
+			queued = append(queued, outbox.Notification{
+				TelegramID:  member.TelegramID,
+				ChatID:      member.ChatID,
+				Text:        outbox.ItemsText(lang, added.UserName, added.TelegramID, added.Shop, date, added.Names),
+				NextAttempt: now,
+			})
 		}
-		return
 	}
+	if err := notifications.Add(queued...); err != nil {
+		log.Printf("queue community notifications error=%v\n", err)
+	}
+	if err := notifications.AddEntries(entries...); err != nil {
+		log.Printf("queue community digest entries error=%v\n", err)
+	}
+}
 
-	if update.Message != nil {
-		inputData := logic.Input{
-			Message: &update.Message.Text,
+// refreshSession returns function which shows the current node of the session
+// again in its last message, e.g. the list changed by another member
+func refreshSession(
+	sessions *session.SessionStorage,
+	appLogic *logic.Logic,
+	bot telegram.Sender,
+	signer *callback.Signer,
+) session.RefreshFunc {
+	return func(sessionItem *session.SessionItem) {
+		reopenData := appLogic.ReopenData(sessionItem)
+		data, err := callback.Decode(reopenData)
+		if err != nil {
+			log.Printf("refresh session telegram_id=%d error=%v\n", sessionItem.User.TelegramID, err)
+			return
 		}
-		output, err := appLogic.GetOutput(
-			inputData,
-			sessionItem,
-		)
+		currentData := data.Command()
+		sessionItem.UpdateCallbackData(&data.Node, &currentData)
 
+		output, err := appLogic.GetOutput(logic.Input{CallbackData: &reopenData}, sessionItem)
 		if err != nil {
-			msg := tgbotapi.NewMessage(update.Message.Chat.ID, "error: "+err.Error())
-			_, err = bot.Send(msg)
-			if err != nil {
-				log.Println("error sending error msg")
-			}
+			log.Printf("refresh session telegram_id=%d error=%v\n", sessionItem.User.TelegramID, err)
+			return
+		}
+		if err := sessions.Save(sessionItem); err != nil {
+			log.Printf("save session error=%v\n", err)
 		}
 
-		// send message to community users
-		if output.MessageToCommunity != nil {
-			communityUsers, err := sessionItem.SListAPI.GetUsersByComunityID(sessionItem.User.ComunityID)
-			if err != nil {
-				sendErrorMessage(bot, update, err)
-			}
+		// other actions are for the user who made the request
+		actions := output.Plan(true)
+		if len(actions) > 0 && actions[0].Type == logic.ActionEdit {
+			executeActions(bot, signer, sessionItem, sessionItem.ChatID, "", actions[:1])
+		}
+	}
+}
 
-			for _, user := range communityUsers {
-				if user.TelegramID == sessionItem.User.TelegramID {
-					continue
-				}
-				newMsg := tgbotapi.NewMessage(user.ChatID, *output.MessageToCommunity)
-				_, err := bot.Send(newMsg)
-				if err != nil {
-					log.Println("error sending community msg")
-				}
+// executeActions executes output actions for the user chat, the callback query
+// is always answered, so telegram stops showing the button progress
+func executeActions(
+	bot telegram.Sender,
+	signer *callback.Signer,
+	sessionItem *session.SessionItem,
+	chatID int64,
+	queryID string,
+	actions []logic.Action,
+) {
+	answered := false
+	for _, action := range actions {
+		signer.SignKeyboard(sessionItem.User.TelegramID, action.Keyboard)
+
+		var err error
+		switch action.Type {
+		case logic.ActionEdit:
+			if sessionItem.LastMsgID == 0 {
+				err = sendMessage(bot, sessionItem, chatID, action)
+				break
+			}
+			edited := tgbotapi.NewEditMessageText(chatID, sessionItem.LastMsgID, action.Text)
+			edited.ReplyMarkup = action.Keyboard
+			edited.ParseMode = action.ParseMode
+			_, err = bot.Send(edited)
+		case logic.ActionSend:
+			err = sendMessage(bot, sessionItem, chatID, action)
+		case logic.ActionPhoto:
+			photo := tgbotapi.NewPhotoUpload(chatID, action.File)
+			photo.Caption = action.Text
+			photo.ParseMode = action.ParseMode
+			_, err = bot.Send(photo)
+		case logic.ActionDocument:
+			document := tgbotapi.NewDocumentUpload(chatID, action.File)
+			document.Caption = action.Text
+			document.ParseMode = action.ParseMode
+			_, err = bot.Send(document)
+		case logic.ActionAnswer:
+			if queryID == "" || answered {
+				continue
 			}
-			//bot.AnswerCallbackQuery(tgbotapi.NewCallback(update.CallbackQuery.ID, "")) // Всплывашка с data нажатой кнопки
+			answer := tgbotapi.NewCallback(queryID, action.Text)
+			answer.ShowAlert = action.Alert
+			_, err = bot.AnswerCallbackQuery(answer)
+			answered = true
+		case logic.ActionDelete:
+			messageID := action.MessageID
+			if messageID == 0 {
+				messageID = sessionItem.LastMsgID
+				sessionItem.LastMsgID = 0
+			}
+			_, err = bot.DeleteMessage(tgbotapi.NewDeleteMessage(chatID, messageID))
+		default:
+			err = fmt.Errorf("unknown action type %d", action.Type)
+		}
+		if err != nil {
+			log.Printf("action type=%d chat_id=%d error=%v\n", action.Type, chatID, err)
 		}
+	}
+
+	if queryID != "" && !answered {
+		if _, err := bot.AnswerCallbackQuery(tgbotapi.NewCallback(queryID, "")); err != nil {
+			log.Println("error answering callback", err)
+		}
+	}
+}
+
+// sendMessage sends a new message, it becomes the current message of the session
+func sendMessage(bot telegram.Sender, sessionItem *session.SessionItem, chatID int64, action logic.Action) error {
+	msg := tgbotapi.NewMessage(chatID, action.Text)
+	if action.Keyboard != nil {
+		msg.ReplyMarkup = *action.Keyboard
+	}
+	msg.ParseMode = action.ParseMode
+	sent, err := bot.Send(msg)
+	if err != nil {
+		return err
+	}
+	sessionItem.LastMsgID = sent.MessageID
+	return nil
+}
 
-		msg := tgbotapi.NewMessage(update.Message.Chat.ID, output.Message)
+func sendText(bot telegram.Sender, chatID int64, text string) {
+	if _, err := bot.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		log.Println("error sending msg", err)
+	}
+}
 
-		if output.Keyboard != nil {
-			msg.ReplyMarkup = *output.Keyboard
+// telegramLang returns language by telegram user settings, it is used
+// when there is no session with the chosen language
+func telegramLang(user *tgbotapi.User) i18n.Lang {
+	if user == nil {
+		return i18n.Default
+	}
+	return i18n.Pick("", user.LanguageCode)
+}
+
+// setMyCommands registers command list for every language, so telegram shows it
+// in the menu, users with other languages get the default one
+func setMyCommands(bot *tgbotapi.BotAPI, commands []logic.Command) error {
+	type botCommand struct {
+		Command     string `json:"command"`
+		Description string `json:"description"`
+	}
+
+	for _, lang := range i18n.Langs {
+		botCommands := []botCommand{}
+		for _, command := range commands {
+			botCommands = append(botCommands, botCommand{
+				Command:     command.Name,
+				Description: i18n.T(lang, command.Description),
+			})
 		}
-		mess, err := bot.Send(msg)
+		data, err := json.Marshal(botCommands)
 		if err != nil {
-			log.Println("error sending msg")
+			return err
+		}
+
+		params := url.Values{"commands": {string(data)}}
+		if lang != i18n.Default {
+			params.Set("language_code", string(lang))
+		}
+		if _, err := bot.MakeRequest("setMyCommands", params); err != nil {
+			return err
 		}
-		sessionItem.LastMsgID = mess.MessageID
+	}
+	return nil
+}
+
+// dumpBuget returns subscriber which schedules backup of the changed buget
+func dumpBuget(dumper *helpers.Dumper) events.Handler {
+	return func(event events.Event) {
+		switch event.(type) {
+		case events.BugetCreated, events.CategoryCreated, events.CategoryUpdated, events.NoteAdded, events.NoteRemoved:
+			dumper.ScheduleUpdate()
+		}
+	}
+}
+
+// countEvents returns subscriber which counts events by name
+func countEvents(metricStorage *metrics.MetricStorage) events.Handler {
+	countEvent := metricStorage.AddCounterMetric("events_total", "shoplist", "events", "event")
+	return func(event events.Event) {
+		countEvent(event.Name())
 	}
 }
 
@@ -238,6 +527,44 @@ func StartMetricsServer(metricHandler http.Handler) {
 	}()
 }
 
+// startWebhook sets telegram webhook and starts https listener for updates
+func startWebhook(bot *tgbotapi.BotAPI, webhookURL, port string) (telegram.Updater, error) {
+	secret := viper.GetString("SHOPLIST-BOT_WEBHOOK_SECRET")
+	certFile := viper.GetString("SHOPLIST-BOT_CERT")
+	keyFile := viper.GetString("SHOPLIST-BOT_KEY")
+
+	// updates are never accepted without the secret
+	if secret == "" {
+		log.Println("webhook secret is not set, random secret is used")
+		secret = webhook.NewSecret()
+	}
+
+	link := webhook.URL(webhookURL, secret)
+	config := tgbotapi.NewWebhook(link)
+	if certFile != "" {
+		config = tgbotapi.NewWebhookWithCert(link, certFile)
+	}
+	if err := webhook.Set(bot, config, secret); err != nil {
+		return nil, err
+	}
+
+	handler := webhook.NewHandler(secret, bot.Buffer)
+	go func() {
+		if err := http.ListenAndServeTLS(":"+port, certFile, keyFile, handler); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	log.Printf("webhook mode, listen on :%s", port)
+
+	return handler, nil
+}
+
+// startPolling removes webhook and starts long polling for updates
+func startPolling(bot *tgbotapi.BotAPI) (telegram.Updater, error) {
+	log.Println("polling mode")
+	return telegram.NewPoller(bot, 60)
+}
+
 func startUpdatesRoomTemp(iotStorage iot.IOTStorage, roomTChan, roomHChan chan float64) {
 	go func() {
 		for range time.Tick(time.Hour) {
@@ -262,6 +589,13 @@ func startUpdatesCpuTemp(cpuTChan chan float64) {
 
 func main() {
 	initConfig()
+	if len(os.Args) > 1 && os.Args[1] == consoleCmd {
+		if err := runConsole(os.Stdin, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	port := viper.GetString("SHOPLIST-BOT_PORT")
 	sentryDsn := viper.GetString("SHOPLIST-BOT_SENTRY_DSN")
 	token := viper.GetString("SHOPLIST-BOT_TOKEN")
@@ -287,15 +621,12 @@ func main() {
 	bot.Debug = true
 	log.Printf("Authorized on account %s", bot.Self.UserName)
 
-	_, err = bot.RemoveWebhook()
-	if err != nil {
-		log.Fatal(err)
+	var updater telegram.Updater
+	if webhookURL != "" {
+		updater, err = startWebhook(bot, webhookURL, port)
+	} else {
+		updater, err = startPolling(bot)
 	}
-
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 60
-
-	updates, err := bot.GetUpdatesChan(u)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -309,6 +640,7 @@ func main() {
 	roomTChan := metricStorage.AddMetric("room", "pi", "temperature")
 	roomHChan := metricStorage.AddMetric("room", "pi", "humidity")
 	cpuTempChan := metricStorage.AddMetric("cpu", "pi", "temperature")
+	observeNode := metricStorage.AddDurationMetric("node_duration_seconds", "shoplist", "logic", "node")
 	go metricStorage.StartMetricsUpdater(metricInterval)
 
 	StartMetricsServer(metricStorage.GetMetricsHandler())
@@ -317,15 +649,95 @@ func main() {
 	startUpdatesCpuTemp(cpuTempChan)
 
 	// get ent
-	e := getEnt()
+	e, db := getEnt()
 
-	sessionStorage := session.NewSessionStorage(serviceURI, startToken, bot, e)
+	sessionStore, err := getSessionStore()
+	if err != nil {
+		log.Fatal(err)
+	}
+	signer, err := getCallbackSigner()
+	if err != nil {
+		log.Fatal(err)
+	}
+	notifications, err := getOutboxStore(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go outbox.NewWorker(notifications, bot, outbox.DefaultConfig(), deactivateUser(e, startToken)).
+		Run(context.Background())
+	go outbox.NewDigester(notifications).Run(context.Background(), time.Minute)
+
+	sessionStorage := session.NewSessionStorage(
+		serviceURI,
+		startToken,
+		bot,
+		e,
+		sessionStore,
+		viper.GetDuration("SHOPLIST-BOT_SESSION_TTL"),
+	)
 
-	bugetStorage, err := bugetstorage.NewStorage(NewBugetDumpFunction())
+	// side effects of the changes
+	bus := events.NewBus()
+	sessionStorage.SetEvents(bus)
+	bus.Subscribe(notifyCommunity(notifications, shoplist.NewShoplistAPI(e, startToken)))
+	bus.Subscribe(countEvents(metricStorage))
+	dumper := helpers.NewDumper(NewBugetDumpFunction(), nil)
+	dumper.Start()
+	bus.Subscribe(dumpBuget(dumper))
+
+	bugetStorage, err := bugetstorage.NewStorage(bus)
 	if err != nil {
 		log.Fatal(err)
 	}
+	auditLog := audit.NewLog(e, bugetStorage, viper.GetDuration("SHOPLIST-BOT_UNDO_WINDOW"))
+	bus.Subscribe(auditLog.Handle)
+	go auditLog.Run(context.Background(), time.Hour, viper.GetDuration("SHOPLIST-BOT_AUDIT_RETENTION"))
+
+	appLogic := newLogic(
+		bugetStorage,
+		iotStorage,
+		observeNode,
+		viper.GetString("SHOPLIST-BUDGET_COMMUNITY"),
+		auditLog,
+	)
+
+	if err := setMyCommands(bot, appLogic.Commands()); err != nil {
+		log.Println("set commands error:", err)
+	}
+
+	// expired session shows the reopen button for the last node
+	sessionStorage.SetReopenFunc(func(item *session.SessionItem) string {
+		return signer.Sign(item.User.TelegramID, appLogic.ReopenData(item))
+	})
+	sessionStorage.SetRefreshFunc(refreshSession(sessionStorage, appLogic, bot, signer))
+
+	// updates of one user are handled in order, different users in parallel
+	updatesDispatcher := dispatcher.New(
+		func(update tgbotapi.Update) {
+			updateHandler(update, sessionStorage, appLogic, bot, signer, startNode)
+		},
+		viper.GetInt("SHOPLIST-BOT_MAX_WORKERS"),
+		viper.GetInt("SHOPLIST-BOT_USER_QUEUE_LEN"),
+	)
+	updatesDispatcher.SetBusyFunc(func(update tgbotapi.Update) {
+		replyBusy(bot, update)
+	})
+	updatesDispatcher.SetWait(viper.GetDuration("SHOPLIST-BOT_USER_QUEUE_WAIT"))
+
+	log.Println("start updates")
+	for update := range updater.Updates() {
+		updatesDispatcher.Dispatch(update)
+	}
+}
 
+// newLogic returns logic with all pages (nodes), commands and middlewares
+func newLogic(
+	bugetStorage bugetstorage.Storage,
+	iotStorage iot.IOTStorage,
+	observeNode func(node string, duration time.Duration),
+	bugetCommunity string,
+	undoer logic.Undoer,
+) *logic.Logic {
 	//Create new logic with pages (nodes)
 	appLogic := logic.New().
 		AddNode(calendar.CalendarWord, calendar.New()).
@@ -341,20 +753,186 @@ func main() {
 		AddNode(consts.FundWord, fund.New(bugetStorage)).
 		AddNode(consts.IOTWord, iotlogic.New(iotStorage))
 
-	log.Println("start updates")
-	for update := range updates {
-		go updateHandler(update, sessionStorage, appLogic, bot, startNode)
+	appLogic.
+		AddCommand(logic.Command{Name: "start", Description: i18n.CommandStart, Node: consts.FirstpageWord, Operation: consts.Start}).
+		AddCommand(logic.Command{Name: "list", Description: i18n.CommandList, Node: consts.CurrentlistWord, Operation: consts.Start}).
+		AddCommand(logic.Command{Name: "add", Description: i18n.CommandAdd, Node: consts.CurrentlistWord, Operation: consts.Start}).
+		AddCommand(logic.Command{Name: "checklist", Description: i18n.CommandChecklist, Node: consts.ChecklistWord, Operation: consts.Start}).
+		AddCommand(logic.Command{Name: "budget", Description: i18n.CommandBudget, Node: consts.BugetWord, Operation: consts.Start})
+
+	// budget and iot nodes are only for the budget community
+	appLogic.Use(
+		logic.Recover(),
+		appLogic.Errors(reportError),
+		logic.Logging(),
+		logic.Timing(observeNode),
+		logic.Authorize(logic.CommunityPolicy(
+			bugetCommunity,
+			consts.BugetWord,
+			consts.BugetCategoryWord,
+			consts.FundsWord,
+			consts.FundWord,
+			consts.IOTWord,
+		)),
+		// list nodes undo list changes, budget nodes undo notes
+		appLogic.Undo(undoer, map[string]string{
+			consts.ChecklistWord:          audit.KindShoplist,
+			consts.CurrentlistWord:        audit.KindShoplist,
+			consts.ShoppingitemsWord:      audit.KindShoplist,
+			dayshoppings.DayshoppingsWord: audit.KindShoplist,
+			consts.BugetCategoryWord:      audit.KindBuget,
+			consts.FundWord:               audit.KindBuget,
+		}),
+	)
+
+	return appLogic
+}
+
+// runConsole drives logic from the terminal without telegram, shoplist database
+// and sessions are the configured ones, logs go to stderr
+func runConsole(in io.Reader, out io.Writer) error {
+	gommonlog.SetOutput(os.Stderr)
+
+	e, db := getEnt()
+	sessionStore, err := getSessionStore()
+	if err != nil {
+		return err
+	}
+	signer, err := getCallbackSigner()
+	if err != nil {
+		return err
+	}
+	notifications, err := getOutboxStore(db)
+	if err != nil {
+		return err
 	}
+
+	userID := viper.GetInt("SHOPLIST-BOT_CONSOLE_USER")
+	if userID == 0 {
+		userID = consoleUserID
+	}
+	frontend := console.New(out, userID)
+
+	sessionStorage := session.NewSessionStorage(
+		"",
+		viper.GetString("SHOPLIST-BOT_SERVICE_START_TOKEN"),
+		frontend,
+		e,
+		sessionStore,
+		viper.GetDuration("SHOPLIST-BOT_SESSION_TTL"),
+	)
+	// budget and iot are not available in console
+	auditLog := audit.NewLog(e, bugetstorage.Storage{}, viper.GetDuration("SHOPLIST-BOT_UNDO_WINDOW"))
+	appLogic := newLogic(bugetstorage.Storage{}, iot.NewIOTStorageMap(), func(string, time.Duration) {}, "", auditLog)
+	sessionStorage.SetRefreshFunc(refreshSession(sessionStorage, appLogic, frontend, signer))
+
+	bus := events.NewBus()
+	sessionStorage.SetEvents(bus)
+	bus.Subscribe(notifyCommunity(notifications, shoplist.NewShoplistAPI(e, "")))
+	bus.Subscribe(auditLog.Handle)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startToken := viper.GetString("SHOPLIST-BOT_SERVICE_START_TOKEN")
+	go outbox.NewWorker(notifications, frontend, outbox.DefaultConfig(), deactivateUser(e, startToken)).Run(ctx)
+	go outbox.NewDigester(notifications).Run(ctx, time.Minute)
+
+	return frontend.Run(in, func(update tgbotapi.Update) {
+		updateHandler(update, sessionStorage, appLogic, frontend, signer, startNode)
+	})
 }
 
-func getEnt() *ent.Client {
+// sqliteDSN returns connection string of the database file, the file is shared
+// by ent, outbox and session stores, so writers wait for the lock instead of failing
+func sqliteDSN(dbFullFileName string) string {
+	return fmt.Sprintf("file:%s?_fk=1&_busy_timeout=%d&_journal_mode=WAL", dbFullFileName, sqliteBusyTimeout)
+}
+
+// getEnt returns ent client and its database, the database is shared with the outbox
+func getEnt() (*ent.Client, *sql.DB) {
 	dbFullFileName := viper.GetString("SHOPLIST-BOT_SHOPLISTTPATH")
 
 	log.Println("shoplist file=", dbFullFileName)
-	client, err := ent.Open(dialect.SQLite, fmt.Sprintf("file:%s?_fk=1", dbFullFileName))
+	db, err := sql.Open(dialect.SQLite, sqliteDSN(dbFullFileName))
 	if err != nil {
 		log.Fatalf("failed opening connection to sqlite: %v", err)
 	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	// new columns are added to the existing database
+	if err := client.Schema.Create(context.Background()); err != nil {
+		log.Fatalf("failed creating schema resources: %v", err)
+	}
+
+	return client, db
+}
+
+// getOutboxStore returns notification and digest store, they are kept
+// in the shoplist database if the outbox path is not set
+func getOutboxStore(shoplistDB *sql.DB) (outbox.Queue, error) {
+	dbFullFileName := viper.GetString("SHOPLIST-BOT_OUTBOXPATH")
+	if dbFullFileName != "" {
+		log.Println("outbox file=", dbFullFileName)
+		return outbox.NewSQLiteStore(sqliteDSN(dbFullFileName))
+	}
+	if viper.GetString("SHOPLIST-BOT_SHOPLISTTPATH") == "" {
+		return outbox.NewMemoryStore(), nil
+	}
+
+	return outbox.NewSQLiteStoreDB(shoplistDB)
+}
+
+// deactivateUser marks the user who blocked the bot, so notifications are not queued for them
+func deactivateUser(e *ent.Client, startToken string) outbox.DeactivateFunc {
+	return func(telegramID int64) error {
+		return shoplist.NewShoplistAPI(e, startToken).SetUserActive(telegramID, false)
+	}
+}
+
+// getCallbackSigner returns signer with configured secret, otherwise the key is
+// generated once and kept in the key file, so buttons of the messages sent
+// before restart stay valid
+func getCallbackSigner() (*callback.Signer, error) {
+	secret := viper.GetString("SHOPLIST-BOT_CALLBACK_SECRET")
+	if secret != "" {
+		return callback.NewSigner([]byte(secret)), nil
+	}
+
+	keyFileName := viper.GetString("SHOPLIST-BOT_CALLBACK_KEYPATH")
+	if keyFileName == "" {
+		dbFullFileName := viper.GetString("SHOPLIST-BOT_SHOPLISTTPATH")
+		if dbFullFileName == "" {
+			return nil, errors.New("callback secret and key path are not set")
+		}
+		keyFileName = filepath.Join(filepath.Dir(dbFullFileName), callbackKeyFile)
+	}
+
+	log.Println("callback key file=", keyFileName)
+	key, err := os.ReadFile(keyFileName)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		key = make([]byte, callbackKeyLen)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(keyFileName, key, 0o600); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case len(key) < callbackKeyLen:
+		return nil, fmt.Errorf("callback key file %s is shorter than %d bytes", keyFileName, callbackKeyLen)
+	}
+
+	return callback.NewSigner(key), nil
+}
+
+// getSessionStore returns sqlite session store if path is set, sessions survive restarts
+func getSessionStore() (session.Store, error) {
+	dbFullFileName := viper.GetString("SHOPLIST-BOT_SESSIONPATH")
+	if dbFullFileName == "" {
+		return session.NewMemoryStore(), nil
+	}
 
-	return client
+	log.Println("session file=", dbFullFileName)
+	return session.NewSQLiteStore(sqliteDSN(dbFullFileName))
 }
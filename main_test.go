@@ -0,0 +1,546 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/audit"
+	"github.com/Frosin/shoplist-telegram-bot/bugetstorage"
+	"github.com/Frosin/shoplist-telegram-bot/callback"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/dispatcher"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/helpers"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/enttest"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/item"
+	"github.com/Frosin/shoplist-telegram-bot/iot"
+	"github.com/Frosin/shoplist-telegram-bot/logic"
+	"github.com/Frosin/shoplist-telegram-bot/outbox"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	"github.com/Frosin/shoplist-telegram-bot/shoplist"
+	"github.com/Frosin/shoplist-telegram-bot/telegram/telegramtest"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = 7
+
+// buttons returns texts of the keyboard buttons
+func buttons(keyboard *tgbotapi.InlineKeyboardMarkup) []string {
+	texts := []string{}
+	if keyboard == nil {
+		return texts
+	}
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			texts = append(texts, button.Text)
+		}
+	}
+	return texts
+}
+
+// testBot is the bot driven by the fake telegram server
+type testBot struct {
+	t        *testing.T
+	server   *telegramtest.Server
+	bot      *tgbotapi.BotAPI
+	e        *ent.Client
+	sessions *session.SessionStorage
+	signer   *callback.Signer
+	bus      *events.Bus
+	auditLog *audit.Log
+	appLogic *logic.Logic
+}
+
+// newTestBot returns the bot with the empty database of the sqlite dsn,
+// the bus has no subscribers
+func newTestBot(t *testing.T, dsn string) *testBot {
+	server := telegramtest.NewServer()
+	t.Cleanup(server.Close)
+	bot, err := server.Bot()
+	require.NoError(t, err)
+
+	e := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { e.Close() })
+
+	sessions := session.NewSessionStorage("", "", bot, e, session.NewMemoryStore(), 0)
+	bus := events.NewBus()
+	sessions.SetEvents(bus)
+	auditLog := audit.NewLog(e, bugetstorage.Storage{}, 0)
+
+	return &testBot{
+		t:        t,
+		server:   server,
+		bot:      bot,
+		e:        e,
+		sessions: sessions,
+		signer:   callback.NewSigner([]byte("secret")),
+		bus:      bus,
+		auditLog: auditLog,
+		appLogic: newLogic(bugetstorage.Storage{}, iot.NewIOTStorageMap(), func(string, time.Duration) {}, "", auditLog),
+	}
+}
+
+// memoryDSN returns dsn of the shared in-memory database
+func memoryDSN(name string) string {
+	return "file:" + name + "?mode=memory&cache=shared&_fk=1"
+}
+
+func (b *testBot) handle(update tgbotapi.Update) {
+	updateHandler(update, b.sessions, b.appLogic, b.bot, b.signer, startNode)
+}
+
+// send handles the text message of the user
+func (b *testBot) send(userID int, text string) {
+	b.handle(b.server.SendText(userID, text))
+}
+
+// lastMessage returns the last message of the bot in the user chat
+func (b *testBot) lastMessage(userID int) telegramtest.Message {
+	message, ok := b.server.LastBotMessage(int64(userID))
+	require.True(b.t, ok)
+	return message
+}
+
+// press handles the button of the message and returns the message after it
+func (b *testBot) press(userID, messageID int, button string) telegramtest.Message {
+	update, err := b.server.Press(userID, messageID, button)
+	require.NoError(b.t, err)
+	b.handle(update)
+	message, _ := b.server.Message(int64(userID), messageID)
+	return message
+}
+
+func TestChecklistCopyToCurrentList(t *testing.T) {
+	b := newTestBot(t, memoryDSN("e2e"))
+
+	// open checklist and add item
+	b.send(testUserID, "/checklist")
+	require.Contains(t, b.lastMessage(testUserID).Text, "Чек-лист. Введите товар")
+
+	b.send(testUserID, "milk")
+	list := b.lastMessage(testUserID)
+	require.Equal(t, []string{"1. 🥛 milk", "⬅ Меню", "Выделить все"}, buttons(list.Keyboard))
+
+	// select item, the same message is edited
+	update, err := b.server.Press(testUserID, list.ID, "1. 🥛 milk")
+	require.NoError(t, err)
+	b.handle(update)
+	list, _ = b.server.Message(testUserID, list.ID)
+	require.Equal(t, []string{
+		"1. 🥛 " + helpers.GetUnderlinedText("milk"),
+		"➖", "milk", "➕", "🏷",
+		"⬅ Меню", "Выделить все", "Удалить", "В текущий список",
+	}, buttons(list.Keyboard))
+	_, answered := b.server.Answer(update.CallbackQuery.ID)
+	require.True(t, answered)
+
+	// quantity of the selected item is changed
+	list = b.press(testUserID, list.ID, "➕")
+	require.Equal(t, []string{
+		"1. 🥛 " + helpers.GetUnderlinedText("milk x2"),
+		"➖", "milk x2", "➕", "🏷",
+		"⬅ Меню", "Выделить все", "Удалить", "В текущий список",
+	}, buttons(list.Keyboard))
+
+	// category of the selected item is chosen
+	list = b.press(testUserID, list.ID, "🏷")
+	require.Equal(t, "Выберите категорию для «milk x2»", list.Text)
+	require.Contains(t, buttons(list.Keyboard), "✔ 🥛 Молочка")
+	list = b.press(testUserID, list.ID, "🥤 Напитки")
+	require.Equal(t, "1. 🥤 "+helpers.GetUnderlinedText("milk x2"), buttons(list.Keyboard)[0])
+
+	// copy to the current list
+	list = b.press(testUserID, list.ID, "В текущий список")
+	require.Contains(t, list.Text, "Товары скопированы.")
+	require.Equal(t, []string{"1. 🥤 milk x2", "⬅ Меню", "Выделить все"}, buttons(list.Keyboard))
+	require.Equal(t, list.ID, b.lastMessage(testUserID).ID)
+
+	// quantities of the same items are summed, the chosen category is remembered
+	b.send(testUserID, "/list")
+	require.Equal(t, []string{"1. 🥤 milk x2", "⬅ Меню"}, buttons(b.lastMessage(testUserID).Keyboard))
+	b.send(testUserID, "Milk 3")
+	b.send(testUserID, "milk\nbread")
+	require.Equal(t, []string{"1. 🍞 bread", "2. 🥤 milk x6", "⬅ Меню"}, buttons(b.lastMessage(testUserID).Keyboard))
+}
+
+func TestForgedCallbackData(t *testing.T) {
+	b := newTestBot(t, memoryDSN("forged"))
+
+	b.send(testUserID, "/start")
+	menu := b.lastMessage(testUserID)
+
+	// button data is replaced with unsigned one
+	pressed, err := b.server.Press(testUserID, menu.ID, "Чек-лист")
+	require.NoError(t, err)
+	pressed.CallbackQuery.Data = callback.New("checklist", callback.OpDelete, "1").MustEncode()
+	b.handle(pressed)
+
+	answer, ok := b.server.Answer(pressed.CallbackQuery.ID)
+	require.True(t, ok)
+	require.Equal(t, i18n.T(i18n.RU, i18n.InvalidButton), answer.Text)
+	unchanged, _ := b.server.Message(testUserID, menu.ID)
+	require.Equal(t, menu.Text, unchanged.Text)
+}
+
+func TestCallbackWithoutMessage(t *testing.T) {
+	b := newTestBot(t, memoryDSN("inline"))
+
+	b.send(testUserID, "/start")
+	menu := b.lastMessage(testUserID)
+
+	// callback of the inline mode message has no message
+	pressed, err := b.server.Press(testUserID, menu.ID, "Чек-лист")
+	require.NoError(t, err)
+	pressed.CallbackQuery.Message = nil
+	pressed.CallbackQuery.InlineMessageID = "inline"
+	b.handle(pressed)
+
+	answer, ok := b.server.Answer(pressed.CallbackQuery.ID)
+	require.True(t, ok)
+	require.Equal(t, i18n.T(i18n.RU, i18n.InvalidButton), answer.Text)
+	unchanged, _ := b.server.Message(testUserID, menu.ID)
+	require.Equal(t, menu.Text, unchanged.Text)
+}
+
+func TestRunConsole(t *testing.T) {
+	viper.Set("SHOPLIST-BOT_SHOPLISTTPATH", filepath.Join(t.TempDir(), "shoplist.db"))
+	defer viper.Set("SHOPLIST-BOT_SHOPLISTTPATH", "")
+
+	out := &bytes.Buffer{}
+	// open the menu and press settings
+	require.NoError(t, runConsole(strings.NewReader("/start\n3\n"), out))
+
+	require.Contains(t, out.String(), "[3] Настройки")
+	require.Contains(t, out.String(), "изменено")
+}
+
+func TestCallbackKeyFile(t *testing.T) {
+	viper.Set("SHOPLIST-BOT_SHOPLISTTPATH", filepath.Join(t.TempDir(), "shoplist.db"))
+	defer viper.Set("SHOPLIST-BOT_SHOPLISTTPATH", "")
+
+	// generated key is kept, so signed buttons survive restart
+	signer, err := getCallbackSigner()
+	require.NoError(t, err)
+	signed := signer.Sign(testUserID, "1:checklist:start")
+
+	restarted, err := getCallbackSigner()
+	require.NoError(t, err)
+	data, err := restarted.Verify(testUserID, signed)
+	require.NoError(t, err)
+	require.Equal(t, "1:checklist:start", data)
+
+	viper.Set("SHOPLIST-BOT_SHOPLISTTPATH", "")
+	_, err = getCallbackSigner()
+	require.Error(t, err)
+}
+
+func TestSettingsLanguage(t *testing.T) {
+	b := newTestBot(t, memoryDSN("lang"))
+
+	// user without telegram language gets the default one
+	b.send(testUserID, "/start")
+	menu := b.lastMessage(testUserID)
+	require.Contains(t, buttons(menu.Keyboard), "Настройки")
+
+	settings := b.press(testUserID, menu.ID, "Настройки")
+	settings = b.press(testUserID, settings.ID, "🌐 English")
+	require.Contains(t, settings.Text, "<Language is changed>")
+	require.Contains(t, buttons(settings.Keyboard), "🌐 Русский")
+
+	// chosen language is stored and overrides telegram one
+	user, err := b.e.User.Query().Only(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "en", user.Language)
+
+	b.send(testUserID, "/start")
+	menu = b.lastMessage(testUserID)
+	require.Equal(t, "Main menu", menu.Text)
+	require.Contains(t, buttons(menu.Keyboard), "Settings")
+}
+
+// joinCommunity makes the member join the group of the user by the user ID
+func (b *testBot) joinCommunity(memberID, userID int) {
+	b.send(userID, "/start")
+	b.send(memberID, "/start")
+	b.press(memberID, b.lastMessage(memberID).ID, "Настройки")
+	b.send(memberID, strconv.Itoa(userID))
+}
+
+func TestCommunityNotification(t *testing.T) {
+	b := newTestBot(t, memoryDSN("community"))
+	notifications := outbox.NewMemoryStore()
+	b.bus.Subscribe(notifyCommunity(notifications, shoplist.NewShoplistAPI(b.e, "")))
+
+	const memberID = testUserID + 1
+	b.joinCommunity(memberID, testUserID)
+
+	b.send(testUserID, "/list")
+	b.send(testUserID, "/add milk")
+	due, err := notifications.Due(time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, int64(memberID), due[0].ChatID)
+
+	// pasted list is the one notification, blank lines and duplicates are skipped
+	b.send(testUserID, "bread\n\nmilk\neggs x10, bread")
+	due, err = notifications.Due(time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	require.Contains(t, due[1].Text, "добавил товары (2) в 'Текущий список'")
+	require.Contains(t, due[1].Text, ": bread, eggs x10")
+
+	// notifications are not queued for the member who blocked the bot
+	require.NoError(t, deactivateUser(b.e, "")(memberID))
+	b.send(testUserID, "/add fish")
+	due, err = notifications.Due(time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+
+	worker := outbox.NewWorker(notifications, b.bot, outbox.DefaultConfig(), nil)
+	require.Equal(t, 1, worker.Flush())
+	require.Contains(t, b.lastMessage(memberID).Text, "milk")
+}
+
+func TestCommunityDigest(t *testing.T) {
+	b := newTestBot(t, memoryDSN("digest"))
+	notifications := outbox.NewMemoryStore()
+	b.bus.Subscribe(notifyCommunity(notifications, shoplist.NewShoplistAPI(b.e, "")))
+
+	const memberID = testUserID + 1
+	b.joinCommunity(memberID, testUserID)
+
+	// member switches to the daily digest
+	settingsPage := b.press(memberID, b.lastMessage(memberID).ID, "🗞 Дайджест")
+	b.send(memberID, "25:00")
+	settingsPage = b.lastMessage(memberID)
+	require.Contains(t, settingsPage.Text, "<Время должно быть в формате ЧЧ:ММ>")
+	b.send(memberID, "8:30 Nowhere/Town")
+	settingsPage = b.lastMessage(memberID)
+	require.Contains(t, settingsPage.Text, "<Неизвестный часовой пояс, например Europe/Moscow>")
+	b.send(memberID, "8:30 Asia/Tokyo")
+	settingsPage = b.lastMessage(memberID)
+	require.Contains(t, settingsPage.Text, "дайджест в 08:30 Asia/Tokyo")
+
+	b.send(testUserID, "/list")
+	b.send(testUserID, "/add milk")
+	b.send(testUserID, "/add bread")
+	due, err := notifications.Due(time.Now(), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	entries, err := notifications.Entries(memberID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "milk", entries[0].Item)
+	require.Equal(t, "bread", entries[1].Item)
+	require.Equal(t, entries[0].ShoppingID, entries[1].ShoppingID)
+	// the digest is due at the time of the member zone
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	at := entries[0].Due.In(tokyo)
+	require.Equal(t, []int{8, 30}, []int{at.Hour(), at.Minute()})
+
+	// notifications are off
+	b.press(memberID, settingsPage.ID, "🔕 Выкл.")
+	b.send(testUserID, "/add fish")
+	entries, err = notifications.Entries(memberID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestUndo(t *testing.T) {
+	b := newTestBot(t, memoryDSN("undo"))
+	b.bus.Subscribe(b.auditLog.Handle)
+
+	b.send(testUserID, "/checklist")
+	b.send(testUserID, "milk")
+	list := b.lastMessage(testUserID)
+	require.Equal(t, []string{"1. 🥛 milk", "⬅ Меню", "Выделить все", "↶ Отменить"}, buttons(list.Keyboard))
+
+	// removed item is restored
+	b.press(testUserID, list.ID, "1. 🥛 milk")
+	list = b.press(testUserID, list.ID, "Удалить")
+	require.NotContains(t, buttons(list.Keyboard), "1. 🥛 milk")
+	list = b.press(testUserID, list.ID, "↶ Отменить")
+
+	// only the last change is undone, undo is not recorded itself
+	require.Equal(t, []string{"1. 🥛 milk", "⬅ Меню", "Выделить все"}, buttons(list.Keyboard))
+
+	records, err := b.e.Audit.Query().All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, []string{"item_added", "items_removed"}, []string{records[0].Action, records[1].Action})
+	require.False(t, records[0].Undone)
+	require.True(t, records[1].Undone)
+
+	// new change can be undone
+	b.send(testUserID, "bread")
+	list = b.lastMessage(testUserID)
+	require.Equal(t, []string{"1. 🍞 bread", "2. 🥛 milk", "⬅ Меню", "Выделить все", "↶ Отменить"}, buttons(list.Keyboard))
+	list = b.press(testUserID, list.ID, "↶ Отменить")
+	require.Equal(t, []string{"1. 🥛 milk", "⬅ Меню", "Выделить все"}, buttons(list.Keyboard))
+}
+
+func TestShoppingItemsComplete(t *testing.T) {
+	b := newTestBot(t, memoryDSN("complete"))
+
+	// open the day of the calendar and add the shopping
+	b.send(testUserID, "/start")
+	update, err := b.server.Press(testUserID, b.lastMessage(testUserID).ID, "Календарь")
+	require.NoError(t, err)
+	day := callback.New(consts.DayshoppingsWord, callback.OpShow, helpers.Time2DayCode(time.Now()))
+	update.CallbackQuery.Data = b.signer.Sign(testUserID, day.MustEncode())
+	b.handle(update)
+	b.send(testUserID, "market")
+	b.press(testUserID, b.lastMessage(testUserID).ID, "1. market")
+	b.send(testUserID, "milk")
+	b.send(testUserID, "bread")
+	list := b.lastMessage(testUserID)
+	// items are in the aisle order
+	require.Equal(t, []string{
+		"1. 🍞 bread", "2. 🥛 milk",
+		"⬅ Вернуться к ", "↑ из текущего", "↑ из чек-листа",
+		"☑ Выбрать",
+	}, buttons(list.Keyboard))
+
+	// tapped item is bought for everyone
+	list = b.press(testUserID, list.ID, "2. 🥛 milk")
+	require.Equal(t, []string{
+		"1. 🍞 bread", "2. ✅ milk",
+		"⬅ Вернуться к ", "↑ из текущего", "↑ из чек-листа",
+		"☑ Выбрать", "🙈 Скрыть купленные", "🧹 Убрать купленные",
+	}, buttons(list.Keyboard))
+	milk, err := b.e.Item.Query().Where(item.ProductNameEQ("milk")).Only(context.Background())
+	require.NoError(t, err)
+	require.True(t, milk.Complete)
+
+	list = b.press(testUserID, list.ID, "🙈 Скрыть купленные")
+	require.Equal(t, []string{
+		"1. 🍞 bread",
+		"⬅ Вернуться к ", "↑ из текущего", "↑ из чек-листа",
+		"☑ Выбрать", "👁 Купленные (1)", "🧹 Убрать купленные",
+	}, buttons(list.Keyboard))
+	list = b.press(testUserID, list.ID, "👁 Купленные (1)")
+
+	// selection is the separate mode
+	list = b.press(testUserID, list.ID, "☑ Выбрать")
+	require.Equal(t, "Выберите товары для удаления", list.Text)
+	list = b.press(testUserID, list.ID, "1. 🍞 bread")
+	require.Equal(t, []string{
+		"1. 🍞 " + helpers.GetUnderlinedText("bread"), "2. ✅ milk",
+		"➖", "bread", "➕", "🏷",
+		"⬅ Вернуться к ", "⊗ выбранные", "↑ из текущего", "↑ из чек-листа",
+		"✖ Отменить выбор",
+	}, buttons(list.Keyboard))
+	list = b.press(testUserID, list.ID, "⊗ выбранные")
+	require.Equal(t, []string{
+		"1. ✅ milk",
+		"⬅ Вернуться к ", "↑ из текущего", "↑ из чек-листа",
+		"☑ Выбрать", "🙈 Скрыть купленные", "🧹 Убрать купленные",
+	}, buttons(list.Keyboard))
+
+	// bought product is suggested for the next shopping
+	list = b.press(testUserID, list.ID, "🧹 Убрать купленные")
+	require.Equal(t, []string{
+		"➕ milk",
+		"⬅ Вернуться к ", "↑ из текущего", "↑ из чек-листа",
+		"☑ Выбрать",
+	}, buttons(list.Keyboard))
+
+	b.send(testUserID, "mi?")
+	list = b.lastMessage(testUserID)
+	require.Equal(t, "Найдено по «mi»:", list.Text)
+	list = b.press(testUserID, list.ID, "➕ milk")
+	require.Equal(t, []string{
+		"1. 🥛 milk",
+		"⬅ Вернуться к ", "↑ из текущего", "↑ из чек-листа",
+		"☑ Выбрать",
+	}, buttons(list.Keyboard))
+
+	b.send(testUserID, "tea?")
+	require.Equal(t, "Ничего не найдено по «tea»", b.lastMessage(testUserID).Text)
+}
+
+func TestCurrentListRestock(t *testing.T) {
+	b := newTestBot(t, memoryDSN("restock"))
+
+	// milk is bought every week, the last time 8 days ago
+	user, err := shoplist.NewShoplistAPI(b.e, "").UserInit(testUserID, testUserID, "user")
+	require.NoError(t, err)
+	client := shoplist.NewShoplistAPI(b.e, user.Token)
+	for _, days := range []int{22, 15, 8} {
+		shoppingID, err := client.AddShoppingWithType(time.Now().AddDate(0, 0, -days), "market", consts.ShoppingTypeDefault)
+		require.NoError(t, err)
+		require.NoError(t, client.AddItem(shoppingID, "молоко 2л"))
+	}
+
+	b.send(testUserID, "/list")
+	list := b.lastMessage(testUserID)
+	require.Contains(t, list.Text, "💡 Пора купить: молоко")
+	require.Equal(t, []string{"💡 молоко", "⬅ Меню"}, buttons(list.Keyboard))
+
+	// the product is added like the last purchase
+	list = b.press(testUserID, list.ID, "💡 молоко")
+	require.Equal(t, "Текущий список. Введите товар для добавления", list.Text)
+	require.Equal(t, []string{"1. 🥛 молоко 2л", "⬅ Меню"}, buttons(list.Keyboard))
+}
+
+func TestAddWithoutList(t *testing.T) {
+	b := newTestBot(t, memoryDSN("add"))
+
+	// the current list is created by the first item of the new user
+	b.send(testUserID, "/add milk")
+	list := b.lastMessage(testUserID)
+	require.Equal(t, "Текущий список. Введите товар для добавления", list.Text)
+	require.Equal(t, []string{"1. 🥛 milk", "⬅ Меню"}, buttons(list.Keyboard))
+
+	b.send(testUserID, "bread")
+	require.Equal(t, []string{"1. 🍞 bread", "2. 🥛 milk", "⬅ Меню"}, buttons(b.lastMessage(testUserID).Keyboard))
+}
+
+func TestConcurrentUsers(t *testing.T) {
+	const (
+		users = 5
+		items = 10
+	)
+
+	// concurrent writes wait for each other in the file database
+	b := newTestBot(t, sqliteDSN(filepath.Join(t.TempDir(), "shoplist.db")))
+	b.sessions.SetRefreshFunc(refreshSession(b.sessions, b.appLogic, b.bot, b.signer))
+
+	// updates of different users are handled by the shared nodes at once
+	updates := dispatcher.New(b.handle, users, items+1)
+	for userID := 1; userID <= users; userID++ {
+		updates.Dispatch(b.server.SendText(userID, "/list"))
+	}
+	for i := 0; i < items; i++ {
+		for userID := 1; userID <= users; userID++ {
+			updates.Dispatch(b.server.SendText(userID, "u"+strconv.Itoa(userID)+"i"+strconv.Itoa(i)))
+		}
+	}
+	updates.Wait()
+
+	// every user sees only their own list
+	for userID := 1; userID <= users; userID++ {
+		expected := []string{}
+		for i := 0; i < items; i++ {
+			expected = append(expected, "u"+strconv.Itoa(userID)+"i"+strconv.Itoa(i))
+		}
+		names := []string{}
+		for _, text := range buttons(b.lastMessage(userID).Keyboard) {
+			if _, name, ok := strings.Cut(text, ". "); ok {
+				names = append(names, name)
+			}
+		}
+		require.ElementsMatch(t, expected, names, "user %d", userID)
+	}
+}
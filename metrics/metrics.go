@@ -18,7 +18,8 @@ type metric struct {
 }
 
 type MetricStorage struct {
-	metrics []metric
+	metrics    []metric
+	collectors []prometheus.Collector
 }
 
 type UpdateResult struct {
@@ -51,6 +52,39 @@ func (m *MetricStorage) AddMetric(name, namespace, subsystem string) chan float6
 	return metric.sourceChan
 }
 
+// AddDurationMetric adds summary of durations partitioned by label,
+// returned func observes duration for the label value
+func (m *MetricStorage) AddDurationMetric(name, namespace, subsystem, label string) func(string, time.Duration) {
+	summary := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace:  namespace,
+		Subsystem:  subsystem,
+		Name:       strings.ReplaceAll(name, ".", "_"),
+		Help:       fmt.Sprintf("shoplist internal duration metric '%s' in seconds", name),
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+	}, []string{label})
+	m.collectors = append(m.collectors, summary)
+
+	return func(value string, duration time.Duration) {
+		summary.WithLabelValues(value).Observe(duration.Seconds())
+	}
+}
+
+// AddCounterMetric adds counter partitioned by label,
+// returned func increments counter for the label value
+func (m *MetricStorage) AddCounterMetric(name, namespace, subsystem, label string) func(string) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      strings.ReplaceAll(name, ".", "_"),
+		Help:      fmt.Sprintf("shoplist internal counter '%s'", name),
+	}, []string{label})
+	m.collectors = append(m.collectors, counter)
+
+	return func(value string) {
+		counter.WithLabelValues(value).Inc()
+	}
+}
+
 //GetMetricsHandler returns default prometheus client server handler
 func (m *MetricStorage) GetMetricsHandler() http.Handler {
 	r := prometheus.NewRegistry()
@@ -58,6 +92,9 @@ func (m *MetricStorage) GetMetricsHandler() http.Handler {
 	for _, metric := range m.metrics {
 		r.MustRegister(metric.gauge)
 	}
+	for _, collector := range m.collectors {
+		r.MustRegister(collector)
+	}
 
 	return promhttp.HandlerFor(r, promhttp.HandlerOpts{})
 }
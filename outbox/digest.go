@@ -0,0 +1,472 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	digestDB = "digest"
+
+	// DefaultDigestMinutes is used for the users without digest interval
+	DefaultDigestMinutes = 60
+	// DigestTimeLayout is the layout of the daily digest time
+	DigestTimeLayout = "15:04"
+	// MaxSummaryItems is the count of the items listed in the batch summary
+	MaxSummaryItems = 5
+
+	createDigestDB = `CREATE TABLE IF NOT EXISTS digest (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		telegram_id INTEGER NOT NULL,
+		chat_id     INTEGER NOT NULL,
+		lang        TEXT NOT NULL,
+		author      TEXT NOT NULL,
+		shopping_id INTEGER NOT NULL,
+		shop        TEXT NOT NULL,
+		date        TEXT NOT NULL,
+		item        TEXT NOT NULL,
+		due         INTEGER NOT NULL,
+		created     INTEGER NOT NULL
+	)`
+	createDigestIndex = `CREATE INDEX IF NOT EXISTS digest_due ON digest (due)`
+)
+
+// Entry is the item added by the community member, entries of the recipient
+// are sent together as digest when the first of them is due
+type Entry struct {
+	ID         int64
+	TelegramID int64
+	ChatID     int64
+	Lang       i18n.Lang
+	Author     string
+	ShoppingID int
+	Shop       string
+	Date       string
+	Item       string
+	Due        time.Time
+	Created    time.Time
+}
+
+// DigestStore keeps digest entries until the digest is queued
+type DigestStore interface {
+	AddEntries(entries ...Entry) error
+	// DueDigests returns recipients which digests are due at the time
+	DueDigests(now time.Time) ([]int64, error)
+	// Entries returns all entries of the recipient, the oldest first
+	Entries(telegramID int64) ([]Entry, error)
+	DeleteEntries(ids ...int64) error
+	// QueueDigest adds the digest notification and deletes its entries at once,
+	// so the entries are neither lost nor sent twice
+	QueueDigest(notification Notification, ids ...int64) error
+}
+
+// Queue keeps notifications and digest entries
+type Queue interface {
+	Store
+	DigestStore
+}
+
+// DigestDue returns time of the digest for the entry added now, digest is sent
+// every minutes or daily at time of the location if it is set, nil location
+// is the location of now
+func DigestDue(now time.Time, minutes int, at string, loc *time.Location) time.Time {
+	if at != "" {
+		if t, err := time.Parse(DigestTimeLayout, at); err == nil {
+			if loc == nil {
+				loc = now.Location()
+			}
+			day := now.In(loc)
+			due := time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+			if !due.After(now) {
+				due = due.AddDate(0, 0, 1)
+			}
+			return due
+		}
+	}
+	if minutes <= 0 {
+		minutes = DefaultDigestMinutes
+	}
+	return now.Add(time.Duration(minutes) * time.Minute)
+}
+
+// DigestLocation returns location of the IANA time zone, e.g. "Europe/Moscow",
+// empty or unknown zone is the local time zone of the bot
+func DigestLocation(zone string) *time.Location {
+	if zone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		log.Printf("outbox digest zone=%q error=%v\n", zone, err)
+		return time.Local
+	}
+	return loc
+}
+
+// ItemsText returns immediate notification about the items added to the shopping,
+// the batch of items is summarized
+func ItemsText(lang i18n.Lang, author string, authorID int64, shop, date string, items []string) string {
+	if len(items) > 1 {
+		return i18n.T(lang, i18n.UserAddedItems, author, authorID, len(items), shopTitle(lang, shop), date, ItemsSummary(lang, items))
+	}
+	return i18n.T(lang, i18n.UserAddedItem, author, authorID, strings.Join(items, ", "), shopTitle(lang, shop), date)
+}
+
+// ItemsSummary returns the first MaxSummaryItems items and the count of the rest
+func ItemsSummary(lang i18n.Lang, items []string) string {
+	if len(items) <= MaxSummaryItems {
+		return strings.Join(items, ", ")
+	}
+	return strings.Join(items[:MaxSummaryItems], ", ") + " " + i18n.T(lang, i18n.MoreItems, len(items)-MaxSummaryItems)
+}
+
+// DigestText returns digest with the entries grouped by shopping
+func DigestText(lang i18n.Lang, entries []Entry) string {
+	order := []int{}
+	groups := map[int][]Entry{}
+	for _, entry := range entries {
+		if _, ok := groups[entry.ShoppingID]; !ok {
+			order = append(order, entry.ShoppingID)
+		}
+		groups[entry.ShoppingID] = append(groups[entry.ShoppingID], entry)
+	}
+
+	lines := []string{i18n.T(lang, i18n.DigestTitle)}
+	for _, shoppingID := range order {
+		group := groups[shoppingID]
+		lines = append(lines, "", i18n.T(lang, i18n.DigestShopping, shopTitle(lang, group[0].Shop), group[0].Date))
+		for _, entry := range group {
+			lines = append(lines, i18n.T(lang, i18n.DigestItem, entry.Item, entry.Author))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shopTitle translates names of the special shoppings
+func shopTitle(lang i18n.Lang, shop string) string {
+	switch shop {
+	case consts.ChecklistWord:
+		return i18n.T(lang, i18n.ChecklistButton)
+	case consts.CurrentlistWord:
+		return i18n.T(lang, i18n.CurrentListButton)
+	}
+	return shop
+}
+
+// Digester turns due digest entries into notifications
+type Digester struct {
+	digests DigestStore
+	now     func() time.Time
+}
+
+func NewDigester(digests DigestStore) *Digester {
+	return &Digester{
+		digests: digests,
+		now:     time.Now,
+	}
+}
+
+// Run queues digests every interval until the context is done
+func (d *Digester) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.Flush()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush queues due digests, it returns count of the queued digests
+func (d *Digester) Flush() int {
+	now := d.now()
+	recipients, err := d.digests.DueDigests(now)
+	if err != nil {
+		log.Printf("outbox due digests error=%v\n", err)
+		return 0
+	}
+
+	queued := 0
+	for _, telegramID := range recipients {
+		entries, err := d.digests.Entries(telegramID)
+		if err != nil {
+			log.Printf("outbox digest entries telegram_id=%d error=%v\n", telegramID, err)
+			continue
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		last := entries[len(entries)-1]
+		notification := Notification{
+			TelegramID:  telegramID,
+			ChatID:      last.ChatID,
+			Text:        DigestText(last.Lang, entries),
+			NextAttempt: now,
+		}
+		ids := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+		if err := d.digests.QueueDigest(notification, ids...); err != nil {
+			log.Printf("outbox queue digest telegram_id=%d error=%v\n", telegramID, err)
+			continue
+		}
+		queued++
+	}
+	return queued
+}
+
+func (m *MemoryStore) AddEntries(entries ...Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range entries {
+		m.lastID++
+		entry.ID = m.lastID
+		if entry.Created.IsZero() {
+			entry.Created = time.Now()
+		}
+		m.entries = append(m.entries, entry)
+	}
+	return nil
+}
+
+func (m *MemoryStore) DueDigests(now time.Time) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recipients := []int64{}
+	seen := map[int64]bool{}
+	for _, entry := range m.entries {
+		if entry.Due.After(now) || seen[entry.TelegramID] {
+			continue
+		}
+		seen[entry.TelegramID] = true
+		recipients = append(recipients, entry.TelegramID)
+	}
+	sort.Slice(recipients, func(i, j int) bool { return recipients[i] < recipients[j] })
+	return recipients, nil
+}
+
+func (m *MemoryStore) Entries(telegramID int64) ([]Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := []Entry{}
+	for _, entry := range m.entries {
+		if entry.TelegramID == telegramID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (m *MemoryStore) DeleteEntries(ids ...int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deleteEntries(ids)
+	return nil
+}
+
+func (m *MemoryStore) QueueDigest(notification Notification, ids ...int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastID++
+	notification.ID = m.lastID
+	if notification.Created.IsZero() {
+		notification.Created = time.Now()
+	}
+	m.notifications[notification.ID] = notification
+	m.deleteEntries(ids)
+	return nil
+}
+
+func (m *MemoryStore) deleteEntries(ids []int64) {
+	deleted := map[int64]bool{}
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	entries := m.entries[:0]
+	for _, entry := range m.entries {
+		if !deleted[entry.ID] {
+			entries = append(entries, entry)
+		}
+	}
+	m.entries = entries
+}
+
+type entryRow struct {
+	ID         int64  `db:"id"`
+	TelegramID int64  `db:"telegram_id"`
+	ChatID     int64  `db:"chat_id"`
+	Lang       string `db:"lang"`
+	Author     string `db:"author"`
+	ShoppingID int    `db:"shopping_id"`
+	Shop       string `db:"shop"`
+	Date       string `db:"date"`
+	Item       string `db:"item"`
+	Due        int64  `db:"due"`
+	Created    int64  `db:"created"`
+}
+
+func (s *SQLiteStore) AddEntries(entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	builder := squirrel.
+		Insert(digestDB).
+		Columns(
+			"telegram_id", "chat_id", "lang", "author", "shopping_id",
+			"shop", "date", "item", "due", "created",
+		)
+	now := time.Now()
+	for _, e := range entries {
+		created := e.Created
+		if created.IsZero() {
+			created = now
+		}
+		builder = builder.Values(
+			e.TelegramID, e.ChatID, string(e.Lang), e.Author, e.ShoppingID,
+			e.Shop, e.Date, e.Item, e.Due.UnixNano(), created.UnixNano(),
+		)
+	}
+	q, args, err := builder.PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (s *SQLiteStore) DueDigests(now time.Time) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := squirrel.
+		Select("telegram_id").
+		Distinct().
+		From(digestDB).
+		Where(squirrel.LtOrEq{"due": now.UnixNano()}).
+		OrderBy("telegram_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := []int64{}
+	err = s.db.SelectContext(ctx, &recipients, q, args...)
+	return recipients, err
+}
+
+func (s *SQLiteStore) Entries(telegramID int64) ([]Entry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := squirrel.
+		Select(
+			"id", "telegram_id", "chat_id", "lang", "author", "shopping_id",
+			"shop", "date", "item", "due", "created",
+		).
+		From(digestDB).
+		Where(squirrel.Eq{"telegram_id": telegramID}).
+		OrderBy("id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []entryRow{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(rows))
+	for _, r := range rows {
+		entries = append(entries, Entry{
+			ID:         r.ID,
+			TelegramID: r.TelegramID,
+			ChatID:     r.ChatID,
+			Lang:       i18n.Lang(r.Lang),
+			Author:     r.Author,
+			ShoppingID: r.ShoppingID,
+			Shop:       r.Shop,
+			Date:       r.Date,
+			Item:       r.Item,
+			Due:        time.Unix(0, r.Due),
+			Created:    time.Unix(0, r.Created),
+		})
+	}
+	return entries, nil
+}
+
+func (s *SQLiteStore) DeleteEntries(ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := deleteEntries(ids).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (s *SQLiteStore) QueueDigest(notification Notification, ids ...int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q, args, err := insertNotifications([]Notification{notification}).ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		q, args, err = deleteEntries(ids).ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func deleteEntries(ids []int64) squirrel.DeleteBuilder {
+	return squirrel.
+		Delete(digestDB).
+		Where(squirrel.Eq{"id": ids}).
+		PlaceholderFormat(squirrel.Dollar)
+}
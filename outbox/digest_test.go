@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestDue(t *testing.T) {
+	now := time.Date(2024, 10, 25, 10, 0, 0, 0, time.UTC)
+
+	require.Equal(t, now.Add(30*time.Minute), DigestDue(now, 30, "", nil))
+	require.Equal(t, now.Add(DefaultDigestMinutes*time.Minute), DigestDue(now, 0, "", nil))
+	require.Equal(t, time.Date(2024, 10, 25, 20, 0, 0, 0, time.UTC), DigestDue(now, 30, "20:00", nil))
+	// time has passed today
+	require.Equal(t, time.Date(2024, 10, 26, 9, 30, 0, 0, time.UTC), DigestDue(now, 30, "9:30", nil))
+	require.Equal(t, now.Add(30*time.Minute), DigestDue(now, 30, "bad", nil))
+
+	// time is in the user time zone, it is already the next day there
+	tokyo := time.FixedZone("JST", 9*60*60)
+	late := time.Date(2024, 10, 25, 16, 0, 0, 0, time.UTC)
+	due := DigestDue(late, 30, "9:30", tokyo)
+	require.Equal(t, time.Date(2024, 10, 26, 9, 30, 0, 0, tokyo), due)
+	require.True(t, due.Equal(time.Date(2024, 10, 26, 0, 30, 0, 0, time.UTC)))
+	require.True(t, DigestDue(now, 30, "20:00", tokyo).Equal(time.Date(2024, 10, 25, 11, 0, 0, 0, time.UTC)))
+
+	require.Equal(t, time.Local, DigestLocation(""))
+	require.Equal(t, time.Local, DigestLocation("Nowhere/Town"))
+}
+
+func TestDigestText(t *testing.T) {
+	entries := []Entry{
+		{ShoppingID: 1, Shop: consts.CurrentlistWord, Date: "2024-10-25", Item: "milk", Author: "ann"},
+		{ShoppingID: 2, Shop: "Market", Date: "2024-10-26", Item: "fish", Author: "bob"},
+		{ShoppingID: 1, Shop: consts.CurrentlistWord, Date: "2024-10-25", Item: "bread", Author: "bob"},
+	}
+
+	require.Equal(t, "🗞 Added to the lists:\n"+
+		"\n"+
+		"Current list (2024-10-25):\n"+
+		"• milk — ann\n"+
+		"• bread — bob\n"+
+		"\n"+
+		"Market (2024-10-26):\n"+
+		"• fish — bob",
+		DigestText(i18n.EN, entries))
+}
+
+func TestDigester(t *testing.T) {
+	sqliteStore, err := NewSQLiteStore(filepath.Join(t.TempDir(), "outbox.db"))
+	require.NoError(t, err)
+
+	queues := map[string]Queue{
+		"memory": NewMemoryStore(),
+		"sqlite": sqliteStore,
+	}
+
+	now := time.Date(2024, 10, 25, 10, 0, 0, 0, time.UTC)
+	for name, q := range queues {
+		queue := q
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, queue.AddEntries(
+				Entry{TelegramID: 1, ChatID: 10, Lang: i18n.EN, ShoppingID: 5, Shop: "Market", Item: "milk", Due: now},
+				Entry{TelegramID: 2, ChatID: 20, Lang: i18n.RU, ShoppingID: 5, Shop: "Market", Item: "milk", Due: now.Add(time.Hour)},
+				Entry{TelegramID: 1, ChatID: 10, Lang: i18n.EN, ShoppingID: 5, Shop: "Market", Item: "bread", Due: now.Add(time.Hour)},
+			))
+
+			digester := NewDigester(queue)
+			digester.now = func() time.Time { return now }
+			require.Equal(t, 1, digester.Flush())
+			// entries are queued once
+			require.Equal(t, 0, digester.Flush())
+
+			due, err := queue.Due(now, 10)
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+			require.Equal(t, int64(10), due[0].ChatID)
+			require.Contains(t, due[0].Text, "milk")
+			require.Contains(t, due[0].Text, "bread")
+
+			entries, err := queue.Entries(1)
+			require.NoError(t, err)
+			require.Empty(t, entries)
+			entries, err = queue.Entries(2)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+		})
+	}
+}
+
+func TestItemsText(t *testing.T) {
+	require.Equal(t, "User ann(1) added 'milk' to 'Market'(2024-10-25)",
+		ItemsText(i18n.EN, "ann", 1, "Market", "2024-10-25", []string{"milk"}))
+	require.Equal(t, "User ann(1) added 7 items to 'Current list'(2024-10-25): a, b, c, d, e and 2 more",
+		ItemsText(i18n.EN, "ann", 1, consts.CurrentlistWord, "2024-10-25", []string{"a", "b", "c", "d", "e", "f", "g"}))
+}
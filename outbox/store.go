@@ -0,0 +1,353 @@
+// Package outbox keeps community notifications and digests until they are delivered
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	storeTimeout = time.Second * 5
+	outboxDB     = "outbox"
+
+	createOutboxDB = `CREATE TABLE IF NOT EXISTS outbox (
+		id           INTEGER PRIMARY KEY AUTOINCREMENT,
+		telegram_id  INTEGER NOT NULL,
+		chat_id      INTEGER NOT NULL,
+		text         TEXT NOT NULL,
+		attempts     INTEGER NOT NULL,
+		next_attempt INTEGER NOT NULL,
+		last_error   TEXT NOT NULL,
+		dead         INTEGER NOT NULL,
+		created      INTEGER NOT NULL
+	)`
+	createOutboxIndex = `CREATE INDEX IF NOT EXISTS outbox_due ON outbox (dead, next_attempt)`
+)
+
+// Notification is the message to the user chat
+type Notification struct {
+	ID         int64
+	TelegramID int64
+	ChatID     int64
+	Text       string
+	// Attempts is the count of failed deliveries
+	Attempts    int
+	NextAttempt time.Time
+	LastError   string
+	// Dead notifications are not delivered anymore
+	Dead    bool
+	Created time.Time
+}
+
+// Store keeps notifications, delivered ones are deleted, failed ones
+// are retried or become dead letters
+type Store interface {
+	Add(notifications ...Notification) error
+	// Due returns notifications to deliver at the time, the oldest first
+	Due(now time.Time, limit int) ([]Notification, error)
+	Delete(id int64) error
+	// Retry increments attempts and postpones the notification
+	Retry(id int64, next time.Time, lastErr string) error
+	// Dead turns the notification into dead letter
+	Dead(id int64, lastErr string) error
+	DeadLetters() ([]Notification, error)
+}
+
+// MemoryStore keeps notifications in memory, they are lost on restart
+type MemoryStore struct {
+	mu            sync.Mutex
+	lastID        int64
+	notifications map[int64]Notification
+	entries       []Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		notifications: map[int64]Notification{},
+	}
+}
+
+func (m *MemoryStore) Add(notifications ...Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, notification := range notifications {
+		m.lastID++
+		notification.ID = m.lastID
+		if notification.Created.IsZero() {
+			notification.Created = time.Now()
+		}
+		m.notifications[notification.ID] = notification
+	}
+	return nil
+}
+
+func (m *MemoryStore) Due(now time.Time, limit int) ([]Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.filter(func(n Notification) bool {
+		return !n.Dead && !n.NextAttempt.After(now)
+	}, limit), nil
+}
+
+func (m *MemoryStore) Delete(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.notifications, id)
+	return nil
+}
+
+func (m *MemoryStore) Retry(id int64, next time.Time, lastErr string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	notification, ok := m.notifications[id]
+	if !ok {
+		return nil
+	}
+	notification.Attempts++
+	notification.NextAttempt = next
+	notification.LastError = lastErr
+	m.notifications[id] = notification
+	return nil
+}
+
+func (m *MemoryStore) Dead(id int64, lastErr string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	notification, ok := m.notifications[id]
+	if !ok {
+		return nil
+	}
+	notification.Attempts++
+	notification.Dead = true
+	notification.LastError = lastErr
+	m.notifications[id] = notification
+	return nil
+}
+
+func (m *MemoryStore) DeadLetters() ([]Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.filter(func(n Notification) bool {
+		return n.Dead
+	}, 0), nil
+}
+
+// filter must be called with locked store, zero limit means all notifications
+func (m *MemoryStore) filter(match func(n Notification) bool, limit int) []Notification {
+	result := []Notification{}
+	for _, notification := range m.notifications {
+		if match(notification) {
+			result = append(result, notification)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].NextAttempt.Equal(result[j].NextAttempt) {
+			return result[i].NextAttempt.Before(result[j].NextAttempt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// SQLiteStore keeps notifications in the sqlite database
+type SQLiteStore struct {
+	db *sqlx.DB
+}
+
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	db, err := sqlx.Connect("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return newSQLiteStore(db)
+}
+
+// NewSQLiteStoreDB keeps notifications in the opened database, so the store
+// shares the connection pool with the other tables of the database
+func NewSQLiteStoreDB(db *sql.DB) (*SQLiteStore, error) {
+	return newSQLiteStore(sqlx.NewDb(db, "sqlite3"))
+}
+
+func newSQLiteStore(db *sqlx.DB) (*SQLiteStore, error) {
+	for _, q := range []string{createOutboxDB, createOutboxIndex, createDigestDB, createDigestIndex} {
+		if _, err := db.Exec(q); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SQLiteStore{
+		db: db,
+	}, nil
+}
+
+type notificationRow struct {
+	ID          int64  `db:"id"`
+	TelegramID  int64  `db:"telegram_id"`
+	ChatID      int64  `db:"chat_id"`
+	Text        string `db:"text"`
+	Attempts    int    `db:"attempts"`
+	NextAttempt int64  `db:"next_attempt"`
+	LastError   string `db:"last_error"`
+	Dead        bool   `db:"dead"`
+	Created     int64  `db:"created"`
+}
+
+func (r notificationRow) notification() Notification {
+	return Notification{
+		ID:          r.ID,
+		TelegramID:  r.TelegramID,
+		ChatID:      r.ChatID,
+		Text:        r.Text,
+		Attempts:    r.Attempts,
+		NextAttempt: time.Unix(0, r.NextAttempt),
+		LastError:   r.LastError,
+		Dead:        r.Dead,
+		Created:     time.Unix(0, r.Created),
+	}
+}
+
+func (s *SQLiteStore) Add(notifications ...Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := insertNotifications(notifications).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+func insertNotifications(notifications []Notification) squirrel.InsertBuilder {
+	builder := squirrel.
+		Insert(outboxDB).
+		Columns(
+			"telegram_id", "chat_id", "text", "attempts",
+			"next_attempt", "last_error", "dead", "created",
+		)
+	now := time.Now()
+	for _, n := range notifications {
+		created := n.Created
+		if created.IsZero() {
+			created = now
+		}
+		builder = builder.Values(
+			n.TelegramID, n.ChatID, n.Text, n.Attempts,
+			n.NextAttempt.UnixNano(), n.LastError, n.Dead, created.UnixNano(),
+		)
+	}
+	return builder.PlaceholderFormat(squirrel.Dollar)
+}
+
+func (s *SQLiteStore) Due(now time.Time, limit int) ([]Notification, error) {
+	return s.selectWhere(squirrel.And{
+		squirrel.Eq{"dead": false},
+		squirrel.LtOrEq{"next_attempt": now.UnixNano()},
+	}, limit)
+}
+
+func (s *SQLiteStore) DeadLetters() ([]Notification, error) {
+	return s.selectWhere(squirrel.Eq{"dead": true}, 0)
+}
+
+func (s *SQLiteStore) selectWhere(where squirrel.Sqlizer, limit int) ([]Notification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	builder := squirrel.
+		Select(
+			"id", "telegram_id", "chat_id", "text", "attempts",
+			"next_attempt", "last_error", "dead", "created",
+		).
+		From(outboxDB).
+		Where(where).
+		OrderBy("next_attempt", "id")
+	if limit > 0 {
+		builder = builder.Limit(uint64(limit))
+	}
+	q, args, err := builder.PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []notificationRow{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	notifications := make([]Notification, 0, len(rows))
+	for _, row := range rows {
+		notifications = append(notifications, row.notification())
+	}
+	return notifications, nil
+}
+
+func (s *SQLiteStore) Delete(id int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := squirrel.
+		Delete(outboxDB).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (s *SQLiteStore) Retry(id int64, next time.Time, lastErr string) error {
+	return s.update(id, map[string]interface{}{
+		"attempts":     squirrel.Expr("attempts + 1"),
+		"next_attempt": next.UnixNano(),
+		"last_error":   lastErr,
+	})
+}
+
+func (s *SQLiteStore) Dead(id int64, lastErr string) error {
+	return s.update(id, map[string]interface{}{
+		"attempts":   squirrel.Expr("attempts + 1"),
+		"dead":       true,
+		"last_error": lastErr,
+	})
+}
+
+func (s *SQLiteStore) update(id int64, values map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := squirrel.
+		Update(outboxDB).
+		SetMap(values).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, q, args...)
+	return err
+}
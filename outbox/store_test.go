@@ -0,0 +1,74 @@
+package outbox_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/outbox"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	sqliteStore, err := outbox.NewSQLiteStore(filepath.Join(t.TempDir(), "outbox.db"))
+	require.NoError(t, err)
+
+	stores := map[string]outbox.Store{
+		"memory": outbox.NewMemoryStore(),
+		"sqlite": sqliteStore,
+	}
+
+	now := time.Now()
+	for name, s := range stores {
+		store := s
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Add(
+				outbox.Notification{TelegramID: 1, ChatID: 10, Text: "first", NextAttempt: now},
+				outbox.Notification{TelegramID: 2, ChatID: 20, Text: "second", NextAttempt: now},
+				outbox.Notification{TelegramID: 3, ChatID: 30, Text: "later", NextAttempt: now.Add(time.Hour)},
+			))
+
+			due, err := store.Due(now, 10)
+			require.NoError(t, err)
+			require.Len(t, due, 2)
+			require.Equal(t, "first", due[0].Text)
+			require.Equal(t, int64(10), due[0].ChatID)
+			require.Equal(t, int64(1), due[0].TelegramID)
+
+			due, err = store.Due(now, 1)
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+
+			// first is delivered, second is postponed
+			require.NoError(t, store.Delete(due[0].ID))
+			due, err = store.Due(now, 10)
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+			require.NoError(t, store.Retry(due[0].ID, now.Add(time.Minute), "timeout"))
+
+			due, err = store.Due(now, 10)
+			require.NoError(t, err)
+			require.Empty(t, due)
+
+			due, err = store.Due(now.Add(time.Minute), 10)
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+			require.Equal(t, 1, due[0].Attempts)
+			require.Equal(t, "timeout", due[0].LastError)
+
+			// dead letters are not due anymore
+			require.NoError(t, store.Dead(due[0].ID, "blocked"))
+			dead, err := store.DeadLetters()
+			require.NoError(t, err)
+			require.Len(t, dead, 1)
+			require.Equal(t, "second", dead[0].Text)
+			require.Equal(t, 2, dead[0].Attempts)
+			require.True(t, dead[0].Dead)
+
+			due, err = store.Due(now.Add(time.Hour), 10)
+			require.NoError(t, err)
+			require.Len(t, due, 1)
+			require.Equal(t, "later", due[0].Text)
+		})
+	}
+}
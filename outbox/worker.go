@@ -0,0 +1,196 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/telegram"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// blockedPrefix starts telegram error descriptions when the user blocked
+// the bot or deleted the account
+const blockedPrefix = "Forbidden"
+
+// Config is the delivery config, telegram allows about one message per second
+// to the chat and 30 messages per second overall
+type Config struct {
+	// Interval is the polling interval of the due notifications
+	Interval time.Duration
+	Batch    int
+	// MaxAttempts failed deliveries turn the notification into dead letter
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	// ChatInterval is the minimal interval between messages to the chat
+	ChatInterval time.Duration
+	// GlobalRate is the max count of messages per second
+	GlobalRate int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Interval:     time.Second,
+		Batch:        100,
+		MaxAttempts:  8,
+		MinBackoff:   time.Second * 5,
+		MaxBackoff:   time.Hour,
+		ChatInterval: time.Second,
+		GlobalRate:   30,
+	}
+}
+
+// DeactivateFunc marks the user who blocked the bot as inactive
+type DeactivateFunc func(telegramID int64) error
+
+// Worker delivers due notifications with rate limits, failed ones are retried
+// with exponential backoff
+type Worker struct {
+	store      Store
+	sender     telegram.Sender
+	config     Config
+	deactivate DeactivateFunc
+	now        func() time.Time
+
+	mu       sync.Mutex
+	lastSent map[int64]time.Time // index by chatID
+	sent     []time.Time         // sent times of the last second
+}
+
+// NewWorker returns worker, deactivate may be nil
+func NewWorker(store Store, sender telegram.Sender, config Config, deactivate DeactivateFunc) *Worker {
+	return &Worker{
+		store:      store,
+		sender:     sender,
+		config:     config,
+		deactivate: deactivate,
+		now:        time.Now,
+		lastSent:   map[int64]time.Time{},
+	}
+}
+
+// Run delivers notifications until the context is done
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.Flush()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush delivers due notifications once, notifications to the chats over the
+// limit are left for the next flush. It returns count of the sent notifications.
+func (w *Worker) Flush() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	for chatID, last := range w.lastSent {
+		if now.Sub(last) >= w.config.ChatInterval {
+			delete(w.lastSent, chatID)
+		}
+	}
+
+	due, err := w.store.Due(now, w.config.Batch)
+	if err != nil {
+		log.Printf("outbox due notifications error=%v\n", err)
+		return 0
+	}
+
+	sent := 0
+	for _, notification := range due {
+		if !w.allowGlobal(now) {
+			break
+		}
+		if !w.allowChat(notification.ChatID, now) {
+			continue
+		}
+
+		w.lastSent[notification.ChatID] = now
+		w.sent = append(w.sent, now)
+		_, err := w.sender.Send(tgbotapi.NewMessage(notification.ChatID, notification.Text))
+		if err == nil {
+			sent++
+			if err := w.store.Delete(notification.ID); err != nil {
+				log.Printf("outbox delete id=%d error=%v\n", notification.ID, err)
+			}
+			continue
+		}
+		w.fail(notification, err, now)
+	}
+	return sent
+}
+
+// fail retries the notification or turns it into dead letter
+func (w *Worker) fail(notification Notification, sendErr error, now time.Time) {
+	log.Printf("outbox send id=%d chat_id=%d attempt=%d error=%v\n",
+		notification.ID, notification.ChatID, notification.Attempts+1, sendErr)
+
+	var err error
+	switch {
+	case isBlocked(sendErr):
+		err = w.store.Dead(notification.ID, sendErr.Error())
+		if w.deactivate != nil {
+			if err := w.deactivate(notification.TelegramID); err != nil {
+				log.Printf("outbox deactivate telegram_id=%d error=%v\n", notification.TelegramID, err)
+			}
+		}
+	case notification.Attempts+1 >= w.config.MaxAttempts:
+		err = w.store.Dead(notification.ID, sendErr.Error())
+	default:
+		err = w.store.Retry(notification.ID, now.Add(w.backoff(notification.Attempts+1, sendErr)), sendErr.Error())
+	}
+	if err != nil {
+		log.Printf("outbox update id=%d error=%v\n", notification.ID, err)
+	}
+}
+
+// backoff returns delay before the next attempt, telegram may tell it itself
+func (w *Worker) backoff(attempts int, err error) time.Duration {
+	var apiErr tgbotapi.Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return time.Duration(apiErr.RetryAfter) * time.Second
+	}
+
+	delay := w.config.MinBackoff
+	for i := 1; i < attempts && delay < w.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.config.MaxBackoff {
+		delay = w.config.MaxBackoff
+	}
+	return delay
+}
+
+func (w *Worker) allowChat(chatID int64, now time.Time) bool {
+	last, ok := w.lastSent[chatID]
+	return !ok || now.Sub(last) >= w.config.ChatInterval
+}
+
+func (w *Worker) allowGlobal(now time.Time) bool {
+	if w.config.GlobalRate <= 0 {
+		return true
+	}
+	// keep only the last second
+	i := 0
+	for i < len(w.sent) && now.Sub(w.sent[i]) >= time.Second {
+		i++
+	}
+	w.sent = w.sent[i:]
+	return len(w.sent) < w.config.GlobalRate
+}
+
+func isBlocked(err error) bool {
+	var apiErr tgbotapi.Error
+	return errors.As(err, &apiErr) && strings.HasPrefix(apiErr.Message, blockedPrefix)
+}
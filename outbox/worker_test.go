@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender fails sending to the chats with errors
+type fakeSender struct {
+	errs map[int64]error
+	sent []tgbotapi.MessageConfig
+}
+
+func (f *fakeSender) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	msg := c.(tgbotapi.MessageConfig)
+	if err := f.errs[msg.ChatID]; err != nil {
+		return tgbotapi.Message{}, err
+	}
+	f.sent = append(f.sent, msg)
+	return tgbotapi.Message{MessageID: len(f.sent)}, nil
+}
+
+func (f *fakeSender) AnswerCallbackQuery(tgbotapi.CallbackConfig) (tgbotapi.APIResponse, error) {
+	return tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func (f *fakeSender) DeleteMessage(tgbotapi.DeleteMessageConfig) (tgbotapi.APIResponse, error) {
+	return tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func newTestWorker(sender *fakeSender, config Config, deactivate DeactivateFunc) (*Worker, *MemoryStore, *time.Time) {
+	store := NewMemoryStore()
+	worker := NewWorker(store, sender, config, deactivate)
+	now := time.Date(2024, 10, 25, 10, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+	return worker, store, &now
+}
+
+func TestWorkerRateLimit(t *testing.T) {
+	sender := &fakeSender{}
+	config := DefaultConfig()
+	config.GlobalRate = 2
+	worker, store, now := newTestWorker(sender, config, nil)
+
+	require.NoError(t, store.Add(
+		Notification{ChatID: 1, Text: "a", NextAttempt: *now},
+		Notification{ChatID: 1, Text: "b", NextAttempt: *now},
+		Notification{ChatID: 2, Text: "c", NextAttempt: *now},
+		Notification{ChatID: 3, Text: "d", NextAttempt: *now},
+	))
+
+	// the second message to the chat 1 waits for the chat interval,
+	// the chat 3 waits for the global limit
+	require.Equal(t, 2, worker.Flush())
+	require.Equal(t, "a", sender.sent[0].Text)
+	require.Equal(t, "c", sender.sent[1].Text)
+	require.Equal(t, 0, worker.Flush())
+
+	*now = now.Add(time.Second)
+	require.Equal(t, 2, worker.Flush())
+	require.Equal(t, "b", sender.sent[2].Text)
+	require.Equal(t, "d", sender.sent[3].Text)
+
+	due, err := store.Due(now.Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
+
+func TestWorkerBackoff(t *testing.T) {
+	sender := &fakeSender{errs: map[int64]error{1: errors.New("timeout")}}
+	config := DefaultConfig()
+	config.MaxAttempts = 3
+	worker, store, now := newTestWorker(sender, config, nil)
+
+	require.NoError(t, store.Add(Notification{ChatID: 1, Text: "a", NextAttempt: *now}))
+
+	require.Equal(t, 0, worker.Flush())
+	due, err := store.Due(now.Add(config.MinBackoff), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, 1, due[0].Attempts)
+	require.Equal(t, now.Add(config.MinBackoff), due[0].NextAttempt)
+
+	// the second delay is doubled
+	*now = now.Add(config.MinBackoff)
+	require.Equal(t, 0, worker.Flush())
+	due, err = store.Due(now.Add(2*config.MinBackoff), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, now.Add(2*config.MinBackoff), due[0].NextAttempt)
+
+	// telegram retry after is used when it is set
+	sender.errs[1] = tgbotapi.Error{Message: "Too Many Requests", ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 60}}
+	*now = now.Add(2 * config.MinBackoff)
+	require.Equal(t, 0, worker.Flush())
+
+	// the last attempt makes dead letter
+	dead, err := store.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, 3, dead[0].Attempts)
+	require.Equal(t, "Too Many Requests", dead[0].LastError)
+
+	require.Equal(t, time.Minute, worker.backoff(1, sender.errs[1]))
+	require.Equal(t, config.MaxBackoff, worker.backoff(100, errors.New("timeout")))
+}
+
+func TestWorkerBlocked(t *testing.T) {
+	sender := &fakeSender{errs: map[int64]error{
+		1: tgbotapi.Error{Message: "Forbidden: bot was blocked by the user"},
+	}}
+	deactivated := []int64{}
+	worker, store, now := newTestWorker(sender, DefaultConfig(), func(telegramID int64) error {
+		deactivated = append(deactivated, telegramID)
+		return nil
+	})
+
+	require.NoError(t, store.Add(
+		Notification{TelegramID: 7, ChatID: 1, Text: "a", NextAttempt: *now},
+		Notification{TelegramID: 8, ChatID: 2, Text: "b", NextAttempt: *now},
+	))
+
+	require.Equal(t, 1, worker.Flush())
+	require.Equal(t, []int64{7}, deactivated)
+
+	dead, err := store.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, int64(7), dead[0].TelegramID)
+}
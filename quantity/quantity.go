@@ -0,0 +1,115 @@
+// Package quantity parses item quantity and unit from the free text,
+// e.g. "молоко 2л", "яйца x10" or "3 батона"
+package quantity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxQuantity is the max parsed quantity, bigger numbers are kept in the name
+const MaxQuantity = 9999
+
+// units are the known units by their spellings, pieces are the default unit
+var units = map[string]string{
+	"шт":     "",
+	"штук":   "",
+	"штуки":  "",
+	"штука":  "",
+	"pcs":    "",
+	"л":      "л",
+	"литр":   "л",
+	"литра":  "л",
+	"литров": "л",
+	"мл":     "мл",
+	"кг":     "кг",
+	"г":      "г",
+	"гр":     "г",
+	"уп":     "уп",
+	"упак":   "уп",
+	"пач":    "уп",
+	"l":      "l",
+	"ml":     "ml",
+	"kg":     "kg",
+	"g":      "g",
+	"gr":     "g",
+}
+
+var (
+	unitPattern = unitAlternation()
+
+	// "яйца x10", "яйца х10", "яйца ×10", "яйца *10"
+	patternTimes = regexp.MustCompile(`^(.+?)\s+[xх×*]\s*(\d+)$`)
+	// "молоко 2л", "молоко 2 л.", "яйца 10"
+	patternSuffix = regexp.MustCompile(`(?i)^(.+?)\s+(\d+)\s*(` + unitPattern + `)?\.?$`)
+	// "3 батона", "2л молока", "2 кг. картошки"
+	patternPrefix = regexp.MustCompile(`(?i)^(\d+)\s*(` + unitPattern + `)?\.?\s+(.+)$`)
+
+	spaces = regexp.MustCompile(`\s+`)
+)
+
+// unitAlternation returns regexp alternation of the unit spellings, the longest first
+func unitAlternation() string {
+	spellings := make([]string, 0, len(units))
+	for spelling := range units {
+		spellings = append(spellings, regexp.QuoteMeta(spelling))
+	}
+	// longer spellings are tried first, e.g. "литра" before "л"
+	sort.Slice(spellings, func(i, j int) bool {
+		if len(spellings[i]) != len(spellings[j]) {
+			return len(spellings[i]) > len(spellings[j])
+		}
+		return spellings[i] < spellings[j]
+	})
+	return strings.Join(spellings, "|")
+}
+
+// Parse returns product name, quantity and unit of the item text,
+// text without quantity is the name with quantity 1
+func Parse(text string) (name string, quantity int, unit string) {
+	text = strings.TrimSpace(spaces.ReplaceAllString(text, " "))
+
+	if m := patternTimes.FindStringSubmatch(text); m != nil {
+		if q, ok := parseQuantity(m[2]); ok {
+			return m[1], q, ""
+		}
+	}
+	if m := patternSuffix.FindStringSubmatch(text); m != nil {
+		if q, ok := parseQuantity(m[2]); ok {
+			return m[1], q, normalizeUnit(m[3])
+		}
+	}
+	if m := patternPrefix.FindStringSubmatch(text); m != nil {
+		if q, ok := parseQuantity(m[1]); ok {
+			return m[3], q, normalizeUnit(m[2])
+		}
+	}
+	return text, 1, ""
+}
+
+func parseQuantity(s string) (int, bool) {
+	q, err := strconv.Atoi(s)
+	if err != nil || q <= 0 || q > MaxQuantity {
+		return 0, false
+	}
+	return q, true
+}
+
+func normalizeUnit(spelling string) string {
+	return units[strings.ToLower(spelling)]
+}
+
+// Format returns item text which is parsed back to the same name,
+// quantity and unit, e.g. "молоко 2л" or "яйца x10"
+func Format(name string, quantity int, unit string) string {
+	switch {
+	case unit != "":
+		return fmt.Sprintf("%s %d%s", name, quantity, unit)
+	case quantity > 1:
+		return fmt.Sprintf("%s x%d", name, quantity)
+	}
+	return name
+}
@@ -0,0 +1,61 @@
+package quantity_test
+
+import (
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/quantity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text        string
+		expName     string
+		expQuantity int
+		expUnit     string
+	}{
+		{text: "молоко", expName: "молоко", expQuantity: 1},
+		{text: "  молоко  ", expName: "молоко", expQuantity: 1},
+		{text: "молоко 2л", expName: "молоко", expQuantity: 2, expUnit: "л"},
+		{text: "молоко 2 Л.", expName: "молоко", expQuantity: 2, expUnit: "л"},
+		{text: "сок 3 литра", expName: "сок", expQuantity: 3, expUnit: "л"},
+		{text: "яйца x10", expName: "яйца", expQuantity: 10},
+		{text: "яйца х10", expName: "яйца", expQuantity: 10},
+		{text: "яйца × 10", expName: "яйца", expQuantity: 10},
+		{text: "яйца 10", expName: "яйца", expQuantity: 10},
+		{text: "яйца 10 шт", expName: "яйца", expQuantity: 10},
+		{text: "3 батона", expName: "батона", expQuantity: 3},
+		{text: "3 лимона", expName: "лимона", expQuantity: 3},
+		{text: "2кг картошки", expName: "картошки", expQuantity: 2, expUnit: "кг"},
+		{text: "сыр 200г", expName: "сыр", expQuantity: 200, expUnit: "г"},
+		{text: "milk 2 l", expName: "milk", expQuantity: 2, expUnit: "l"},
+		{text: "молоко 3.2%", expName: "молоко 3.2%", expQuantity: 1},
+		{text: "7up", expName: "7up", expQuantity: 1},
+		{text: "батарейки 0", expName: "батарейки 0", expQuantity: 1},
+		{text: "100000 рублей", expName: "100000 рублей", expQuantity: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+			name, q, unit := quantity.Parse(tt.text)
+			require.Equal(t, tt.expName, name)
+			require.Equal(t, tt.expQuantity, q)
+			require.Equal(t, tt.expUnit, unit)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "молоко", quantity.Format("молоко", 1, ""))
+	require.Equal(t, "молоко 1л", quantity.Format("молоко", 1, "л"))
+	require.Equal(t, "яйца x10", quantity.Format("яйца", 10, ""))
+
+	// formatted text is parsed back
+	for _, text := range []string{"молоко 2л", "яйца x10", "хлеб"} {
+		name, q, unit := quantity.Parse(text)
+		require.Equal(t, text, quantity.Format(name, q, unit))
+	}
+}
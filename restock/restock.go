@@ -0,0 +1,129 @@
+// Package restock predicts the products which are due to buy again by the
+// purchase history, e.g. milk bought every 7 days is due in a week after the
+// last purchase.
+package restock
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/category"
+)
+
+const (
+	// MinPurchases is the count of the purchase days to estimate the interval
+	MinPurchases = 3
+	// StaleFactor is the count of the missed intervals after which the
+	// product is considered not bought anymore
+	StaleFactor = 3
+
+	day = 24 * time.Hour
+)
+
+// Purchase is the item bought on the shopping date
+type Purchase struct {
+	ItemID int
+	Name   string
+	Date   time.Time
+}
+
+// Suggestion is the product which is due to buy again
+type Suggestion struct {
+	// ItemID is the item of the last purchase
+	ItemID int
+	// Name is the product name of the last purchase
+	Name string
+	// Interval is the typical interval between the purchases
+	Interval time.Duration
+	// Last is the day of the last purchase
+	Last time.Time
+}
+
+// Overdue returns the count of the intervals passed since the last purchase
+func (s Suggestion) Overdue(now time.Time) float64 {
+	return float64(now.Sub(s.Last)) / float64(s.Interval)
+}
+
+// Due returns the products whose typical interval has passed since the last
+// purchase, the most overdue first. Purchases of the same product on the same
+// day are counted once, the products bought rarely or long ago are skipped.
+func Due(history []Purchase, now time.Time) []Suggestion {
+	byName := map[string][]Purchase{}
+	for _, v := range history {
+		if v.Date.After(now) {
+			continue
+		}
+		name := category.Normalize(v.Name)
+		byName[name] = append(byName[name], v)
+	}
+
+	suggestions := []Suggestion{}
+	for _, purchases := range byName {
+		suggestion, ok := predict(purchases)
+		if !ok {
+			continue
+		}
+		overdue := suggestion.Overdue(now)
+		if overdue < 1 || overdue > StaleFactor {
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		oi, oj := suggestions[i].Overdue(now), suggestions[j].Overdue(now)
+		if oi != oj {
+			return oi > oj
+		}
+		return suggestions[i].Name < suggestions[j].Name
+	})
+	return suggestions
+}
+
+// predict returns the typical interval of the product purchases, it is the
+// median of the intervals between the purchase days
+func predict(purchases []Purchase) (Suggestion, bool) {
+	sort.SliceStable(purchases, func(i, j int) bool {
+		return purchases[i].Date.Before(purchases[j].Date)
+	})
+
+	days := []time.Time{}
+	for _, v := range purchases {
+		date := truncateDay(v.Date)
+		if len(days) == 0 || !days[len(days)-1].Equal(date) {
+			days = append(days, date)
+		}
+	}
+	if len(days) < MinPurchases {
+		return Suggestion{}, false
+	}
+
+	intervals := make([]time.Duration, 0, len(days)-1)
+	for i := 1; i < len(days); i++ {
+		intervals = append(intervals, days[i].Sub(days[i-1]))
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+	interval := intervals[len(intervals)/2]
+	if len(intervals)%2 == 0 {
+		interval = (intervals[len(intervals)/2-1] + interval) / 2
+	}
+	// intervals are counted in days
+	interval = interval.Round(day)
+	if interval < day {
+		interval = day
+	}
+
+	last := purchases[len(purchases)-1]
+	return Suggestion{
+		ItemID:   last.ItemID,
+		Name:     last.Name,
+		Interval: interval,
+		Last:     days[len(days)-1],
+	}, true
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
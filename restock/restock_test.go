@@ -0,0 +1,57 @@
+package restock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/restock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+
+	history := []restock.Purchase{
+		// every week, the last purchase 8 days ago
+		{ItemID: 1, Name: "Молоко", Date: daysAgo(22)},
+		{ItemID: 2, Name: "молоко", Date: daysAgo(15)},
+		{ItemID: 3, Name: "МОЛОКО", Date: daysAgo(8)},
+		{ItemID: 4, Name: "Молоко", Date: daysAgo(8)},
+		// every 3 days, the last purchase 5 days ago
+		{ItemID: 5, Name: "хлеб", Date: daysAgo(11)},
+		{ItemID: 6, Name: "хлеб", Date: daysAgo(8)},
+		{ItemID: 7, Name: "Хлеб", Date: daysAgo(5)},
+		// every 10 days, not due yet
+		{ItemID: 8, Name: "сыр", Date: daysAgo(25)},
+		{ItemID: 9, Name: "сыр", Date: daysAgo(15)},
+		{ItemID: 10, Name: "сыр", Date: daysAgo(5)},
+		// every day, not bought for a long time
+		{ItemID: 11, Name: "кефир", Date: daysAgo(32)},
+		{ItemID: 12, Name: "кефир", Date: daysAgo(31)},
+		{ItemID: 13, Name: "кефир", Date: daysAgo(30)},
+		// bought on two days only
+		{ItemID: 14, Name: "чай", Date: daysAgo(40)},
+		{ItemID: 15, Name: "чай", Date: daysAgo(20)},
+		{ItemID: 16, Name: "чай", Date: daysAgo(20)},
+		// planned purchases are skipped
+		{ItemID: 17, Name: "хлеб", Date: now.AddDate(0, 0, 1)},
+	}
+
+	suggestions := restock.Due(history, now)
+	require.Len(t, suggestions, 2)
+
+	require.Equal(t, "Хлеб", suggestions[0].Name)
+	require.Equal(t, 7, suggestions[0].ItemID)
+	require.Equal(t, 3*24*time.Hour, suggestions[0].Interval)
+
+	require.Equal(t, 4, suggestions[1].ItemID)
+	require.Equal(t, 7*24*time.Hour, suggestions[1].Interval)
+	require.Equal(t, time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC), suggestions[1].Last)
+
+	require.Empty(t, restock.Due(nil, now))
+}
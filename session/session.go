@@ -2,17 +2,31 @@ package session
 
 import (
 	"log"
+	"sync"
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
 	"github.com/Frosin/shoplist-telegram-bot/shoplist"
+	"github.com/Frosin/shoplist-telegram-bot/telegram"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
 const (
-	sessionLiveTime = time.Minute * 3
+	DefaultLiveTime = time.Minute * 3
+	// DefaultStateTTL is the time the state of the expired session is kept,
+	// so the session is restored by the reopen button or after restart
+	DefaultStateTTL = time.Hour * 24 * 7
 )
 
+// ReopenFunc returns callback data which shows the last node of the expired session again
+type ReopenFunc func(item *SessionItem) string
+
+// RefreshFunc re-renders the last message of the locked session item
+type RefreshFunc func(item *SessionItem)
+
 type SessionItem struct {
 	SListAPI    *shoplist.Shoplist
 	CurrentNode string
@@ -20,42 +34,210 @@ type SessionItem struct {
 	LastMsgID   int
 	ChatID      int64
 	User        *ent.User
+	Lang        i18n.Lang
+	// ShoppingID is the shopping shown in the last message, the message
+	// is refreshed when other community members change the shopping
+	ShoppingID  int
 	removeTimer *time.Timer
-	Data        interface{} //its field may be consists any value, we need
+	states      map[string]*NodeState //index by node name
+
+	mu      sync.Mutex
+	changed map[int]bool // changed shoppings, guarded by storage mutex
+	// comunityID is the community of the user, guarded by storage mutex
+	comunityID string
 }
 
 type SessionStorage struct {
 	serviceURL string //database service URL
 	startToken string
+	mu         sync.Mutex
 	items      map[int]*SessionItem //index by telegramUserID
-	botAPI     *tgbotapi.BotAPI
+	store      Store
+	liveTime   time.Duration
+	stateTTL   time.Duration
+	reopen     ReopenFunc
+	refresh    RefreshFunc
+	bus        *events.Bus
+	botAPI     telegram.Sender
 	e          *ent.Client
 }
 
-func NewSessionStorage(serviceURL, startToken string, botAPI *tgbotapi.BotAPI, e *ent.Client) *SessionStorage {
+// NewSessionStorage returns session storage, zero liveTime means DefaultLiveTime
+func NewSessionStorage(
+	serviceURL, startToken string,
+	botAPI telegram.Sender,
+	e *ent.Client,
+	store Store,
+	liveTime time.Duration,
+) *SessionStorage {
+	if liveTime <= 0 {
+		liveTime = DefaultLiveTime
+	}
 	storage := SessionStorage{
 		serviceURL: serviceURL,
 		startToken: startToken,
 		items:      map[int]*SessionItem{},
+		store:      store,
+		liveTime:   liveTime,
+		stateTTL:   DefaultStateTTL,
+		reopen:     reopenStart,
 		botAPI:     botAPI,
 		e:          e,
 	}
 	return &storage
 }
 
+// SetReopenFunc sets function for the reopen button of the expired session
+func (s *SessionStorage) SetReopenFunc(fn ReopenFunc) {
+	s.reopen = fn
+}
+
+// SetStateTTL sets the time the state of the expired session is kept
+func (s *SessionStorage) SetStateTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stateTTL = ttl
+}
+
+// SetRefreshFunc sets function which re-renders sessions showing the shopping
+// changed by another user
+func (s *SessionStorage) SetRefreshFunc(fn RefreshFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refresh = fn
+}
+
+// SetEvents sets the bus for the shoplist events of the sessions,
+// sessions showing the shoppings changed by other users are refreshed
+func (s *SessionStorage) SetEvents(bus *events.Bus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bus = bus
+	bus.Subscribe(s.handleEvent)
+}
+
+func (s *SessionStorage) handleEvent(event events.Event) {
+	switch e := event.(type) {
+	case events.ItemAdded:
+		s.shoppingChanged(e.Author, e.ShoppingID)
+	case events.ItemsRemoved:
+		s.shoppingChanged(e.Author, e.ShoppingID)
+	case events.ItemsCopied:
+		s.shoppingChanged(e.Author, e.ShoppingID)
+		if e.FromShoppingID != 0 {
+			s.shoppingChanged(e.Author, e.FromShoppingID)
+		}
+	case events.ItemCompleted:
+		s.shoppingChanged(e.Author, e.ShoppingID)
+	case events.ItemQuantityChanged:
+		s.shoppingChanged(e.Author, e.ShoppingID)
+	case events.ItemCategoryChanged:
+		s.shoppingChanged(e.Author, e.ShoppingID)
+	case events.CommunityJoined:
+		s.communityJoined(e.Author)
+	}
+}
+
+// shoppingChanged schedules refresh of the sessions of other users of the
+// author community, changes made before the refresh are refreshed once
+func (s *SessionStorage) shoppingChanged(author events.Author, shoppingID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refresh == nil {
+		return
+	}
+
+	for _, item := range s.items {
+		if item.User.ID == author.UserID || item.comunityID != author.ComunityID {
+			continue
+		}
+		if item.changed == nil {
+			item.changed = map[int]bool{}
+			go s.refreshItem(item, s.refresh)
+		}
+		item.changed[shoppingID] = true
+	}
+}
+
+// communityJoined moves the session of the user to the new community
+func (s *SessionStorage) communityJoined(author events.Author) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item, ok := s.items[int(author.TelegramID)]; ok {
+		item.comunityID = author.ComunityID
+	}
+}
+
+// refreshItem re-renders the session if it still shows the changed shopping
+func (s *SessionStorage) refreshItem(item *SessionItem, refresh RefreshFunc) {
+	item.Lock()
+	defer item.Unlock()
+
+	s.mu.Lock()
+	changed := item.changed
+	item.changed = nil
+	// expired session shows the reopen button
+	expired := s.items[int(item.User.TelegramID)] != item
+	s.mu.Unlock()
+
+	if expired || item.LastMsgID == 0 || !changed[item.ShoppingID] {
+		return
+	}
+	refresh(item)
+}
+
+func reopenStart(item *SessionItem) string {
+	return consts.FirstPageStart
+}
+
+// deferredDeletion must be called with locked storage
 func (s *SessionStorage) deferredDeletion(item *SessionItem) {
-	item.removeTimer = time.AfterFunc(sessionLiveTime, func() {
-		// startMessage := tgbotapi.NewMessage(item.ChatID, consts.StartText) //NewEditMessageText(item.ChatID, item.LastMsgID, consts.StartText)
-		// startMessage.ReplyMarkup = tgbotapi.NewReplyKeyboard(
-		// 	[]tgbotapi.KeyboardButton{
-		// 		tgbotapi.KeyboardButton{Text: consts.MenuText},
-		// 	})
-		// s.botAPI.Send(startMessage)
+	var timer *time.Timer
+	timer = time.AfterFunc(s.liveTime, func() {
+		s.mu.Lock()
+		// session was used after timer has fired
+		if item.removeTimer != timer {
+			s.mu.Unlock()
+			return
+		}
 		delete(s.items, int(item.User.TelegramID))
+		stateTTL := s.stateTTL
+		s.mu.Unlock()
+
+		// the state is kept for the reopen button, states of the long gone users are deleted
+		if err := s.store.Prune(time.Now().Add(-stateTTL)); err != nil {
+			log.Printf("prune session states error=%v\n", err)
+		}
+		s.notifyExpired(item)
 	})
+	item.removeTimer = timer
 }
 
-func (s *SessionStorage) Add(user *tgbotapi.User, chatID int64, startNode string) (*SessionItem, error) {
+// notifyExpired replaces keyboard of the last message with the reopen button,
+// so old buttons don't silently do nothing
+func (s *SessionStorage) notifyExpired(item *SessionItem) {
+	item.Lock()
+	defer item.Unlock()
+
+	if s.botAPI == nil || item.LastMsgID == 0 {
+		return
+	}
+
+	expiredMessage := tgbotapi.NewEditMessageText(item.ChatID, item.LastMsgID, i18n.T(item.Lang, i18n.SessionExpired))
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(item.Lang, i18n.ReopenButton), s.reopen(item)),
+		),
+	)
+	expiredMessage.ReplyMarkup = &keyboard
+
+	if _, err := s.botAPI.Send(expiredMessage); err != nil {
+		log.Printf("send session expired message error=%v\n", err)
+	}
+}
+
+// newItem inits user and creates session item with start node
+func (s *SessionStorage) newItem(user *tgbotapi.User, chatID int64, startNode string) (*SessionItem, error) {
 	// base client for create user
 	client := shoplist.NewShoplistAPI(s.e, s.startToken)
 	// init user
@@ -67,15 +249,36 @@ func (s *SessionStorage) Add(user *tgbotapi.User, chatID int64, startNode string
 		s.e,
 		userData.Token,
 	)
+	s.mu.Lock()
+	newTokenClient.SetEvents(s.bus)
+	s.mu.Unlock()
 
-	// save session in storage
-	item := SessionItem{
+	return &SessionItem{
 		SListAPI:    newTokenClient,
 		CurrentNode: startNode,
 		User:        userData,
 		ChatID:      chatID,
+		comunityID:  userData.ComunityID,
+	}, nil
+}
+
+func (s *SessionStorage) Add(user *tgbotapi.User, chatID int64, startNode string) (*SessionItem, error) {
+	item, err := s.newItem(user, chatID, startNode)
+	if err != nil {
+		return nil, err
+	}
+
+	// restore state saved before restart or expiry
+	s.mu.Lock()
+	stateTTL := s.stateTTL
+	s.mu.Unlock()
+	state, err := s.store.Load(user.ID)
+	switch {
+	case err == nil && time.Since(state.Updated) < stateTTL:
+		item.setState(state)
+	case err != nil && err != ErrStateNotFound:
+		log.Printf("load session state error=%v\n", err)
 	}
-	s.items[user.ID] = &item
 
 	// hide custom keyboard
 	// msg := tgbotapi.NewMessage(item.ChatID, consts.AfterStartText)
@@ -85,9 +288,18 @@ func (s *SessionStorage) Add(user *tgbotapi.User, chatID int64, startNode string
 	// 	log.Printf("sendMsg error=%v\n", err)
 	// }
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the same user may be added concurrently
+	if existing, ok := s.items[user.ID]; ok {
+		return existing, nil
+	}
+	// save session in storage
+	s.items[user.ID] = item
+
 	// remove user session after the sessionLive interval
-	s.deferredDeletion(&item)
-	return &item, nil
+	s.deferredDeletion(item)
+	return item, nil
 }
 
 func (s *SessionStorage) Get(update tgbotapi.Update, startNode string) (*SessionItem, error) {
@@ -98,76 +310,140 @@ func (s *SessionStorage) Get(update tgbotapi.Update, startNode string) (*Session
 	switch {
 	case update.CallbackQuery != nil:
 		fromUser = update.CallbackQuery.From
-		chatID = update.CallbackQuery.Message.Chat.ID
+		// callback of the inline mode message has no message
+		if update.CallbackQuery.Message != nil {
+			chatID = update.CallbackQuery.Message.Chat.ID
+		}
 	case update.Message != nil:
 		fromUser = update.Message.From
 		chatID = update.Message.Chat.ID
 	}
 
+	s.mu.Lock()
 	item, ok := s.items[fromUser.ID]
 	if !ok {
+		s.mu.Unlock()
 		//debug
 		log.Printf("added=%v", fromUser.ID)
 		//
-		return s.Add(fromUser, chatID, startNode)
-	}
-	// reset removeTimer
-	if item.removeTimer != nil {
-		finished := item.removeTimer.Stop()
-		if finished {
-			item.removeTimer.Reset(sessionLiveTime)
+		var err error
+		item, err = s.Add(fromUser, chatID, startNode)
+		if err != nil {
+			return nil, err
 		}
-	}
+	} else {
+		// reset removeTimer
+		if item.removeTimer != nil {
+			item.removeTimer.Stop()
+		}
+		s.deferredDeletion(item)
 
-	//debug
-	log.Printf("len=(%v)", len(s.items))
-	for i, v := range s.items {
-		log.Printf("%v-> sAPI=%v, userID=%v, communityID=%v", i, v.SListAPI, v.User.TelegramID, v.User.ComunityID)
+		//debug
+		log.Printf("len=(%v)", len(s.items))
+		for i, v := range s.items {
+			log.Printf("%v-> sAPI=%v, userID=%v, communityID=%v", i, v.SListAPI, v.User.TelegramID, v.User.ComunityID)
+		}
+		//
+		s.mu.Unlock()
 	}
-	//
+
+	// the session may be refreshed at the moment
+	item.Lock()
+	defer item.Unlock()
+	item.setCallbackMessage(update)
+	item.setLang(fromUser)
 	return item, nil
 }
 
-func (s *SessionItem) UpdateCallbackData(currentNode, currentData *string) {
-	if currentNode != nil {
-		s.CurrentNode = *currentNode
+// Lock serializes handling of the user updates with refreshes of the session
+func (s *SessionItem) Lock() {
+	s.mu.Lock()
+}
+
+func (s *SessionItem) Unlock() {
+	s.mu.Unlock()
+}
+
+// Save stores session state, so it can be restored after restart
+func (s *SessionStorage) Save(item *SessionItem) error {
+	return s.store.Save(item.getState())
+}
+
+// Author returns the session user as the author of the changes
+func (s *SessionItem) Author() events.Author {
+	if s.User == nil {
+		return events.Author{}
 	}
-	if currentData != nil {
-		s.CurrentData = *currentData
+	return events.Author{
+		UserID:     s.User.ID,
+		TelegramID: s.User.TelegramID,
+		UserName:   s.User.TelegramUsername,
+		ComunityID: s.User.ComunityID,
 	}
 }
 
-// AddIntDataToArray interprets session data as an int array and adds value to it
-func (s *SessionItem) AddIntDataToArray(value int) {
-	dataAsArray, _ := s.Data.([]int)
-	dataAsArray = append(dataAsArray, value)
-	s.Data = dataAsArray
+// setCallbackMessage sets pressed message as the last one for the new session,
+// so the reopen button of the expired session works
+func (s *SessionItem) setCallbackMessage(update tgbotapi.Update) {
+	if s.LastMsgID == 0 && update.CallbackQuery != nil && update.CallbackQuery.Message != nil {
+		s.LastMsgID = update.CallbackQuery.Message.MessageID
+	}
 }
 
-func (s *SessionItem) SetDataArrayValue(value []int) {
-	s.Data = value
+// setLang picks language of the user: chosen in settings or telegram one
+func (s *SessionItem) setLang(user *tgbotapi.User) {
+	s.Lang = i18n.Pick(s.User.Language, user.LanguageCode)
 }
 
-func (s *SessionItem) DeleteValueInArray(value int) {
-	dataAsArray, _ := s.Data.([]int)
-	newArray := []int{}
-	for _, v := range dataAsArray {
-		if v == value {
-			continue
-		}
-		newArray = append(newArray, v)
+func (s *SessionItem) getState() State {
+	return State{
+		TelegramID:  int(s.User.TelegramID),
+		CurrentNode: s.CurrentNode,
+		CurrentData: s.CurrentData,
+		LastMsgID:   s.LastMsgID,
+		ChatID:      s.ChatID,
+		ShoppingID:  s.ShoppingID,
+		States:      copyStates(s.states),
+		Updated:     time.Now(),
 	}
+}
 
-	s.Data = newArray
+func (s *SessionItem) setState(state State) {
+	s.CurrentNode = state.CurrentNode
+	s.CurrentData = state.CurrentData
+	s.LastMsgID = state.LastMsgID
+	s.ShoppingID = state.ShoppingID
+	s.states = copyStates(state.States)
 }
 
-// GetDataAsArray interprets session data as an int array and returns it
-func (s *SessionItem) GetDataAsArray() []int {
-	dataAsArray, _ := s.Data.([]int)
-	return dataAsArray
+func (s *SessionItem) UpdateCallbackData(currentNode, currentData *string) {
+	if currentNode != nil {
+		// node states are cleared on switching to another node
+		if *currentNode != s.CurrentNode {
+			s.ResetStates()
+			s.ShoppingID = 0
+		}
+		s.CurrentNode = *currentNode
+	}
+	if currentData != nil {
+		s.CurrentData = *currentData
+	}
+}
+
+// State returns state of the node, state is created if it doesn't exist
+func (s *SessionItem) State(node string) *NodeState {
+	if s.states == nil {
+		s.states = map[string]*NodeState{}
+	}
+	state, ok := s.states[node]
+	if !ok {
+		state = &NodeState{}
+		s.states[node] = state
+	}
+	return state
 }
 
-// ClearDataArray clears data
-func (s *SessionItem) ClearDataArray() {
-	s.Data = []int{}
+// ResetStates clears states of all nodes
+func (s *SessionItem) ResetStates() {
+	s.states = map[string]*NodeState{}
 }
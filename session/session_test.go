@@ -0,0 +1,201 @@
+package session_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/enttest"
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func newMessage(userID int, text string) tgbotapi.Update {
+	return tgbotapi.Update{
+		Message: &tgbotapi.Message{
+			From: &tgbotapi.User{ID: userID, UserName: fmt.Sprintf("user%d", userID)},
+			Chat: &tgbotapi.Chat{ID: int64(userID)},
+			Text: text,
+		},
+	}
+}
+
+func TestSessionStorageRestore(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:restore?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	store := session.NewMemoryStore()
+
+	sessions := session.NewSessionStorage("", "", nil, e, store, 0)
+	item, err := sessions.Get(newMessage(1, "milk"), "firstpage")
+	require.NoError(t, err)
+	require.Equal(t, "firstpage", item.CurrentNode)
+
+	item.UpdateCallbackData(strPtr("checklist"), strPtr("12i45"))
+	item.LastMsgID = 1453
+	item.ShoppingID = 12
+	item.State("checklist").ToggleSelected(45)
+	item.State("checklist").Offset = 10
+	require.NoError(t, sessions.Save(item))
+
+	// new storage with the same store, as after restart
+	restarted := session.NewSessionStorage("", "", nil, e, store, 0)
+	restored, err := restarted.Get(newMessage(1, "milk"), "firstpage")
+	require.NoError(t, err)
+	require.Equal(t, "checklist", restored.CurrentNode)
+	require.Equal(t, "12i45", restored.CurrentData)
+	require.Equal(t, 1453, restored.LastMsgID)
+	require.Equal(t, 12, restored.ShoppingID)
+	require.Equal(t, []int{45}, restored.State("checklist").Selected)
+	require.Equal(t, 10, restored.State("checklist").Offset)
+	require.Equal(t, item.User.ID, restored.User.ID)
+}
+
+func TestSessionStorageConcurrentGet(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:concurrent?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	sessions := session.NewSessionStorage("", "", nil, e, session.NewMemoryStore(), 0)
+
+	// create users first, sqlite doesn't like parallel writers
+	for userID := 1; userID <= 5; userID++ {
+		_, err := sessions.Get(newMessage(userID, ""), "firstpage")
+		require.NoError(t, err)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		for userID := 1; userID <= 5; userID++ {
+			wg.Add(1)
+			go func(userID int) {
+				defer wg.Done()
+				item, err := sessions.Get(newMessage(userID, ""), "firstpage")
+				require.NoError(t, err)
+				require.Equal(t, int64(userID), item.User.TelegramID)
+			}(userID)
+		}
+	}
+	wg.Wait()
+}
+
+func TestSessionStorageExpiry(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:expiry?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	store := session.NewMemoryStore()
+	sessions := session.NewSessionStorage("", "", nil, e, store, time.Millisecond*50)
+
+	item, err := sessions.Get(newMessage(1, ""), "firstpage")
+	require.NoError(t, err)
+	item.UpdateCallbackData(strPtr("checklist"), strPtr("start"))
+	require.NoError(t, sessions.Save(item))
+
+	time.Sleep(time.Millisecond * 200)
+
+	// the state is kept for the reopen button
+	state, err := store.Load(1)
+	require.NoError(t, err)
+	require.Equal(t, "checklist", state.CurrentNode)
+
+	// reopen button of the expired session restores session for pressed message
+	reopen := tgbotapi.Update{
+		CallbackQuery: &tgbotapi.CallbackQuery{
+			From:    &tgbotapi.User{ID: 1},
+			Message: &tgbotapi.Message{MessageID: 1453, Chat: &tgbotapi.Chat{ID: 1}},
+			Data:    "checklist_start",
+		},
+	}
+	reopened, err := sessions.Get(reopen, "firstpage")
+	require.NoError(t, err)
+	require.NotSame(t, item, reopened)
+	require.Equal(t, "checklist", reopened.CurrentNode)
+	require.Equal(t, 1453, reopened.LastMsgID)
+}
+
+func TestSessionStorageStateTTL(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:statettl?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	store := session.NewMemoryStore()
+	require.NoError(t, store.Save(session.State{
+		TelegramID:  1,
+		CurrentNode: "checklist",
+		Updated:     time.Now().Add(-time.Hour),
+	}))
+
+	// state older than TTL is not restored
+	sessions := session.NewSessionStorage("", "", nil, e, store, 0)
+	sessions.SetStateTTL(time.Minute)
+	item, err := sessions.Get(newMessage(1, ""), "firstpage")
+	require.NoError(t, err)
+	require.Equal(t, "firstpage", item.CurrentNode)
+}
+
+func TestSessionStorageRefresh(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:refresh?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	sessions := session.NewSessionStorage("", "", nil, e, session.NewMemoryStore(), 0)
+	sessions.SetEvents(events.NewBus())
+	refreshed := make(chan *session.SessionItem, 10)
+	sessions.SetRefreshFunc(func(item *session.SessionItem) {
+		refreshed <- item
+	})
+
+	author, err := sessions.Get(newMessage(1, ""), "firstpage")
+	require.NoError(t, err)
+	member, err := sessions.Get(newMessage(2, ""), "firstpage")
+	require.NoError(t, err)
+	require.NoError(t, member.SListAPI.UpdateUser(member.User.ID, &author.User.ComunityID, nil))
+	stranger, err := sessions.Get(newMessage(3, ""), "firstpage")
+	require.NoError(t, err)
+
+	shoppingID, err := author.SListAPI.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	otherShoppingID, err := author.SListAPI.AddShoppingWithType(time.Now(), "bakery", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+
+	// member shows the shopping in the last message
+	member.Lock()
+	member.LastMsgID = 1453
+	member.ShoppingID = shoppingID
+	member.Unlock()
+	// sessions of other communities are not woken
+	stranger.Lock()
+	stranger.LastMsgID = 1454
+	stranger.ShoppingID = shoppingID
+	stranger.Unlock()
+
+	require.NoError(t, author.SListAPI.AddItem(shoppingID, "milk"))
+	select {
+	case item := <-refreshed:
+		require.Same(t, member, item)
+	case <-time.After(time.Second):
+		t.Fatal("member session is not refreshed")
+	}
+
+	// changes of other shoppings and own changes are not refreshed
+	require.NoError(t, author.SListAPI.AddItem(otherShoppingID, "bread"))
+	require.NoError(t, member.SListAPI.AddItem(shoppingID, "fish"))
+
+	items, err := author.SListAPI.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.NoError(t, author.SListAPI.RemoveItems([]int{items[0].ID}))
+	select {
+	case item := <-refreshed:
+		require.Same(t, member, item)
+	case <-time.After(time.Second):
+		t.Fatal("member session is not refreshed")
+	}
+	require.Empty(t, refreshed)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,64 @@
+package session
+
+// NodeState is the serializable state of one logic node
+type NodeState struct {
+	// Selected contains selected item IDs
+	Selected []int `json:"selected,omitempty"`
+	// Offset is the pagination offset
+	Offset int `json:"offset,omitempty"`
+	// Step is the pending wizard step
+	Step string `json:"step,omitempty"`
+	// Selecting is the explicit mode of the item selection for the bulk actions
+	Selecting bool `json:"selecting,omitempty"`
+	// HideCompleted hides bought items
+	HideCompleted bool `json:"hide_completed,omitempty"`
+	// Search is the text of the catalog products search
+	Search string `json:"search,omitempty"`
+}
+
+// IsSelected returns true if item is selected
+func (n *NodeState) IsSelected(itemID int) bool {
+	for _, v := range n.Selected {
+		if v == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleSelected selects item or removes selection if item is already selected
+func (n *NodeState) ToggleSelected(itemID int) {
+	if !n.IsSelected(itemID) {
+		n.Selected = append(n.Selected, itemID)
+		return
+	}
+
+	selected := []int{}
+	for _, v := range n.Selected {
+		if v == itemID {
+			continue
+		}
+		selected = append(selected, v)
+	}
+	n.Selected = selected
+}
+
+// SetSelected replaces selected items
+func (n *NodeState) SetSelected(itemIDs []int) {
+	n.Selected = append([]int{}, itemIDs...)
+}
+
+// ClearSelected removes selection
+func (n *NodeState) ClearSelected() {
+	n.Selected = nil
+}
+
+func copyStates(states map[string]*NodeState) map[string]*NodeState {
+	result := make(map[string]*NodeState, len(states))
+	for node, state := range states {
+		stateCopy := *state
+		stateCopy.Selected = append([]int(nil), state.Selected...)
+		result[node] = &stateCopy
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package session_test
+
+import (
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStateSelection(t *testing.T) {
+	state := session.NodeState{}
+
+	state.ToggleSelected(1)
+	state.ToggleSelected(2)
+	state.ToggleSelected(3)
+	require.Equal(t, []int{1, 2, 3}, state.Selected)
+	require.True(t, state.IsSelected(2))
+
+	state.ToggleSelected(2)
+	require.Equal(t, []int{1, 3}, state.Selected)
+	require.False(t, state.IsSelected(2))
+
+	state.SetSelected([]int{5})
+	require.Equal(t, []int{5}, state.Selected)
+
+	state.ClearSelected()
+	require.Empty(t, state.Selected)
+}
+
+func TestSessionItemStates(t *testing.T) {
+	item := session.SessionItem{}
+	checklist, currentlist := "checklist", "currentlist"
+	start, selectItem := "start", "12i45"
+
+	item.UpdateCallbackData(&checklist, &start)
+	item.State(checklist).ToggleSelected(45)
+
+	// the same node keeps state
+	item.UpdateCallbackData(&checklist, &selectItem)
+	require.Equal(t, []int{45}, item.State(checklist).Selected)
+
+	// nodes don't share state
+	require.Empty(t, item.State(currentlist).Selected)
+
+	// switching node clears state
+	item.UpdateCallbackData(&currentlist, &start)
+	item.State(currentlist).ToggleSelected(7)
+	item.UpdateCallbackData(&checklist, &start)
+	require.Empty(t, item.State(checklist).Selected)
+	require.Empty(t, item.State(currentlist).Selected)
+}
@@ -0,0 +1,211 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	storeTimeout = time.Second * 5
+	sessionDB    = "session"
+
+	createSessionDB = `CREATE TABLE IF NOT EXISTS session (
+		telegram_id  INTEGER PRIMARY KEY,
+		current_node TEXT NOT NULL,
+		current_data TEXT NOT NULL,
+		last_msg_id  INTEGER NOT NULL,
+		chat_id      INTEGER NOT NULL,
+		states       TEXT NOT NULL,
+		updated      INTEGER NOT NULL,
+		shopping_id  INTEGER NOT NULL DEFAULT 0
+	)`
+	createUpdatedIdx = `CREATE INDEX IF NOT EXISTS session_updated ON session (updated)`
+)
+
+var (
+	ErrStateNotFound = errors.New("session state not found")
+)
+
+// State is the persistent part of the user session
+type State struct {
+	TelegramID  int
+	CurrentNode string
+	CurrentData string
+	LastMsgID   int
+	ChatID      int64
+	ShoppingID  int
+	States      map[string]*NodeState
+	// Updated is the time of the last save, it is kept with seconds precision
+	Updated time.Time
+}
+
+// Store keeps session states between updates and restarts
+type Store interface {
+	Load(telegramID int) (State, error)
+	Save(state State) error
+	// Prune deletes states updated before the time
+	Prune(before time.Time) error
+}
+
+// MemoryStore keeps states in memory, they are lost on restart
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[int]State
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		states: map[int]State{},
+	}
+}
+
+func (m *MemoryStore) Load(telegramID int) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.states[telegramID]
+	if !ok {
+		return State{}, ErrStateNotFound
+	}
+	state.States = copyStates(state.States)
+	return state, nil
+}
+
+func (m *MemoryStore) Save(state State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state.States = copyStates(state.States)
+	m.states[state.TelegramID] = state
+	return nil
+}
+
+func (m *MemoryStore) Prune(before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for telegramID, state := range m.states {
+		if state.Updated.Before(before) {
+			delete(m.states, telegramID)
+		}
+	}
+	return nil
+}
+
+// SQLiteStore keeps states in the sqlite database
+type SQLiteStore struct {
+	db *sqlx.DB
+}
+
+func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
+	db, err := sqlx.Connect("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(createSessionDB); err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(createUpdatedIdx); err != nil {
+		return nil, err
+	}
+
+	return &SQLiteStore{
+		db: db,
+	}, nil
+}
+
+func (s *SQLiteStore) Load(telegramID int) (State, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := squirrel.
+		Select("telegram_id", "current_node", "current_data", "last_msg_id", "chat_id", "shopping_id", "states", "updated").
+		From(sessionDB).
+		Where(squirrel.Eq{"telegram_id": telegramID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return State{}, err
+	}
+
+	var (
+		state   State
+		states  string
+		updated int64
+	)
+	row := s.db.QueryRowContext(ctx, q, args...)
+	err = row.Scan(
+		&state.TelegramID, &state.CurrentNode,
+		&state.CurrentData, &state.LastMsgID,
+		&state.ChatID, &state.ShoppingID, &states, &updated,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		return State{}, ErrStateNotFound
+	case err != nil:
+		return State{}, err
+	}
+
+	if err := json.Unmarshal([]byte(states), &state.States); err != nil {
+		return State{}, err
+	}
+	state.Updated = time.Unix(updated, 0)
+	return state, nil
+}
+
+func (s *SQLiteStore) Save(state State) error {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if state.States == nil {
+		state.States = map[string]*NodeState{}
+	}
+	states, err := json.Marshal(state.States)
+	if err != nil {
+		return err
+	}
+
+	q, args, err := squirrel.
+		Replace(sessionDB).
+		Columns(
+			"telegram_id", "current_node", "current_data",
+			"last_msg_id", "chat_id", "shopping_id", "states", "updated",
+		).
+		Values(
+			state.TelegramID, state.CurrentNode, state.CurrentData,
+			state.LastMsgID, state.ChatID, state.ShoppingID, string(states), state.Updated.Unix(),
+		).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (s *SQLiteStore) Prune(before time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	q, args, err := squirrel.
+		Delete(sessionDB).
+		Where(squirrel.Lt{"updated": before.Unix()}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, q, args...)
+	return err
+}
@@ -0,0 +1,67 @@
+package session_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/session"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	sqliteStore, err := session.NewSQLiteStore(filepath.Join(t.TempDir(), "session.db"))
+	require.NoError(t, err)
+
+	stores := map[string]session.Store{
+		"memory": session.NewMemoryStore(),
+		"sqlite": sqliteStore,
+	}
+
+	for name, s := range stores {
+		store := s
+		t.Run(name, func(t *testing.T) {
+			_, err := store.Load(1)
+			require.Equal(t, session.ErrStateNotFound, err)
+
+			state := session.State{
+				TelegramID:  1,
+				CurrentNode: "checklist",
+				CurrentData: "12i45",
+				LastMsgID:   1453,
+				ChatID:      123456789,
+				ShoppingID:  12,
+				States: map[string]*session.NodeState{
+					"checklist": {Selected: []int{45, 46}, Step: "name"},
+				},
+				Updated: time.Now().Add(-time.Hour).Truncate(time.Second),
+			}
+			require.NoError(t, store.Save(state))
+
+			loaded, err := store.Load(1)
+			require.NoError(t, err)
+			require.Equal(t, state, loaded)
+
+			// replace existing state
+			state.CurrentNode = "currentlist"
+			state.States = map[string]*session.NodeState{
+				"currentlist": {Offset: 20},
+			}
+			require.NoError(t, store.Save(state))
+
+			loaded, err = store.Load(1)
+			require.NoError(t, err)
+			require.Equal(t, state, loaded)
+
+			// fresh states are kept
+			require.NoError(t, store.Prune(state.Updated))
+			_, err = store.Load(1)
+			require.NoError(t, err)
+
+			require.NoError(t, store.Prune(time.Now()))
+			_, err = store.Load(1)
+			require.Equal(t, session.ErrStateNotFound, err)
+		})
+	}
+}
@@ -2,17 +2,27 @@ package shoplist
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Frosin/shoplist-telegram-bot/category"
 	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/i18n"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/item"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/itemcategory"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/predicate"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/product"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shop"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/shopping"
 	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/user"
+	"github.com/Frosin/shoplist-telegram-bot/quantity"
+	"github.com/Frosin/shoplist-telegram-bot/restock"
 	"github.com/dchest/uniuri"
 	"github.com/labstack/gommon/log"
 
@@ -26,11 +36,15 @@ const (
 	shoppingTypeDefault     = 0
 	shoppingTypeCheckList   = 1
 	shoppingTypeCurrentList = 2
+
+	// frequentCount is the count of adds and buys of the product to be suggested
+	frequentCount = 2
 )
 
 type Shoplist struct {
 	token string
 	ent   *ent.Client
+	bus   *events.Bus
 }
 
 //NewShoplistAPI returns new Shoplist api instance
@@ -41,6 +55,20 @@ func NewShoplistAPI(e *ent.Client, token string) *Shoplist {
 	}
 }
 
+// SetEvents sets the bus for the events of the write methods
+func (s *Shoplist) SetEvents(bus *events.Bus) {
+	s.bus = bus
+}
+
+func author(usr *ent.User) events.Author {
+	return events.Author{
+		UserID:     usr.ID,
+		TelegramID: usr.TelegramID,
+		UserName:   usr.TelegramUsername,
+		ComunityID: usr.ComunityID,
+	}
+}
+
 func (s *Shoplist) GetUserByTelegramID(telegramID int) (*ent.User, error) {
 
 	log.Info("METHOD GetUserByTelegramID")
@@ -53,7 +81,7 @@ func (s *Shoplist) GetUserByTelegramID(telegramID int) (*ent.User, error) {
 		Where(user.TelegramIDEQ(int64(telegramID))).
 		Only(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("GetUserByTelegramID error: %w", err)
+		return nil, wrapErr("GetUserByTelegramID error", err)
 	}
 
 	log.Info("GetUserByTelegramID", user)
@@ -72,7 +100,7 @@ func (s *Shoplist) GetUsersByComunityID(comunityID string) ([]*ent.User, error)
 		Where(user.ComunityIDEQ(comunityID)).
 		All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("GetUsersByComunityID error: %w", err)
+		return nil, wrapErr("GetUsersByComunityID error", err)
 	}
 
 	log.Info("GetUsersByComunityID", users)
@@ -99,7 +127,7 @@ func (s *Shoplist) CreateUser(userID int, chatID int64, username string) (*ent.U
 		SetToken(token).Save(ctx)
 
 	if err != nil {
-		return nil, fmt.Errorf("CreateUser error: %w", err)
+		return nil, wrapErr("CreateUser error", err)
 	}
 
 	log.Info("CreateUser", user)
@@ -115,13 +143,14 @@ func (s *Shoplist) UpdateUser(userID int, comunityID, userName *string) error {
 	defer cancel()
 
 	if comunityID != nil {
-		_, err := s.ent.User.
+		usr, err := s.ent.User.
 			UpdateOneID(userID).
 			SetComunityID(*comunityID).
 			Save(ctx)
 		if err != nil {
-			return fmt.Errorf("UpdateUser error: %w", err)
+			return wrapErr("UpdateUser error", err)
 		}
+		s.bus.Publish(events.CommunityJoined{Author: author(usr)})
 	}
 
 	if userName != nil {
@@ -130,32 +159,105 @@ func (s *Shoplist) UpdateUser(userID int, comunityID, userName *string) error {
 			SetTelegramUsername(*userName).
 			Save(ctx)
 		if err != nil {
-			return fmt.Errorf("UpdateUser error: %w", err)
+			return wrapErr("UpdateUser error", err)
 		}
 	}
 
 	return nil
 }
 
+// UpdateUserLanguage sets language chosen by user, empty one means telegram language
+func (s *Shoplist) UpdateUserLanguage(userID int, language string) error {
+
+	log.Info("METHOD UpdateUserLanguage")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
+	defer cancel()
+
+	_, err := s.ent.User.
+		UpdateOneID(userID).
+		SetLanguage(language).
+		Save(ctx)
+	if err != nil {
+		return wrapErr("UpdateUserLanguage error", err)
+	}
+	return nil
+}
+
+// UpdateUserNotify sets mode of the community notifications, digest is sent
+// every minutes or daily at time of the zone if time is not empty
+func (s *Shoplist) UpdateUserNotify(userID int, mode user.Notify, minutes int, at, zone string) error {
+
+	log.Info("METHOD UpdateUserNotify")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
+	defer cancel()
+
+	_, err := s.ent.User.
+		UpdateOneID(userID).
+		SetNotify(mode).
+		SetDigestMinutes(minutes).
+		SetDigestTime(at).
+		SetDigestZone(zone).
+		Save(ctx)
+	if err != nil {
+		return wrapErr("UpdateUserNotify error", err)
+	}
+	return nil
+}
+
 func (s *Shoplist) UserInit(telegramUserID int, chatID int64, userName string) (*ent.User, error) {
 
 	log.Info("METHOD UserInit")
 	user, err := s.GetUserByTelegramID(telegramUserID)
 
 	switch {
-	case ent.IsNotFound(err):
+	case errors.Is(err, consts.ErrNotFound):
 		user, err = s.CreateUser(telegramUserID, chatID, userName)
 		if err != nil {
-			return nil, fmt.Errorf("UserInit error: %w", err)
+			return nil, wrapErr("UserInit error", err)
 		}
 	case err != nil:
-		return nil, fmt.Errorf("UserInit error: %w", err)
+		return nil, wrapErr("UserInit error", err)
+	case !user.Active:
+		// user has unblocked the bot and writes again
+		if err := s.SetUserActive(user.TelegramID, true); err != nil {
+			return nil, wrapErr("UserInit error", err)
+		}
+		user.Active = true
 	}
 
 	return user, nil
 }
 
+// SetUserActive marks user active or inactive, e.g. when user blocked the bot
+func (s *Shoplist) SetUserActive(telegramID int64, active bool) error {
+	log.Info("METHOD SetUserActive")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
+	defer cancel()
+
+	_, err := s.ent.User.
+		Update().
+		Where(user.TelegramIDEQ(telegramID)).
+		SetActive(active).
+		Save(ctx)
+	if err != nil {
+		return wrapErr("SetUserActive error", err)
+	}
+	return nil
+}
+
 func (s *Shoplist) getCommunityUsers() (int, []int, error) {
+	usr, comUserIDs, err := s.getCommunity()
+	if err != nil {
+		return 0, nil, err
+	}
+	return usr.ID, comUserIDs, nil
+}
+
+// getCommunity returns the current user and IDs of the community users
+func (s *Shoplist) getCommunity() (*ent.User, []int, error) {
 	log.Info("METHOD getCommunityUsers")
 
 	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
@@ -166,7 +268,7 @@ func (s *Shoplist) getCommunityUsers() (int, []int, error) {
 		Where(user.TokenEQ(s.token)).
 		Only(ctx)
 	if err != nil {
-		return 0, nil, fmt.Errorf("getCommunityUsers getUser error: %w", err)
+		return nil, nil, wrapErr("getCommunityUsers getUser error", err)
 	}
 
 	log.Infof("userID=%v, userTelegramID=%v, userToken=%v, userComunityID=%v", usr.ID, usr.TelegramID, usr.Token, usr.ComunityID)
@@ -176,14 +278,14 @@ func (s *Shoplist) getCommunityUsers() (int, []int, error) {
 		Where(user.ComunityIDEQ(usr.ComunityID)).
 		All(ctx)
 	if err != nil {
-		return 0, nil, fmt.Errorf("getCommunityUsers get comunityUsers error: %w", err)
+		return nil, nil, wrapErr("getCommunityUsers get comunityUsers error", err)
 	}
 	comUserIDs := []int{}
 	for _, v := range comunityUsers {
 		comUserIDs = append(comUserIDs, v.ID)
 	}
 
-	return usr.ID, comUserIDs, nil
+	return usr, comUserIDs, nil
 }
 
 //GetShoppingDays returns days with shoppings by date params
@@ -201,7 +303,7 @@ func (s *Shoplist) GetShoppingDays(time time.Time) ([]int, error) {
 
 	_, comUserIDs, err := s.getCommunityUsers()
 	if err != nil {
-		return nil, fmt.Errorf("GetShoppingDays: %w", err)
+		return nil, wrapErr("GetShoppingDays", err)
 	}
 
 	monthShoppings, err := s.ent.Shopping.
@@ -217,7 +319,7 @@ func (s *Shoplist) GetShoppingDays(time time.Time) ([]int, error) {
 		All(ctx)
 
 	if err != nil {
-		return nil, fmt.Errorf("GetShoppingDays get monthShoppings error: %w", err)
+		return nil, wrapErr("GetShoppingDays get monthShoppings error", err)
 	}
 
 	log.Info("GetShoppingDays", monthShoppings)
@@ -238,7 +340,7 @@ func (s *Shoplist) GetShoppingsByDay(sDay time.Time) ([]*ent.Shopping, error) {
 
 	_, comUserIDs, err := s.getCommunityUsers()
 	if err != nil {
-		return nil, fmt.Errorf("GetShoppingsByDay: %w", err)
+		return nil, wrapErr("GetShoppingsByDay", err)
 	}
 
 	strMonth := strconv.Itoa(int(sDay.Month()))
@@ -268,7 +370,7 @@ func (s *Shoplist) GetShoppingsByDay(sDay time.Time) ([]*ent.Shopping, error) {
 		All(ctx)
 
 	if err != nil {
-		return nil, fmt.Errorf("GetShoppingsByDay get shoppings error: %w", err)
+		return nil, wrapErr("GetShoppingsByDay get shoppings error", err)
 	}
 
 	log.Info("GetShoppingsByDay", shoppings)
@@ -282,13 +384,23 @@ func (s *Shoplist) GetShoppingItems(shoppingID int) ([]*ent.Item, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
 	defer cancel()
 
+	_, comUserIDs, err := s.getCommunityUsers()
+	if err != nil {
+		return nil, wrapErr("GetShoppingItems", err)
+	}
+
 	goods, err := s.ent.Item.
 		Query().
 		WithShopping().
-		Where(item.HasShoppingWith(shopping.IDEQ(int(shoppingID)))).
+		Where(item.HasShoppingWith(
+			shopping.IDEQ(int(shoppingID)),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			),
+		)).
 		All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("GetShoppingItems error: %w", err)
+		return nil, wrapErr("GetShoppingItems error", err)
 	}
 
 	log.Info("GetShoppingItems", goods)
@@ -304,7 +416,7 @@ func (s *Shoplist) GetShopping(ID int) (*ent.Shopping, error) {
 
 	_, comUserIDs, err := s.getCommunityUsers()
 	if err != nil {
-		return nil, fmt.Errorf("GetShopping: %w", err)
+		return nil, wrapErr("GetShopping", err)
 	}
 
 	shopping, err := s.ent.Shopping.
@@ -318,7 +430,7 @@ func (s *Shoplist) GetShopping(ID int) (*ent.Shopping, error) {
 			)).
 		Only(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("GetShopping: %w", err)
+		return nil, wrapErr("GetShopping", err)
 	}
 
 	log.Info("GetShopping", shopping)
@@ -334,7 +446,7 @@ func (s *Shoplist) GetSpecialShopping(sType consts.ShoppingType) (int, error) {
 
 	ownerID, _, err := s.getCommunityUsers()
 	if err != nil {
-		return 0, fmt.Errorf("GetSpecialShopping: %w", err)
+		return 0, wrapErr("GetSpecialShopping", err)
 	}
 
 	shopping, err := s.ent.Shopping.
@@ -350,7 +462,7 @@ func (s *Shoplist) GetSpecialShopping(sType consts.ShoppingType) (int, error) {
 		Only(ctx)
 
 	if err != nil {
-		return 0, fmt.Errorf("GetSpecialShopping: %w", err)
+		return 0, wrapErr("GetSpecialShopping", err)
 	}
 
 	log.Info("GetSpecialShopping", shopping)
@@ -358,135 +470,1346 @@ func (s *Shoplist) GetSpecialShopping(sType consts.ShoppingType) (int, error) {
 	return shopping.ID, nil
 }
 
-func (s *Shoplist) AddItem(shoppingID int, itemName string) error {
-	log.Info("METHOD AddItem")
+// GetOrAddSpecialShopping returns ID of the special shopping of the community,
+// the shopping is created on the first use of the list
+func (s *Shoplist) GetOrAddSpecialShopping(sType consts.ShoppingType, shopName string) (int, error) {
+	shoppingID, err := s.GetSpecialShopping(sType)
+	if !errors.Is(err, consts.ErrNotFound) {
+		return shoppingID, err
+	}
+
+	return s.AddShoppingWithType(time.Now(), shopName, sType)
+}
+
+// AddItem adds the item text with parsed quantity and unit, e.g. "молоко 2л"
+func (s *Shoplist) AddItem(shoppingID int, itemText string) error {
+	name, q, unit := quantity.Parse(itemText)
+	return s.AddItemQuantity(shoppingID, name, q, unit)
+}
+
+// AddItemQuantity adds the item to the shopping, quantity of the same not bought
+// item with the same unit is summed instead. Empty name is skipped.
+func (s *Shoplist) AddItemQuantity(shoppingID int, itemName string, q int, unit string) error {
+	log.Info("METHOD AddItemQuantity")
+
+	if strings.TrimSpace(itemName) == "" {
+		return nil
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
 	defer cancel()
 
-	shopping, err := s.ent.Shopping.
+	usr, comUserIDs, err := s.getCommunity()
+	if err != nil {
+		return wrapErr("AddItem", err)
+	}
+
+	// shopping must belong to the user community
+	shp, err := s.ent.Shopping.
 		Query().
-		Where(shopping.IDEQ(shoppingID)).
+		WithShop().
+		WithItem().
+		Where(
+			shopping.IDEQ(shoppingID),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			)).
 		Only(ctx)
 
 	if err != nil {
-		return fmt.Errorf("AddItem getShopping: %w", err)
+		return wrapErr("AddItem getShopping", err)
+	}
+
+	// the item gets the name of the catalog product
+	prod, err := s.product(ctx, usr, itemName)
+	if err != nil {
+		return wrapErr("AddItem getProduct", err)
+	}
+	if prod != nil {
+		itemName = prod.Title
+	}
+
+	// names are compared in go, sqlite lower() doesn't fold cyrillic
+	for _, v := range shp.Edges.Item {
+		if !v.Complete && v.Unit == unit && strings.EqualFold(v.ProductName, itemName) {
+			if _, err := productAdded(ctx, s.ent.Product, usr, prod, itemName); err != nil {
+				return wrapErr("AddItem productAdded", err)
+			}
+			return s.updateQuantity(ctx, usr, shp.ID, v, v.Quantity+q)
+		}
+	}
+
+	categoryID, err := s.categoryFor(ctx, usr, prod, itemName)
+	if err != nil {
+		return wrapErr("AddItem categoryFor", err)
 	}
 
+	var newItem *ent.Item
 	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
-		_, err := tx.Item.
+		newItem, err = tx.Item.
 			Create().
 			SetProductName(itemName).
-			SetShopping(shopping).
+			SetQuantity(q).
+			SetUnit(unit).
+			SetCategoryID(categoryID).
+			SetShopping(shp).
 			Save(ctx)
 		if err != nil {
 			return err
 		}
-		return nil
+		_, err = productAdded(ctx, tx.Product, usr, prod, itemName)
+		return err
 	})
 	if err != nil {
-		return fmt.Errorf("AddItem withTx: %w", err)
+		return wrapErr("AddItem withTx", err)
+	}
+
+	added := events.ItemAdded{
+		Author:     author(usr),
+		ShoppingID: shp.ID,
+		Date:       shp.Date,
+		ItemIDs:    []int{newItem.ID},
+		Names:      []string{quantity.Format(newItem.ProductName, newItem.Quantity, newItem.Unit)},
+	}
+	if shp.Edges.Shop != nil {
+		added.Shop = shp.Edges.Shop.Name
 	}
+	s.bus.Publish(added)
 
 	return nil
 }
 
-func (s *Shoplist) RemoveItems(items []int) error {
-	log.Info("METHOD RemoveItems")
+// AddItems adds the item texts to the shopping in one transaction, e.g. lines of
+// the pasted list. Blank texts and repeated ones are skipped, quantities of the
+// same items are summed like by AddItemQuantity. It returns the count of the
+// added and the summed items.
+func (s *Shoplist) AddItems(shoppingID int, itemTexts []string) (int, error) {
+	log.Info("METHOD AddItems")
 
 	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
 	defer cancel()
 
-	_, err := s.ent.Item.Delete().Where(item.IDIn(items...)).Exec(ctx)
+	usr, comUserIDs, err := s.getCommunity()
 	if err != nil {
-		return fmt.Errorf("RemoveItems: %w", err)
+		return 0, wrapErr("AddItems", err)
 	}
 
-	return nil
+	// shopping must belong to the user community
+	shp, err := s.ent.Shopping.
+		Query().
+		WithShop().
+		WithItem().
+		Where(
+			shopping.IDEQ(shoppingID),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			)).
+		Only(ctx)
+	if err != nil {
+		return 0, wrapErr("AddItems getShopping", err)
+	}
+
+	exist := notBoughtItems(shp)
+	entries := []*itemEntry{}
+	byKey := map[string]*itemEntry{}
+	repeated := map[string]bool{}
+	for _, text := range itemTexts {
+		name, q, unit := quantity.Parse(text)
+		if name == "" {
+			continue
+		}
+		// the item gets the name of the catalog product
+		prod, err := s.product(ctx, usr, name)
+		if err != nil {
+			return 0, wrapErr("AddItems getProduct", err)
+		}
+		if prod != nil {
+			name = prod.Title
+		}
+		k := itemKey(name, unit)
+		if repeated[k+"\x00"+strconv.Itoa(q)] {
+			continue
+		}
+		repeated[k+"\x00"+strconv.Itoa(q)] = true
+
+		if v, ok := byKey[k]; ok {
+			v.quantity += q
+			continue
+		}
+		v := &itemEntry{name: name, unit: unit, quantity: q, item: exist[k]}
+		if v.item == nil {
+			if v.category, err = s.categoryFor(ctx, usr, prod, name); err != nil {
+				return 0, wrapErr("AddItems categoryFor", err)
+			}
+		}
+		byKey[k] = v
+		entries = append(entries, v)
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	var (
+		newItems []*ent.Item
+		changed  []events.ItemQuantityChanged
+	)
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		newItems, changed, err = addEntries(ctx, tx, usr, shp, entries)
+		return err
+	})
+	if err != nil {
+		return 0, wrapErr("AddItems withTx", err)
+	}
+
+	// the community is notified about the batch once
+	if len(newItems) > 0 {
+		added := events.ItemAdded{
+			Author:     author(usr),
+			ShoppingID: shp.ID,
+			Date:       shp.Date,
+		}
+		for _, v := range newItems {
+			added.ItemIDs = append(added.ItemIDs, v.ID)
+			added.Names = append(added.Names, quantity.Format(v.ProductName, v.Quantity, v.Unit))
+		}
+		if shp.Edges.Shop != nil {
+			added.Shop = shp.Edges.Shop.Name
+		}
+		s.bus.Publish(added)
+	}
+	for _, v := range changed {
+		s.bus.Publish(v)
+	}
+
+	return len(entries), nil
 }
 
-func (s *Shoplist) AddShoppingWithType(day time.Time, shopName string, shoppingType consts.ShoppingType) (int, error) {
-	log.Info("METHOD AddShoppingWithType")
+// CopyItems adds the items of the community shopping to the shopping in one
+// transaction, quantities of the same items are summed like by AddItems.
+// It returns the count of the copied items.
+func (s *Shoplist) CopyItems(fromShoppingID, shoppingID int, itemIDs []int) (int, error) {
+	log.Info("METHOD CopyItems")
+	return s.copyItems(fromShoppingID, shoppingID, itemIDs, false)
+}
+
+// MoveItems copies the items like CopyItems and removes them from their
+// shopping in the same transaction
+func (s *Shoplist) MoveItems(fromShoppingID, shoppingID int, itemIDs []int) (int, error) {
+	log.Info("METHOD MoveItems")
+	return s.copyItems(fromShoppingID, shoppingID, itemIDs, true)
+}
+
+func (s *Shoplist) copyItems(fromShoppingID, shoppingID int, itemIDs []int, move bool) (int, error) {
+	if len(itemIDs) == 0 || fromShoppingID == shoppingID {
+		return 0, nil
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
 	defer cancel()
 
-	shp, err := s.ent.Shop.
-		Query().
-		Where(shop.NameEQ(shopName)).
-		First(ctx)
-
+	usr, comUserIDs, err := s.getCommunity()
 	if err != nil {
-		switch {
-		case ent.IsNotFound(err):
-			shp, err = s.ent.Shop.
-				Create().
-				SetName(shopName).
-				Save(ctx)
-			if err != nil {
-				return 0, fmt.Errorf("AddShoppingWithType create: %w", err)
-			}
-		default:
-			return 0, fmt.Errorf("AddShoppingWithType get shop: %w", err)
-		}
+		return 0, wrapErr("CopyItems", err)
 	}
 
-	log.Info("AddShoppingWithType", shp)
+	// shopping must belong to the user community
+	shp, err := s.ent.Shopping.
+		Query().
+		WithShop().
+		WithItem().
+		Where(
+			shopping.IDEQ(shoppingID),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			)).
+		Only(ctx)
+	if err != nil {
+		return 0, wrapErr("CopyItems getShopping", err)
+	}
 
-	ownerID, _, err := s.getCommunityUsers()
+	// items of other shoppings and communities are not copied
+	where := []predicate.Item{
+		item.IDIn(itemIDs...),
+		item.HasShoppingWith(
+			shopping.IDEQ(fromShoppingID),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			),
+		),
+	}
+	sourceItems, err := s.ent.Item.
+		Query().
+		Where(where...).
+		Order(ent.Asc(item.FieldID)).
+		All(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("AddShoppingWithType: %w", err)
+		return 0, wrapErr("CopyItems getItems", err)
+	}
+	if len(sourceItems) == 0 {
+		return 0, nil
 	}
 
-	var newShopping *ent.Shopping
-	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
-		newShopping, err = tx.Shopping.
-			Create().
-			SetShop(shp).
-			SetDate(day).
-			SetUserID(ownerID).
-			SetType(int(shoppingType)).
-			Save(ctx)
+	exist := notBoughtItems(shp)
+	entries := []*itemEntry{}
+	byKey := map[string]*itemEntry{}
+	for _, v := range sourceItems {
+		// the item gets the name of the catalog product
+		name := v.ProductName
+		prod, err := s.product(ctx, usr, name)
 		if err != nil {
+			return 0, wrapErr("CopyItems getProduct", err)
+		}
+		if prod != nil {
+			name = prod.Title
+		}
+		k := itemKey(name, v.Unit)
+		if e, ok := byKey[k]; ok {
+			e.quantity += v.Quantity
+			continue
+		}
+		// the copy keeps the category of the item
+		e := &itemEntry{name: name, unit: v.Unit, quantity: v.Quantity, category: v.CategoryID, item: exist[k]}
+		byKey[k] = e
+		entries = append(entries, e)
+	}
+
+	var (
+		newItems []*ent.Item
+		changed  []events.ItemQuantityChanged
+	)
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		newItems, changed, err = addEntries(ctx, tx, usr, shp, entries)
+		if err != nil || !move {
 			return err
 		}
-		return nil
+		_, err = tx.Item.
+			Delete().
+			Where(where...).
+			Exec(ctx)
+		return err
 	})
 	if err != nil {
-		return 0, fmt.Errorf("AddShoppingWithType withTx: %w", err)
+		return 0, wrapErr("CopyItems withTx", err)
 	}
 
-	log.Info("AddShoppingWithType", newShopping)
+	// the batch is the one event, so it is undone at once
+	copied := events.ItemsCopied{
+		Author:     author(usr),
+		ShoppingID: shp.ID,
+		Date:       shp.Date,
+	}
+	if shp.Edges.Shop != nil {
+		copied.Shop = shp.Edges.Shop.Name
+	}
+	for _, v := range newItems {
+		copied.ItemIDs = append(copied.ItemIDs, v.ID)
+	}
+	for _, v := range entries {
+		copied.Names = append(copied.Names, quantity.Format(v.name, v.quantity, v.unit))
+	}
+	for _, v := range changed {
+		copied.Changed = append(copied.Changed, events.QuantityChange{
+			ItemID:   v.ItemID,
+			Previous: v.Previous,
+		})
+	}
+	if move {
+		copied.FromShoppingID = fromShoppingID
+		for _, v := range sourceItems {
+			copied.Removed = append(copied.Removed, events.RemovedItem{
+				ProductName: v.ProductName,
+				Quantity:    v.Quantity,
+				Unit:        v.Unit,
+				CategoryID:  v.CategoryID,
+				Complete:    v.Complete,
+			})
+		}
+	}
+	s.bus.Publish(copied)
 
-	return newShopping.ID, nil
+	return len(entries), nil
 }
 
-func (s *Shoplist) AddShopping(day time.Time, shopName string) error {
-	log.Info("METHOD AddShopping")
+// UncopyItems reverts the copy in one transaction: the new items are removed,
+// the summed quantities are restored and the moved items are added back
+func (s *Shoplist) UncopyItems(copied events.ItemsCopied) error {
+	log.Info("METHOD UncopyItems")
 
-	_, err := s.AddShoppingWithType(day, shopName, consts.ShoppingTypeDefault)
-	return err
-}
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
 
-func WithTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
-	tx, err := client.Tx(ctx)
+	usr, comUserIDs, err := s.getCommunity()
 	if err != nil {
-		return err
+		return wrapErr("UncopyItems", err)
 	}
-	defer func() {
-		if v := recover(); v != nil {
-			tx.Rollback()
-			panic(v)
+	// items of other communities are not changed
+	community := item.HasShoppingWith(
+		shopping.HasUserWith(
+			user.IDIn(comUserIDs...),
+		),
+	)
+
+	var source *ent.Shopping
+	if len(copied.Removed) > 0 {
+		// shopping must belong to the user community
+		source, err = s.ent.Shopping.
+			Query().
+			WithShop().
+			Where(
+				shopping.IDEQ(copied.FromShoppingID),
+				shopping.HasUserWith(
+					user.IDIn(comUserIDs...),
+				)).
+			Only(ctx)
+		if err != nil {
+			return wrapErr("UncopyItems getShopping", err)
 		}
-	}()
-	if err := fn(tx); err != nil {
-		if rerr := tx.Rollback(); rerr != nil {
-			err = fmt.Errorf("rolling back transaction: %w", rerr)
+	}
+
+	var restored []*ent.Item
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		if len(copied.ItemIDs) > 0 {
+			_, err := tx.Item.
+				Delete().
+				Where(item.IDIn(copied.ItemIDs...), community).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		for _, v := range copied.Changed {
+			_, err := tx.Item.
+				Update().
+				Where(item.IDEQ(v.ItemID), community).
+				SetQuantity(v.Previous).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		if source == nil {
+			return nil
+		}
+		builders := make([]*ent.ItemCreate, 0, len(copied.Removed))
+		for _, v := range copied.Removed {
+			builders = append(builders, tx.Item.
+				Create().
+				SetProductName(v.ProductName).
+				SetQuantity(v.Quantity).
+				SetUnit(v.Unit).
+				SetCategoryID(v.CategoryID).
+				SetComplete(v.Complete).
+				SetShopping(source))
 		}
+		restored, err = tx.Item.CreateBulk(builders...).Save(ctx)
 		return err
+	})
+	if err != nil {
+		return wrapErr("UncopyItems withTx", err)
 	}
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("committing transaction: %w", err)
+
+	if len(copied.ItemIDs) > 0 {
+		s.bus.Publish(events.ItemsRemoved{
+			Author:     author(usr),
+			ShoppingID: copied.ShoppingID,
+			ItemIDs:    copied.ItemIDs,
+		})
+	}
+	for _, v := range copied.Changed {
+		s.bus.Publish(events.ItemQuantityChanged{
+			Author:     author(usr),
+			ShoppingID: copied.ShoppingID,
+			ItemID:     v.ItemID,
+			Quantity:   v.Previous,
+		})
+	}
+	if source != nil {
+		added := events.ItemAdded{
+			Author:     author(usr),
+			ShoppingID: source.ID,
+			Date:       source.Date,
+		}
+		for _, v := range restored {
+			added.ItemIDs = append(added.ItemIDs, v.ID)
+			added.Names = append(added.Names, quantity.Format(v.ProductName, v.Quantity, v.Unit))
+		}
+		if source.Edges.Shop != nil {
+			added.Shop = source.Edges.Shop.Name
+		}
+		s.bus.Publish(added)
 	}
+
 	return nil
 }
+
+// itemEntry is the item to add to the shopping
+type itemEntry struct {
+	name, unit         string
+	quantity, category int
+	// item is the not bought item of the shopping the quantity is added to
+	item *ent.Item
+}
+
+// itemKey is the same for the items with the same name and unit
+func itemKey(name, unit string) string {
+	return strings.ToLower(name) + "\x00" + unit
+}
+
+// notBoughtItems returns the not bought items of the shopping by their keys
+func notBoughtItems(shp *ent.Shopping) map[string]*ent.Item {
+	exist := map[string]*ent.Item{}
+	for _, v := range shp.Edges.Item {
+		if !v.Complete {
+			exist[itemKey(v.ProductName, v.Unit)] = v
+		}
+	}
+	return exist
+}
+
+// addEntries adds the entries to the shopping in the transaction, it returns
+// the new items and the changes of the summed ones
+func addEntries(ctx context.Context, tx *ent.Tx, usr *ent.User, shp *ent.Shopping, entries []*itemEntry) ([]*ent.Item, []events.ItemQuantityChanged, error) {
+	var (
+		newItems []*ent.Item
+		changed  []events.ItemQuantityChanged
+		err      error
+	)
+	builders := make([]*ent.ItemCreate, 0, len(entries))
+	for _, v := range entries {
+		if v.item == nil {
+			builders = append(builders, tx.Item.
+				Create().
+				SetProductName(v.name).
+				SetQuantity(v.quantity).
+				SetUnit(v.unit).
+				SetCategoryID(v.category).
+				SetShopping(shp))
+			continue
+		}
+		err := tx.Item.
+			UpdateOne(v.item).
+			SetQuantity(v.item.Quantity + v.quantity).
+			Exec(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		changed = append(changed, events.ItemQuantityChanged{
+			Author:      author(usr),
+			ShoppingID:  shp.ID,
+			ItemID:      v.item.ID,
+			ProductName: v.item.ProductName,
+			Quantity:    v.item.Quantity + v.quantity,
+			Previous:    v.item.Quantity,
+		})
+	}
+	if len(builders) > 0 {
+		if newItems, err = tx.Item.CreateBulk(builders...).Save(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// entries with the same name and other units are the same product
+	products := map[string]*ent.Product{}
+	for _, v := range entries {
+		name := category.Normalize(v.name)
+		prod, ok := products[name]
+		if !ok {
+			if prod, err = productByName(ctx, tx.Product, usr, name); err != nil {
+				return nil, nil, err
+			}
+		}
+		if products[name], err = productAdded(ctx, tx.Product, usr, prod, v.name); err != nil {
+			return nil, nil, err
+		}
+	}
+	return newItems, changed, nil
+}
+
+// GetItem returns the item of the user community
+func (s *Shoplist) GetItem(itemID int) (*ent.Item, error) {
+	log.Info("METHOD GetItem")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
+	defer cancel()
+
+	_, itm, err := s.getCommunityItem(ctx, itemID)
+	if err != nil {
+		return nil, wrapErr("GetItem", err)
+	}
+	return itm, nil
+}
+
+// ChangeItemQuantity adds delta to the quantity of the item, quantity is at least 1
+func (s *Shoplist) ChangeItemQuantity(itemID int, delta int) error {
+	log.Info("METHOD ChangeItemQuantity")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, itm, err := s.getCommunityItem(ctx, itemID)
+	if err != nil {
+		return wrapErr("ChangeItemQuantity", err)
+	}
+	q := itm.Quantity + delta
+	if q < 1 {
+		q = 1
+	}
+	return s.updateQuantity(ctx, usr, itm.Edges.Shopping.ID, itm, q)
+}
+
+// SetItemQuantity sets the quantity of the item
+func (s *Shoplist) SetItemQuantity(itemID int, q int) error {
+	log.Info("METHOD SetItemQuantity")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, itm, err := s.getCommunityItem(ctx, itemID)
+	if err != nil {
+		return wrapErr("SetItemQuantity", err)
+	}
+	return s.updateQuantity(ctx, usr, itm.Edges.Shopping.ID, itm, q)
+}
+
+func (s *Shoplist) updateQuantity(ctx context.Context, usr *ent.User, shoppingID int, itm *ent.Item, q int) error {
+	if q == itm.Quantity {
+		return nil
+	}
+	err := s.ent.Item.
+		UpdateOne(itm).
+		SetQuantity(q).
+		Exec(ctx)
+	if err != nil {
+		return wrapErr("updateQuantity", err)
+	}
+
+	s.bus.Publish(events.ItemQuantityChanged{
+		Author:      author(usr),
+		ShoppingID:  shoppingID,
+		ItemID:      itm.ID,
+		ProductName: itm.ProductName,
+		Quantity:    q,
+		Previous:    itm.Quantity,
+	})
+	return nil
+}
+
+// getCommunityItem returns the user and the item of the user community with its shopping
+func (s *Shoplist) getCommunityItem(ctx context.Context, itemID int) (*ent.User, *ent.Item, error) {
+	usr, comUserIDs, err := s.getCommunity()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	itm, err := s.ent.Item.
+		Query().
+		WithShopping().
+		Where(
+			item.IDEQ(itemID),
+			item.HasShoppingWith(
+				shopping.HasUserWith(
+					user.IDIn(comUserIDs...),
+				),
+			)).
+		Only(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return usr, itm, nil
+}
+
+func (s *Shoplist) RemoveItems(items []int) error {
+	log.Info("METHOD RemoveItems")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, comUserIDs, err := s.getCommunity()
+	if err != nil {
+		return wrapErr("RemoveItems", err)
+	}
+
+	// items of other communities are not removed
+	where := []predicate.Item{
+		item.IDIn(items...),
+		item.HasShoppingWith(
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			),
+		),
+	}
+	removedItems, err := s.ent.Item.
+		Query().
+		Where(where...).
+		WithShopping().
+		All(ctx)
+	if err != nil {
+		return wrapErr("RemoveItems getItems", err)
+	}
+
+	_, err = s.ent.Item.
+		Delete().
+		Where(where...).
+		Exec(ctx)
+	if err != nil {
+		return wrapErr("RemoveItems", err)
+	}
+
+	// one event per shopping
+	removed := []*events.ItemsRemoved{}
+	byShopping := map[int]*events.ItemsRemoved{}
+	for _, v := range removedItems {
+		if v.Edges.Shopping == nil {
+			continue
+		}
+		event, ok := byShopping[v.Edges.Shopping.ID]
+		if !ok {
+			event = &events.ItemsRemoved{
+				Author:     author(usr),
+				ShoppingID: v.Edges.Shopping.ID,
+			}
+			byShopping[v.Edges.Shopping.ID] = event
+			removed = append(removed, event)
+		}
+		event.ItemIDs = append(event.ItemIDs, v.ID)
+		event.Items = append(event.Items, events.RemovedItem{
+			ProductName: v.ProductName,
+			Quantity:    v.Quantity,
+			Unit:        v.Unit,
+			CategoryID:  v.CategoryID,
+			Complete:    v.Complete,
+		})
+	}
+	for _, event := range removed {
+		s.bus.Publish(*event)
+	}
+
+	return nil
+}
+
+// RestoreItems adds the removed items back to the shopping with all their
+// fields, e.g. bought items stay bought
+func (s *Shoplist) RestoreItems(shoppingID int, items []events.RemovedItem) error {
+	log.Info("METHOD RestoreItems")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, comUserIDs, err := s.getCommunity()
+	if err != nil {
+		return wrapErr("RestoreItems", err)
+	}
+
+	// shopping must belong to the user community
+	shp, err := s.ent.Shopping.
+		Query().
+		WithShop().
+		Where(
+			shopping.IDEQ(shoppingID),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			)).
+		Only(ctx)
+	if err != nil {
+		return wrapErr("RestoreItems getShopping", err)
+	}
+
+	builders := make([]*ent.ItemCreate, 0, len(items))
+	for _, v := range items {
+		builders = append(builders, s.ent.Item.
+			Create().
+			SetProductName(v.ProductName).
+			SetQuantity(v.Quantity).
+			SetUnit(v.Unit).
+			SetCategoryID(v.CategoryID).
+			SetComplete(v.Complete).
+			SetShopping(shp))
+	}
+	restored, err := s.ent.Item.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		return wrapErr("RestoreItems", err)
+	}
+
+	added := events.ItemAdded{
+		Author:     author(usr),
+		ShoppingID: shp.ID,
+		Date:       shp.Date,
+	}
+	for _, v := range restored {
+		added.ItemIDs = append(added.ItemIDs, v.ID)
+		added.Names = append(added.Names, quantity.Format(v.ProductName, v.Quantity, v.Unit))
+	}
+	if shp.Edges.Shop != nil {
+		added.Shop = shp.Edges.Shop.Name
+	}
+	s.bus.Publish(added)
+
+	return nil
+}
+
+// SetItemComplete marks the item of the community as bought or not bought
+func (s *Shoplist) SetItemComplete(itemID int, complete bool) error {
+	log.Info("METHOD SetItemComplete")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, itm, err := s.getCommunityItem(ctx, itemID)
+	if err != nil {
+		return wrapErr("SetItemComplete getItem", err)
+	}
+
+	prod, err := s.product(ctx, usr, itm.ProductName)
+	if err != nil {
+		return wrapErr("SetItemComplete getProduct", err)
+	}
+
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		err := tx.Item.
+			UpdateOne(itm).
+			SetComplete(complete).
+			Exec(ctx)
+		if err != nil || itm.Complete == complete {
+			return err
+		}
+		return productBought(ctx, tx.Product, usr, prod, itm.ProductName, complete)
+	})
+	if err != nil {
+		return wrapErr("SetItemComplete withTx", err)
+	}
+
+	s.bus.Publish(events.ItemCompleted{
+		Author:      author(usr),
+		ShoppingID:  itm.Edges.Shopping.ID,
+		ItemID:      itm.ID,
+		ProductName: itm.ProductName,
+		Complete:    complete,
+	})
+
+	return nil
+}
+
+// RemoveCompletedItems removes bought items of the shopping
+func (s *Shoplist) RemoveCompletedItems(shoppingID int) error {
+	log.Info("METHOD RemoveCompletedItems")
+
+	items, err := s.GetShoppingItems(shoppingID)
+	if err != nil {
+		return wrapErr("RemoveCompletedItems", err)
+	}
+
+	completed := []int{}
+	for _, v := range items {
+		if v.Complete {
+			completed = append(completed, v.ID)
+		}
+	}
+	if len(completed) == 0 {
+		return nil
+	}
+	return s.RemoveItems(completed)
+}
+
+// SuggestProducts returns the catalog products to add to the shopping, products
+// already in the shopping are skipped. Products containing the search text are
+// returned, the beginning matches first, or the frequent ones if it is empty.
+func (s *Shoplist) SuggestProducts(shoppingID int, search string, limit int) ([]*ent.Product, error) {
+	log.Info("METHOD SuggestProducts")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
+	defer cancel()
+
+	usr, comUserIDs, err := s.getCommunity()
+	if err != nil {
+		return nil, wrapErr("SuggestProducts", err)
+	}
+
+	items, err := s.ent.Item.
+		Query().
+		Where(item.HasShoppingWith(
+			shopping.IDEQ(shoppingID),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			),
+		)).
+		All(ctx)
+	if err != nil {
+		return nil, wrapErr("SuggestProducts getItems", err)
+	}
+	inShopping := map[string]bool{}
+	for _, v := range items {
+		inShopping[category.Normalize(v.ProductName)] = true
+	}
+
+	search = category.Normalize(search)
+	query := s.ent.Product.
+		Query().
+		Where(product.ComunityIDEQ(usr.ComunityID))
+	if search != "" {
+		query = query.Where(product.NameContains(search))
+	}
+	products, err := query.
+		Order(
+			ent.Desc(product.FieldBoughtCount),
+			ent.Desc(product.FieldAddedCount),
+			ent.Desc(product.FieldLastAdded),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, wrapErr("SuggestProducts", err)
+	}
+
+	suggested := []*ent.Product{}
+	for _, v := range products {
+		if inShopping[v.Name] || (search == "" && v.AddedCount+v.BoughtCount < frequentCount) {
+			continue
+		}
+		suggested = append(suggested, v)
+	}
+	if search != "" {
+		sort.SliceStable(suggested, func(i, j int) bool {
+			return strings.HasPrefix(suggested[i].Name, search) && !strings.HasPrefix(suggested[j].Name, search)
+		})
+	}
+	if len(suggested) > limit {
+		suggested = suggested[:limit]
+	}
+	return suggested, nil
+}
+
+// AddProduct adds the item of the catalog product to the shopping
+func (s *Shoplist) AddProduct(shoppingID int, productID int) error {
+	log.Info("METHOD AddProduct")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, _, err := s.getCommunity()
+	if err != nil {
+		return wrapErr("AddProduct", err)
+	}
+	// product must belong to the user community
+	prod, err := s.ent.Product.
+		Query().
+		Where(
+			product.IDEQ(productID),
+			product.ComunityIDEQ(usr.ComunityID),
+		).
+		Only(ctx)
+	if err != nil {
+		return wrapErr("AddProduct getProduct", err)
+	}
+	return s.AddItemQuantity(shoppingID, prod.Title, 1, "")
+}
+
+// CopyItem adds the item of the community shopping to the shopping with its
+// quantity, quantity of the same incomplete item is summed
+func (s *Shoplist) CopyItem(shoppingID int, itemID int) error {
+	log.Info("METHOD CopyItem")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	_, itm, err := s.getCommunityItem(ctx, itemID)
+	if err != nil {
+		return wrapErr("CopyItem", err)
+	}
+	return s.AddItemQuantity(shoppingID, itm.ProductName, itm.Quantity, itm.Unit)
+}
+
+// GetPurchases returns the items of the community shoppings dated since the
+// time as the purchase history, checklist and current list are skipped
+func (s *Shoplist) GetPurchases(since time.Time) ([]restock.Purchase, error) {
+	log.Info("METHOD GetPurchases")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReadTimeout)
+	defer cancel()
+
+	_, comUserIDs, err := s.getCommunityUsers()
+	if err != nil {
+		return nil, wrapErr("GetPurchases", err)
+	}
+
+	items, err := s.ent.Item.
+		Query().
+		WithShopping().
+		Where(item.HasShoppingWith(
+			shopping.TypeEQ(shoppingTypeDefault),
+			shopping.DateGTE(since),
+			shopping.HasUserWith(
+				user.IDIn(comUserIDs...),
+			),
+		)).
+		All(ctx)
+	if err != nil {
+		return nil, wrapErr("GetPurchases", err)
+	}
+
+	purchases := make([]restock.Purchase, 0, len(items))
+	for _, v := range items {
+		purchases = append(purchases, restock.Purchase{
+			ItemID: v.ID,
+			Name:   v.ProductName,
+			Date:   v.Edges.Shopping.Date,
+		})
+	}
+	return purchases, nil
+}
+
+// product returns the catalog product of the item name or nil
+func (s *Shoplist) product(ctx context.Context, usr *ent.User, itemName string) (*ent.Product, error) {
+	return productByName(ctx, s.ent.Product, usr, category.Normalize(itemName))
+}
+
+func productByName(ctx context.Context, products *ent.ProductClient, usr *ent.User, name string) (*ent.Product, error) {
+	prod, err := products.
+		Query().
+		Where(
+			product.ComunityIDEQ(usr.ComunityID),
+			product.NameEQ(name),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	return prod, err
+}
+
+// productAdded counts the product item as added, the product is created on the
+// first add with the item name as its title
+func productAdded(ctx context.Context, products *ent.ProductClient, usr *ent.User, prod *ent.Product, itemName string) (*ent.Product, error) {
+	if prod == nil {
+		return products.
+			Create().
+			SetComunityID(usr.ComunityID).
+			SetName(category.Normalize(itemName)).
+			SetTitle(itemName).
+			SetAddedCount(1).
+			Save(ctx)
+	}
+	return products.
+		UpdateOne(prod).
+		AddAddedCount(1).
+		SetLastAdded(time.Now()).
+		Save(ctx)
+}
+
+// productBought counts the product item as bought or not bought anymore
+func productBought(ctx context.Context, products *ent.ProductClient, usr *ent.User, prod *ent.Product, itemName string, bought bool) error {
+	switch {
+	case prod == nil && bought:
+		return products.
+			Create().
+			SetComunityID(usr.ComunityID).
+			SetName(category.Normalize(itemName)).
+			SetTitle(itemName).
+			SetBoughtCount(1).
+			SetLastBought(time.Now()).
+			Exec(ctx)
+	case prod == nil:
+		return nil
+	case bought:
+		return products.
+			UpdateOne(prod).
+			AddBoughtCount(1).
+			SetLastBought(time.Now()).
+			Exec(ctx)
+	case prod.BoughtCount > 0:
+		return products.
+			UpdateOne(prod).
+			AddBoughtCount(-1).
+			Exec(ctx)
+	}
+	return nil
+}
+
+// GetItemCategories returns categories of the user community in the aisle order,
+// the default categories are created on the first use
+func (s *Shoplist) GetItemCategories() ([]*ent.ItemCategory, error) {
+	log.Info("METHOD GetItemCategories")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, _, err := s.getCommunity()
+	if err != nil {
+		return nil, wrapErr("GetItemCategories", err)
+	}
+	categories, err := s.itemCategories(ctx, usr)
+	if err != nil {
+		return nil, wrapErr("GetItemCategories", err)
+	}
+	return categories, nil
+}
+
+func (s *Shoplist) itemCategories(ctx context.Context, usr *ent.User) ([]*ent.ItemCategory, error) {
+	categories, err := s.queryItemCategories(ctx, usr.ComunityID)
+	if err != nil || len(categories) > 0 {
+		return categories, err
+	}
+
+	// names are in the language of the user who opens the list first
+	lang := i18n.Pick(usr.Language, "")
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		builders := make([]*ent.ItemCategoryCreate, 0, len(category.Defaults))
+		for i, v := range category.Defaults {
+			builders = append(builders, tx.ItemCategory.
+				Create().
+				SetComunityID(usr.ComunityID).
+				SetKey(v.Key).
+				SetName(i18n.T(lang, v.Name)).
+				SetEmoji(v.Emoji).
+				SetSortOrder(i+1))
+		}
+		return tx.ItemCategory.CreateBulk(builders...).Exec(ctx)
+	})
+	// the unique index keeps the defaults seeded by the concurrent request,
+	// they are queried again as the conflicting bulk insert returns no IDs
+	if err != nil && !ent.IsConstraintError(err) {
+		return nil, err
+	}
+	return s.queryItemCategories(ctx, usr.ComunityID)
+}
+
+func (s *Shoplist) queryItemCategories(ctx context.Context, comunityID string) ([]*ent.ItemCategory, error) {
+	return s.ent.ItemCategory.
+		Query().
+		Where(itemcategory.ComunityIDEQ(comunityID)).
+		Order(ent.Asc(itemcategory.FieldSortOrder), ent.Asc(itemcategory.FieldID)).
+		All(ctx)
+}
+
+// categoryFor returns the category ID of the product: the one chosen in the
+// community or the default one by the keywords, 0 is no category
+func (s *Shoplist) categoryFor(ctx context.Context, usr *ent.User, prod *ent.Product, productName string) (int, error) {
+	if prod != nil && prod.CategoryID != 0 {
+		return prod.CategoryID, nil
+	}
+
+	key := category.Match(productName)
+	if key == "" {
+		return 0, nil
+	}
+	categories, err := s.itemCategories(ctx, usr)
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range categories {
+		if v.Key == key {
+			return v.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// SetItemCategory sets the category of the item, 0 is no category. The category
+// is remembered for the product name, so the same items get it when added.
+func (s *Shoplist) SetItemCategory(itemID int, categoryID int) error {
+	log.Info("METHOD SetItemCategory")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	usr, itm, err := s.getCommunityItem(ctx, itemID)
+	if err != nil {
+		return wrapErr("SetItemCategory getItem", err)
+	}
+
+	// category must belong to the user community
+	if categoryID != 0 {
+		_, err = s.ent.ItemCategory.
+			Query().
+			Where(
+				itemcategory.IDEQ(categoryID),
+				itemcategory.ComunityIDEQ(usr.ComunityID),
+			).
+			Only(ctx)
+		if err != nil {
+			return wrapErr("SetItemCategory getCategory", err)
+		}
+	}
+
+	prod, err := s.product(ctx, usr, itm.ProductName)
+	if err != nil {
+		return wrapErr("SetItemCategory getProduct", err)
+	}
+
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		err := tx.Item.
+			UpdateOne(itm).
+			SetCategoryID(categoryID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if prod == nil {
+			return tx.Product.
+				Create().
+				SetComunityID(usr.ComunityID).
+				SetName(category.Normalize(itm.ProductName)).
+				SetTitle(itm.ProductName).
+				SetCategoryID(categoryID).
+				Exec(ctx)
+		}
+		return tx.Product.
+			UpdateOne(prod).
+			SetCategoryID(categoryID).
+			Exec(ctx)
+	})
+	if err != nil {
+		return wrapErr("SetItemCategory withTx", err)
+	}
+
+	s.bus.Publish(events.ItemCategoryChanged{
+		Author:      author(usr),
+		ShoppingID:  itm.Edges.Shopping.ID,
+		ItemID:      itm.ID,
+		ProductName: itm.ProductName,
+		CategoryID:  categoryID,
+		Previous:    itm.CategoryID,
+	})
+
+	return nil
+}
+
+func (s *Shoplist) AddShoppingWithType(day time.Time, shopName string, shoppingType consts.ShoppingType) (int, error) {
+	log.Info("METHOD AddShoppingWithType")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	shp, err := s.ent.Shop.
+		Query().
+		Where(shop.NameEQ(shopName)).
+		First(ctx)
+
+	if err != nil {
+		switch {
+		case ent.IsNotFound(err):
+			shp, err = s.ent.Shop.
+				Create().
+				SetName(shopName).
+				Save(ctx)
+			if err != nil {
+				return 0, wrapErr("AddShoppingWithType create", err)
+			}
+		default:
+			return 0, wrapErr("AddShoppingWithType get shop", err)
+		}
+	}
+
+	log.Info("AddShoppingWithType", shp)
+
+	owner, _, err := s.getCommunity()
+	if err != nil {
+		return 0, wrapErr("AddShoppingWithType", err)
+	}
+
+	var newShopping *ent.Shopping
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		newShopping, err = tx.Shopping.
+			Create().
+			SetShop(shp).
+			SetDate(day).
+			SetUserID(owner.ID).
+			SetType(int(shoppingType)).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, wrapErr("AddShoppingWithType withTx", err)
+	}
+
+	log.Info("AddShoppingWithType", newShopping)
+
+	s.bus.Publish(events.ShoppingCreated{
+		Author:     author(owner),
+		ShoppingID: newShopping.ID,
+		Shop:       shp.Name,
+		Date:       newShopping.Date,
+		Type:       newShopping.Type,
+	})
+
+	return newShopping.ID, nil
+}
+
+func (s *Shoplist) AddShopping(day time.Time, shopName string) error {
+	log.Info("METHOD AddShopping")
+
+	_, err := s.AddShoppingWithType(day, shopName, consts.ShoppingTypeDefault)
+	return err
+}
+
+// RemoveShopping removes the shopping of the community with its items
+func (s *Shoplist) RemoveShopping(ID int) error {
+	log.Info("METHOD RemoveShopping")
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.WriteTimeout)
+	defer cancel()
+
+	_, comUserIDs, err := s.getCommunityUsers()
+	if err != nil {
+		return wrapErr("RemoveShopping", err)
+	}
+
+	err = WithTx(ctx, s.ent, func(tx *ent.Tx) error {
+		shp, err := tx.Shopping.
+			Query().
+			Where(
+				shopping.IDEQ(ID),
+				shopping.HasUserWith(
+					user.IDIn(comUserIDs...),
+				)).
+			Only(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Item.
+			Delete().
+			Where(item.HasShoppingWith(shopping.IDEQ(shp.ID))).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		return tx.Shopping.DeleteOne(shp).Exec(ctx)
+	})
+	if err != nil {
+		return wrapErr("RemoveShopping", err)
+	}
+
+	return nil
+}
+
+func WithTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("rolling back transaction: %w", rerr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr("committing transaction", err)
+	}
+	return nil
+}
+
+// wrapErr adds operation to the error, ent not found error becomes consts.ErrNotFound
+func wrapErr(operation string, err error) error {
+	if ent.IsNotFound(err) {
+		return fmt.Errorf("%s: %v: %w", operation, err, consts.ErrNotFound)
+	}
+	return fmt.Errorf("%s: %w", operation, err)
+}
@@ -0,0 +1,528 @@
+package shoplist_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/category"
+	"github.com/Frosin/shoplist-telegram-bot/consts"
+	"github.com/Frosin/shoplist-telegram-bot/events"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/enttest"
+	"github.com/Frosin/shoplist-telegram-bot/internal/shoplist/ent/hook"
+	"github.com/Frosin/shoplist-telegram-bot/shoplist"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommunityOwnership(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:ownership?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	newClient := func(telegramID int) *shoplist.Shoplist {
+		user, err := shoplist.NewShoplistAPI(e, "").UserInit(telegramID, int64(telegramID), "")
+		require.NoError(t, err)
+		return shoplist.NewShoplistAPI(e, user.Token)
+	}
+	owner := newClient(1)
+	stranger := newClient(2)
+
+	shoppingID, err := owner.AddShoppingWithType(time.Now(), consts.ChecklistWord, consts.ShoppingTypeCheckList)
+	require.NoError(t, err)
+	require.NoError(t, owner.AddItem(shoppingID, "milk"))
+
+	// stranger can't add to, read or remove from the shopping of other community
+	require.Error(t, stranger.AddItem(shoppingID, "bread"))
+
+	items, err := stranger.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	items, err = owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	require.NoError(t, stranger.RemoveItems([]int{items[0].ID}))
+	items, err = owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	require.NoError(t, owner.RemoveItems([]int{items[0].ID}))
+	items, err = owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	// shopping is removed with its items only by the community
+	require.NoError(t, owner.AddItem(shoppingID, "milk"))
+	require.ErrorIs(t, stranger.RemoveShopping(shoppingID), consts.ErrNotFound)
+	require.NoError(t, owner.RemoveShopping(shoppingID))
+	_, err = owner.GetShopping(shoppingID)
+	require.ErrorIs(t, err, consts.ErrNotFound)
+}
+
+func TestEvents(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:events?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	bus := events.NewBus()
+	published := []events.Event{}
+	bus.Subscribe(func(event events.Event) {
+		published = append(published, event)
+	})
+
+	user, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "ann")
+	require.NoError(t, err)
+	client := shoplist.NewShoplistAPI(e, user.Token)
+	client.SetEvents(bus)
+
+	day := time.Date(2024, 10, 25, 0, 0, 0, 0, time.UTC)
+	shoppingID, err := client.AddShoppingWithType(day, "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, client.AddItem(shoppingID, "milk"))
+	items, err := client.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.NoError(t, client.RemoveItems([]int{items[0].ID}))
+	require.NoError(t, client.UpdateUser(user.ID, &user.ComunityID, nil))
+
+	author := events.Author{
+		UserID:     user.ID,
+		TelegramID: 1,
+		UserName:   "ann",
+		ComunityID: user.ComunityID,
+	}
+	require.Len(t, published, 4)
+	require.Equal(t, events.ShoppingCreated{
+		Author:     author,
+		ShoppingID: shoppingID,
+		Shop:       "market",
+		Date:       day,
+	}, published[0])
+	added := published[1].(events.ItemAdded)
+	require.Equal(t, author, added.Author)
+	require.Equal(t, "market", added.Shop)
+	require.Equal(t, []int{items[0].ID}, added.ItemIDs)
+	require.Equal(t, []string{"milk"}, added.Names)
+	require.Equal(t, events.ItemsRemoved{
+		Author:     author,
+		ShoppingID: shoppingID,
+		ItemIDs:    []int{items[0].ID},
+		Items: []events.RemovedItem{{
+			ProductName: "milk",
+			Quantity:    1,
+			CategoryID:  items[0].CategoryID,
+		}},
+	}, published[2])
+	require.Equal(t, events.CommunityJoined{Author: author}, published[3])
+}
+
+func TestItemComplete(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:complete?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	newClient := func(telegramID int) *shoplist.Shoplist {
+		user, err := shoplist.NewShoplistAPI(e, "").UserInit(telegramID, int64(telegramID), "")
+		require.NoError(t, err)
+		return shoplist.NewShoplistAPI(e, user.Token)
+	}
+	owner := newClient(1)
+	stranger := newClient(2)
+
+	shoppingID, err := owner.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, owner.AddItem(shoppingID, "milk"))
+	require.NoError(t, owner.AddItem(shoppingID, "bread"))
+	items, err := owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// items of other communities are not changed
+	require.ErrorIs(t, stranger.SetItemComplete(items[0].ID, true), consts.ErrNotFound)
+	require.NoError(t, owner.SetItemComplete(items[0].ID, true))
+
+	// only bought items are cleared
+	require.NoError(t, owner.RemoveCompletedItems(shoppingID))
+	items, err = owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "bread", items[0].ProductName)
+	require.False(t, items[0].Complete)
+}
+
+func TestItemQuantity(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:quantity?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	newClient := func(telegramID int) *shoplist.Shoplist {
+		user, err := shoplist.NewShoplistAPI(e, "").UserInit(telegramID, int64(telegramID), "")
+		require.NoError(t, err)
+		return shoplist.NewShoplistAPI(e, user.Token)
+	}
+	owner := newClient(1)
+	stranger := newClient(2)
+
+	shoppingID, err := owner.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, owner.AddItem(shoppingID, "молоко 2л"))
+	require.NoError(t, owner.AddItem(shoppingID, "Молоко 1 литр"))
+	require.NoError(t, owner.AddItem(shoppingID, "3 батона"))
+	require.NoError(t, owner.AddItem(shoppingID, "молоко"))
+
+	// the same items with the same unit are merged
+	items, err := owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.Equal(t, "молоко", items[0].ProductName)
+	require.Equal(t, 3, items[0].Quantity)
+	require.Equal(t, "л", items[0].Unit)
+	require.Equal(t, "батона", items[1].ProductName)
+	require.Equal(t, 3, items[1].Quantity)
+	require.Equal(t, "", items[2].Unit)
+
+	// bought items are not merged
+	require.NoError(t, owner.SetItemComplete(items[1].ID, true))
+	require.NoError(t, owner.AddItem(shoppingID, "батона x2"))
+	items, err = owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+
+	// quantity is at least 1
+	require.ErrorIs(t, stranger.ChangeItemQuantity(items[0].ID, 1), consts.ErrNotFound)
+	require.NoError(t, owner.ChangeItemQuantity(items[0].ID, -5))
+	require.NoError(t, owner.SetItemQuantity(items[2].ID, 4))
+	items, err = owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Equal(t, 1, items[0].Quantity)
+	require.Equal(t, 4, items[2].Quantity)
+}
+
+func TestAddItems(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:additems?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	user, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "")
+	require.NoError(t, err)
+	api := shoplist.NewShoplistAPI(e, user.Token)
+	bus := events.NewBus()
+	published := []events.Event{}
+	bus.Subscribe(func(event events.Event) { published = append(published, event) })
+	api.SetEvents(bus)
+
+	shoppingID, err := api.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, api.AddItem(shoppingID, "milk"))
+
+	added, err := api.AddItems(shoppingID, []string{"bread", "", "Milk", "eggs x10", "BREAD"})
+	require.NoError(t, err)
+	require.Equal(t, 3, added)
+
+	items, err := api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.Equal(t, "milk", items[0].ProductName)
+	require.Equal(t, 2, items[0].Quantity)
+	require.Equal(t, "eggs", items[2].ProductName)
+	require.Equal(t, 10, items[2].Quantity)
+
+	// new items of the batch are the one event, quantity of the existing one is summed
+	require.Len(t, published, 4)
+	batch, ok := published[2].(events.ItemAdded)
+	require.True(t, ok)
+	require.Equal(t, []string{"bread", "eggs x10"}, batch.Names)
+	changed, ok := published[3].(events.ItemQuantityChanged)
+	require.True(t, ok)
+	require.Equal(t, items[0].ID, changed.ItemID)
+
+	// the only text is summed too, empty names are skipped
+	added, err = api.AddItems(shoppingID, []string{"eggs x2"})
+	require.NoError(t, err)
+	require.Equal(t, 1, added)
+	added, err = api.AddItems(shoppingID, []string{"", " "})
+	require.NoError(t, err)
+	require.Zero(t, added)
+	require.NoError(t, api.AddItem(shoppingID, " "))
+	items, err = api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.Equal(t, 12, items[2].Quantity)
+	require.Len(t, published, 5)
+}
+
+func TestCopyItems(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:copyitems?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	user, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "")
+	require.NoError(t, err)
+	api := shoplist.NewShoplistAPI(e, user.Token)
+	bus := events.NewBus()
+	published := []events.Event{}
+	bus.Subscribe(func(event events.Event) { published = append(published, event) })
+	api.SetEvents(bus)
+
+	fromID, err := api.AddShoppingWithType(time.Now(), consts.CurrentlistWord, consts.ShoppingTypeCurrentList)
+	require.NoError(t, err)
+	_, err = api.AddItems(fromID, []string{"milk", "bread", "eggs x10"})
+	require.NoError(t, err)
+	fromItems, err := api.GetShoppingItems(fromID)
+	require.NoError(t, err)
+	shoppingID, err := api.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, api.AddItem(shoppingID, "Milk"))
+	published = published[:0]
+
+	// the same item is summed, the others are added
+	copied, err := api.CopyItems(fromID, shoppingID, []int{fromItems[0].ID, fromItems[2].ID})
+	require.NoError(t, err)
+	require.Equal(t, 2, copied)
+	items, err := api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, 2, items[0].Quantity)
+	require.Equal(t, "eggs", items[1].ProductName)
+	require.Equal(t, 10, items[1].Quantity)
+
+	// the batch is the one event
+	require.Len(t, published, 1)
+	event, ok := published[0].(events.ItemsCopied)
+	require.True(t, ok)
+	require.Equal(t, []int{items[1].ID}, event.ItemIDs)
+	require.Equal(t, []string{"milk", "eggs x10"}, event.Names)
+	require.Equal(t, []events.QuantityChange{{ItemID: items[0].ID, Previous: 1}}, event.Changed)
+	require.Zero(t, event.FromShoppingID)
+	require.Empty(t, event.Removed)
+
+	// moved items are removed from their shopping
+	moved, err := api.MoveItems(fromID, shoppingID, []int{fromItems[0].ID, fromItems[1].ID})
+	require.NoError(t, err)
+	require.Equal(t, 2, moved)
+	fromItems, err = api.GetShoppingItems(fromID)
+	require.NoError(t, err)
+	require.Len(t, fromItems, 1)
+	require.Equal(t, "eggs", fromItems[0].ProductName)
+	items, err = api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.Equal(t, 3, items[0].Quantity)
+	require.Len(t, published, 2)
+	event = published[1].(events.ItemsCopied)
+	require.Equal(t, fromID, event.FromShoppingID)
+	require.Len(t, event.Removed, 2)
+
+	// the copy is reverted at once
+	require.NoError(t, api.UncopyItems(event))
+	items, err = api.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, 2, items[0].Quantity)
+	fromItems, err = api.GetShoppingItems(fromID)
+	require.NoError(t, err)
+	require.Len(t, fromItems, 3)
+
+	// items of other shoppings are not copied
+	copied, err = api.CopyItems(fromID, shoppingID, []int{items[0].ID})
+	require.NoError(t, err)
+	require.Zero(t, copied)
+}
+
+func TestItemCategory(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:itemcategory?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	newClient := func(telegramID int) *shoplist.Shoplist {
+		user, err := shoplist.NewShoplistAPI(e, "").UserInit(telegramID, int64(telegramID), "")
+		require.NoError(t, err)
+		return shoplist.NewShoplistAPI(e, user.Token)
+	}
+	owner := newClient(1)
+	stranger := newClient(2)
+
+	// default categories are created on the first use
+	categories, err := owner.GetItemCategories()
+	require.NoError(t, err)
+	require.Len(t, categories, len(category.Defaults))
+	require.Equal(t, "Овощи и фрукты", categories[0].Name)
+	again, err := owner.GetItemCategories()
+	require.NoError(t, err)
+	require.Equal(t, categories[0].ID, again[0].ID)
+	byKey := map[string]int{}
+	for _, v := range categories {
+		byKey[v.Key] = v.ID
+	}
+
+	shoppingID, err := owner.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	_, err = owner.AddItems(shoppingID, []string{"Молоко 2л", "лампочка"})
+	require.NoError(t, err)
+	items, err := owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Equal(t, byKey["dairy"], items[0].CategoryID)
+	require.Zero(t, items[1].CategoryID)
+
+	// categories of other communities can't be chosen
+	strangerCategories, err := stranger.GetItemCategories()
+	require.NoError(t, err)
+	require.ErrorIs(t, owner.SetItemCategory(items[1].ID, strangerCategories[0].ID), consts.ErrNotFound)
+	require.ErrorIs(t, stranger.SetItemCategory(items[1].ID, strangerCategories[0].ID), consts.ErrNotFound)
+
+	// the chosen category is remembered for the product
+	require.NoError(t, owner.SetItemCategory(items[1].ID, byKey["household"]))
+	require.NoError(t, owner.RemoveItems([]int{items[1].ID}))
+	require.NoError(t, owner.AddItem(shoppingID, "Лампочка"))
+	items, err = owner.GetShoppingItems(shoppingID)
+	require.NoError(t, err)
+	require.Equal(t, byKey["household"], items[1].CategoryID)
+}
+
+func TestItemCategoriesConcurrentSeed(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:categoryseed?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	user, err := shoplist.NewShoplistAPI(e, "").UserInit(1, 1, "")
+	require.NoError(t, err)
+	api := shoplist.NewShoplistAPI(e, user.Token)
+	concurrent := shoplist.NewShoplistAPI(e, user.Token)
+
+	// the concurrent request seeds the defaults after the categories are queried
+	seeded := false
+	e.ItemCategory.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.ItemCategoryFunc(func(ctx context.Context, m *ent.ItemCategoryMutation) (ent.Value, error) {
+			if !seeded {
+				seeded = true
+				_, err := concurrent.GetItemCategories()
+				require.NoError(t, err)
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	categories, err := api.GetItemCategories()
+	require.NoError(t, err)
+	require.True(t, seeded)
+	require.Len(t, categories, len(category.Defaults))
+	require.NotZero(t, categories[0].ID)
+	count, err := e.ItemCategory.Query().Count(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, len(category.Defaults), count)
+}
+
+func TestProducts(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:products?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	newClient := func(telegramID int) *shoplist.Shoplist {
+		user, err := shoplist.NewShoplistAPI(e, "").UserInit(telegramID, int64(telegramID), "")
+		require.NoError(t, err)
+		return shoplist.NewShoplistAPI(e, user.Token)
+	}
+	owner := newClient(1)
+	stranger := newClient(2)
+
+	first, err := owner.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	second, err := owner.AddShoppingWithType(time.Now(), "bakery", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	next, err := owner.AddShoppingWithType(time.Now(), "next", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+
+	// the first spelling of the product is kept
+	_, err = owner.AddItems(first, []string{"Молоко", "Хлеб"})
+	require.NoError(t, err)
+	require.NoError(t, owner.AddItem(second, "молоко  2"))
+	items, err := owner.GetShoppingItems(second)
+	require.NoError(t, err)
+	require.Equal(t, "Молоко", items[0].ProductName)
+
+	// products of the shopping aren't suggested
+	products, err := owner.SuggestProducts(first, "", 10)
+	require.NoError(t, err)
+	require.Empty(t, products)
+
+	// frequent products are suggested without search
+	products, err = owner.SuggestProducts(next, "", 10)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	require.Equal(t, "Молоко", products[0].Title)
+	require.Equal(t, 2, products[0].AddedCount)
+
+	products, err = owner.SuggestProducts(next, "ХЛ", 10)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	require.Equal(t, "Хлеб", products[0].Title)
+
+	// bought products are counted
+	items, err = owner.GetShoppingItems(first)
+	require.NoError(t, err)
+	require.NoError(t, owner.SetItemComplete(items[1].ID, true))
+	require.NoError(t, owner.SetItemComplete(items[0].ID, true))
+	require.NoError(t, owner.SetItemComplete(items[0].ID, false))
+	products, err = owner.SuggestProducts(next, "", 10)
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	require.Equal(t, "Хлеб", products[0].Title)
+	require.Equal(t, 1, products[0].BoughtCount)
+	require.Zero(t, products[1].BoughtCount)
+
+	// products of other communities can't be added
+	strangerShopping, err := stranger.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	strangerProducts, err := stranger.SuggestProducts(strangerShopping, "", 10)
+	require.NoError(t, err)
+	require.Empty(t, strangerProducts)
+	require.ErrorIs(t, stranger.AddProduct(strangerShopping, products[0].ID), consts.ErrNotFound)
+
+	require.NoError(t, owner.AddProduct(next, products[0].ID))
+	items, err = owner.GetShoppingItems(next)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "Хлеб", items[0].ProductName)
+	require.Equal(t, 1, items[0].Quantity)
+}
+
+func TestPurchases(t *testing.T) {
+	e := enttest.Open(t, "sqlite3", "file:purchases?mode=memory&cache=shared&_fk=1")
+	defer e.Close()
+
+	newClient := func(telegramID int) *shoplist.Shoplist {
+		user, err := shoplist.NewShoplistAPI(e, "").UserInit(telegramID, int64(telegramID), "")
+		require.NoError(t, err)
+		return shoplist.NewShoplistAPI(e, user.Token)
+	}
+	owner := newClient(1)
+	stranger := newClient(2)
+
+	weekAgo := time.Now().AddDate(0, 0, -7)
+	old, err := owner.AddShoppingWithType(time.Now().AddDate(0, 0, -30), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, owner.AddItem(old, "хлеб"))
+	recent, err := owner.AddShoppingWithType(weekAgo, "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, owner.AddItem(recent, "молоко 2л"))
+	checklist, err := owner.AddShoppingWithType(weekAgo, consts.ChecklistWord, consts.ShoppingTypeCheckList)
+	require.NoError(t, err)
+	require.NoError(t, owner.AddItem(checklist, "сыр"))
+	strangerShopping, err := stranger.AddShoppingWithType(weekAgo, "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, stranger.AddItem(strangerShopping, "чай"))
+
+	// only the community shoppings since the time are the purchases
+	purchases, err := owner.GetPurchases(time.Now().AddDate(0, 0, -10))
+	require.NoError(t, err)
+	require.Len(t, purchases, 1)
+	require.Equal(t, "молоко", purchases[0].Name)
+	require.Equal(t, weekAgo.Unix(), purchases[0].Date.Unix())
+
+	// the item is copied with its quantity
+	next, err := owner.AddShoppingWithType(time.Now(), "market", consts.ShoppingTypeDefault)
+	require.NoError(t, err)
+	require.NoError(t, owner.CopyItem(next, purchases[0].ItemID))
+	items, err := owner.GetShoppingItems(next)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "молоко", items[0].ProductName)
+	require.Equal(t, 2, items[0].Quantity)
+	require.Equal(t, "л", items[0].Unit)
+
+	// items of other communities can't be copied
+	require.ErrorIs(t, stranger.CopyItem(strangerShopping, purchases[0].ItemID), consts.ErrNotFound)
+}
@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Sender sends messages and answers to telegram, *tgbotapi.BotAPI implements it
+type Sender interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	AnswerCallbackQuery(config tgbotapi.CallbackConfig) (tgbotapi.APIResponse, error)
+	DeleteMessage(config tgbotapi.DeleteMessageConfig) (tgbotapi.APIResponse, error)
+}
+
+// Updater is the source of updates, e.g. webhook handler or long polling
+type Updater interface {
+	Updates() tgbotapi.UpdatesChannel
+}
+
+// Poller receives updates by long polling
+type Poller struct {
+	updates tgbotapi.UpdatesChannel
+}
+
+// NewPoller removes webhook and starts long polling with timeout in seconds
+func NewPoller(bot *tgbotapi.BotAPI, timeout int) (*Poller, error) {
+	if _, err := bot.RemoveWebhook(); err != nil {
+		return nil, err
+	}
+
+	config := tgbotapi.NewUpdate(0)
+	config.Timeout = timeout
+	updates, err := bot.GetUpdatesChan(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Poller{
+		updates: updates,
+	}, nil
+}
+
+// Updates returns channel with received updates
+func (p *Poller) Updates() tgbotapi.UpdatesChannel {
+	return p.updates
+}
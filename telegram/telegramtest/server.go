@@ -0,0 +1,398 @@
+// Package telegramtest provides fake Telegram Bot API server for end-to-end tests
+package telegramtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	Token = "test-token"
+
+	maxMemory   = 32 << 20
+	pollTimeout = time.Second
+)
+
+// Request is the recorded bot API call
+type Request struct {
+	Method string
+	Params url.Values
+}
+
+// Message is the chat message as the user sees it now
+type Message struct {
+	ID        int
+	ChatID    int64
+	FromBot   bool
+	Text      string
+	ParseMode string
+	Keyboard  *tgbotapi.InlineKeyboardMarkup
+	Deleted   bool
+}
+
+// Server records sent, edited and deleted messages, callback answers,
+// and gives injected updates to getUpdates
+type Server struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	requests  []Request
+	messages  []*Message
+	answers   map[string]tgbotapi.CallbackConfig
+	updates   []tgbotapi.Update
+	updateID  int
+	notify    chan struct{}
+	callIDSeq int
+}
+
+// NewServer starts fake server, it must be closed
+func NewServer() *Server {
+	s := &Server{
+		answers: map[string]tgbotapi.CallbackConfig{},
+		notify:  make(chan struct{}, 1),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns http client which sends api.telegram.org requests to the fake server
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.server.URL)
+	return &http.Client{
+		Transport: rewriteTransport{
+			target: target,
+			next:   s.server.Client().Transport,
+		},
+	}
+}
+
+// Bot returns bot api connected to the fake server
+func (s *Server) Bot() (*tgbotapi.BotAPI, error) {
+	return tgbotapi.NewBotAPIWithClient(Token, s.Client())
+}
+
+// Requests returns recorded calls of the method, all calls for empty method
+func (s *Server) Requests(method string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := []Request{}
+	for _, r := range s.requests {
+		if method == "" || r.Method == method {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// Message returns the message by its ID
+func (s *Server) Message(chatID int64, messageID int) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m := s.find(chatID, messageID); m != nil {
+		return *m, true
+	}
+	return Message{}, false
+}
+
+// LastBotMessage returns the last not deleted message sent by the bot to the chat
+func (s *Server) LastBotMessage(chatID int64) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		m := s.messages[i]
+		if m.ChatID == chatID && m.FromBot && !m.Deleted {
+			return *m, true
+		}
+	}
+	return Message{}, false
+}
+
+// Answer returns the answer of the callback query
+func (s *Server) Answer(queryID string) (tgbotapi.CallbackConfig, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	answer, ok := s.answers[queryID]
+	return answer, ok
+}
+
+// SendText makes update with the user text message, chat ID is the user ID.
+// The update is also given to getUpdates.
+func (s *Server) SendText(userID int, text string) tgbotapi.Update {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message := s.add(&Message{ChatID: int64(userID), Text: text})
+	update := s.newUpdate()
+	update.Message = &tgbotapi.Message{
+		MessageID: message.ID,
+		From:      user(userID),
+		Chat:      &tgbotapi.Chat{ID: int64(userID), Type: "private"},
+		Date:      int(time.Now().Unix()),
+		Text:      text,
+	}
+	s.inject(update)
+	return update
+}
+
+// Press makes update with callback query of the button with the text.
+// The update is also given to getUpdates.
+func (s *Server) Press(userID int, messageID int, buttonText string) (tgbotapi.Update, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message := s.find(int64(userID), messageID)
+	if message == nil || message.Deleted {
+		return tgbotapi.Update{}, fmt.Errorf("message %d not found", messageID)
+	}
+	if message.Keyboard == nil {
+		return tgbotapi.Update{}, fmt.Errorf("message %d has no keyboard", messageID)
+	}
+
+	for _, row := range message.Keyboard.InlineKeyboard {
+		for _, button := range row {
+			if button.Text != buttonText || button.CallbackData == nil {
+				continue
+			}
+			s.callIDSeq++
+			update := s.newUpdate()
+			update.CallbackQuery = &tgbotapi.CallbackQuery{
+				ID:   strconv.Itoa(s.callIDSeq),
+				From: user(userID),
+				Message: &tgbotapi.Message{
+					MessageID: message.ID,
+					Chat:      &tgbotapi.Chat{ID: message.ChatID, Type: "private"},
+					Text:      message.Text,
+				},
+				Data: *button.CallbackData,
+			}
+			s.inject(update)
+			return update, nil
+		}
+	}
+	return tgbotapi.Update{}, fmt.Errorf("button %q not found in message %d", buttonText, messageID)
+}
+
+func (s *Server) newUpdate() tgbotapi.Update {
+	s.updateID++
+	return tgbotapi.Update{UpdateID: s.updateID}
+}
+
+func (s *Server) inject(update tgbotapi.Update) {
+	s.updates = append(s.updates, update)
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Server) add(m *Message) *Message {
+	m.ID = len(s.messages) + 1
+	s.messages = append(s.messages, m)
+	return m
+}
+
+func (s *Server) find(chatID int64, messageID int) *Message {
+	if messageID < 1 || messageID > len(s.messages) {
+		return nil
+	}
+	m := s.messages[messageID-1]
+	if m.ChatID != chatID {
+		return nil
+	}
+	return m
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// path is /bot<token>/<method>
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] != "bot"+Token {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	method := parts[1]
+
+	if err := r.ParseMultipartForm(maxMemory); err != nil && err != http.ErrNotMultipart {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if method == "getUpdates" {
+		s.getUpdates(w, r.Form)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: method, Params: r.Form})
+
+	switch method {
+	case "getMe":
+		writeResult(w, tgbotapi.User{ID: 1, IsBot: true, FirstName: "bot", UserName: "test_bot"})
+	case "sendMessage", "sendPhoto", "sendDocument":
+		chatID, err := strconv.ParseInt(r.Form.Get("chat_id"), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Bad Request: chat not found")
+			return
+		}
+		keyboard, err := parseKeyboard(r.Form.Get("reply_markup"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		text := r.Form.Get("text")
+		if method != "sendMessage" {
+			text = r.Form.Get("caption")
+		}
+		m := s.add(&Message{
+			ChatID:    chatID,
+			FromBot:   true,
+			Text:      text,
+			ParseMode: r.Form.Get("parse_mode"),
+			Keyboard:  keyboard,
+		})
+		writeResult(w, result(m))
+	case "editMessageText":
+		m, ok := s.formMessage(w, r.Form)
+		if !ok {
+			return
+		}
+		keyboard, err := parseKeyboard(r.Form.Get("reply_markup"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		m.Text = r.Form.Get("text")
+		m.ParseMode = r.Form.Get("parse_mode")
+		m.Keyboard = keyboard
+		writeResult(w, result(m))
+	case "deleteMessage":
+		m, ok := s.formMessage(w, r.Form)
+		if !ok {
+			return
+		}
+		m.Deleted = true
+		writeResult(w, true)
+	case "answerCallbackQuery":
+		s.answers[r.Form.Get("callback_query_id")] = tgbotapi.CallbackConfig{
+			CallbackQueryID: r.Form.Get("callback_query_id"),
+			Text:            r.Form.Get("text"),
+			ShowAlert:       r.Form.Get("show_alert") == "true",
+		}
+		writeResult(w, true)
+	default:
+		writeResult(w, true)
+	}
+}
+
+// formMessage returns the bot message from chat_id and message_id params
+func (s *Server) formMessage(w http.ResponseWriter, form url.Values) (*Message, bool) {
+	chatID, _ := strconv.ParseInt(form.Get("chat_id"), 10, 64)
+	messageID, _ := strconv.Atoi(form.Get("message_id"))
+	m := s.find(chatID, messageID)
+	if m == nil || m.Deleted || !m.FromBot {
+		writeError(w, http.StatusBadRequest, "Bad Request: message to edit not found")
+		return nil, false
+	}
+	return m, true
+}
+
+// getUpdates returns injected updates from offset, it waits for them a bit like long polling
+func (s *Server) getUpdates(w http.ResponseWriter, form url.Values) {
+	offset, _ := strconv.Atoi(form.Get("offset"))
+
+	timer := time.NewTimer(pollTimeout)
+	defer timer.Stop()
+	for {
+		s.mu.Lock()
+		updates := []tgbotapi.Update{}
+		for _, update := range s.updates {
+			if update.UpdateID >= offset {
+				updates = append(updates, update)
+			}
+		}
+		s.mu.Unlock()
+
+		if len(updates) > 0 {
+			writeResult(w, updates)
+			return
+		}
+		select {
+		case <-s.notify:
+		case <-timer.C:
+			writeResult(w, updates)
+			return
+		}
+	}
+}
+
+func parseKeyboard(markup string) (*tgbotapi.InlineKeyboardMarkup, error) {
+	if markup == "" {
+		return nil, nil
+	}
+	keyboard := &tgbotapi.InlineKeyboardMarkup{}
+	if err := json.Unmarshal([]byte(markup), keyboard); err != nil {
+		return nil, fmt.Errorf("Bad Request: can't parse reply keyboard markup JSON object")
+	}
+	return keyboard, nil
+}
+
+func result(m *Message) tgbotapi.Message {
+	return tgbotapi.Message{
+		MessageID: m.ID,
+		Chat:      &tgbotapi.Chat{ID: m.ChatID, Type: "private"},
+		Date:      int(time.Now().Unix()),
+		Text:      m.Text,
+	}
+}
+
+func user(userID int) *tgbotapi.User {
+	return &tgbotapi.User{ID: userID, FirstName: "user", UserName: "user" + strconv.Itoa(userID)}
+}
+
+func writeResult(w http.ResponseWriter, result interface{}) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tgbotapi.APIResponse{Ok: true, Result: data})
+}
+
+func writeError(w http.ResponseWriter, code int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(tgbotapi.APIResponse{Ok: false, ErrorCode: code, Description: description})
+}
+
+// rewriteTransport sends requests to the target server
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+	return t.next.RoundTrip(r)
+}
@@ -0,0 +1,75 @@
+package telegramtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Frosin/shoplist-telegram-bot/telegram"
+	"github.com/Frosin/shoplist-telegram-bot/telegram/telegramtest"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	server := telegramtest.NewServer()
+	defer server.Close()
+
+	bot, err := server.Bot()
+	require.NoError(t, err)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("ok", "data")),
+	)
+	msg := tgbotapi.NewMessage(7, "hello")
+	msg.ReplyMarkup = keyboard
+	sent, err := bot.Send(msg)
+	require.NoError(t, err)
+
+	update, err := server.Press(7, sent.MessageID, "ok")
+	require.NoError(t, err)
+	require.Equal(t, "data", update.CallbackQuery.Data)
+	_, err = server.Press(8, sent.MessageID, "ok")
+	require.Error(t, err)
+
+	_, err = bot.Send(tgbotapi.NewEditMessageText(7, sent.MessageID, "edited"))
+	require.NoError(t, err)
+	_, err = bot.AnswerCallbackQuery(tgbotapi.NewCallback(update.CallbackQuery.ID, "toast"))
+	require.NoError(t, err)
+
+	message, ok := server.Message(7, sent.MessageID)
+	require.True(t, ok)
+	require.Equal(t, "edited", message.Text)
+	require.Nil(t, message.Keyboard)
+	answer, ok := server.Answer(update.CallbackQuery.ID)
+	require.True(t, ok)
+	require.Equal(t, "toast", answer.Text)
+
+	_, err = bot.DeleteMessage(tgbotapi.NewDeleteMessage(7, sent.MessageID))
+	require.NoError(t, err)
+	_, ok = server.LastBotMessage(7)
+	require.False(t, ok)
+	_, err = bot.Send(tgbotapi.NewEditMessageText(7, sent.MessageID, "deleted"))
+	require.Error(t, err)
+}
+
+func TestPoller(t *testing.T) {
+	server := telegramtest.NewServer()
+	defer server.Close()
+
+	bot, err := server.Bot()
+	require.NoError(t, err)
+
+	poller, err := telegram.NewPoller(bot, 1)
+	require.NoError(t, err)
+	defer bot.StopReceivingUpdates()
+
+	sent := server.SendText(7, "milk")
+	select {
+	case update := <-poller.Updates():
+		require.Equal(t, sent.UpdateID, update.UpdateID)
+		require.Equal(t, "milk", update.Message.Text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("update is not received")
+	}
+	require.NotEmpty(t, server.Requests("setWebhook"))
+}
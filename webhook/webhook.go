@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/dchest/uniuri"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	// SecretHeader is sent by Telegram when the webhook was set with secret_token
+	SecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+	maxBodySize = 1 << 20
+	secretLen   = 32
+)
+
+// Setter is the part of the bot API which sets the webhook
+type Setter interface {
+	MakeRequest(endpoint string, params url.Values) (tgbotapi.APIResponse, error)
+	UploadFile(endpoint string, params map[string]string, fieldname string, file interface{}) (tgbotapi.APIResponse, error)
+}
+
+// Handler accepts Telegram update POSTs and passes them to the updates channel
+type Handler struct {
+	secret  string
+	updates chan tgbotapi.Update
+}
+
+// NewHandler returns webhook handler, updates are checked by the secret path or token,
+// all updates are rejected without the secret
+func NewHandler(secret string, buffer int) *Handler {
+	return &Handler{
+		secret:  secret,
+		updates: make(chan tgbotapi.Update, buffer),
+	}
+}
+
+// Updates returns channel with received updates, same as the polling one
+func (h *Handler) Updates() tgbotapi.UpdatesChannel {
+	return h.updates
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.authorized(r) {
+		log.Println("webhook: unauthorized request from", r.RemoteAddr)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var update tgbotapi.Update
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&update); err != nil {
+		log.Println("webhook: bad update:", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.updates <- update
+	w.WriteHeader(http.StatusOK)
+}
+
+// authorized checks secret token header or the last element of the request path
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.secret == "" {
+		return false
+	}
+
+	if token := r.Header.Get(SecretHeader); token != "" {
+		return equal(token, h.secret)
+	}
+
+	return equal(path.Base(r.URL.Path), h.secret)
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// NewSecret returns random secret, it is used if the secret is not configured
+func NewSecret() string {
+	return uniuri.NewLen(secretLen)
+}
+
+// Set sets the webhook with the secret, Telegram sends it in SecretHeader of
+// every update. The library config has no secret_token field.
+func Set(bot Setter, config tgbotapi.WebhookConfig, secret string) error {
+	if config.Certificate == nil {
+		v := url.Values{}
+		v.Add("url", config.URL.String())
+		v.Add("secret_token", secret)
+		if config.MaxConnections != 0 {
+			v.Add("max_connections", strconv.Itoa(config.MaxConnections))
+		}
+		_, err := bot.MakeRequest("setWebhook", v)
+		return err
+	}
+
+	params := map[string]string{
+		"url":          config.URL.String(),
+		"secret_token": secret,
+	}
+	if config.MaxConnections != 0 {
+		params["max_connections"] = strconv.Itoa(config.MaxConnections)
+	}
+	_, err := bot.UploadFile("setWebhook", params, "certificate", config.Certificate)
+	return err
+}
+
+// URL returns webhook url with the secret path
+func URL(baseURL, secret string) string {
+	if secret == "" {
+		return baseURL
+	}
+	if baseURL != "" && baseURL[len(baseURL)-1] == '/' {
+		return baseURL + secret
+	}
+	return baseURL + "/" + secret
+}
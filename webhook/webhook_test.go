@@ -0,0 +1,182 @@
+package webhook_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/Frosin/shoplist-telegram-bot/webhook"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "s3cr3t"
+
+func loadUpdate(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     string
+		body       string
+		expCode    int
+		expUpdate  bool
+		checkValue func(t *testing.T, u tgbotapi.Update)
+	}{
+		{
+			name:      "message by secret path",
+			method:    http.MethodPost,
+			target:    "/" + testSecret,
+			body:      "message_update.json",
+			expCode:   http.StatusOK,
+			expUpdate: true,
+			checkValue: func(t *testing.T, u tgbotapi.Update) {
+				require.Equal(t, 917341201, u.UpdateID)
+				require.NotNil(t, u.Message)
+				require.Equal(t, "молоко", u.Message.Text)
+				require.Equal(t, int64(123456789), u.Message.Chat.ID)
+			},
+		},
+		{
+			name:      "callback by secret header",
+			method:    http.MethodPost,
+			target:    "/",
+			header:    testSecret,
+			body:      "callback_update.json",
+			expCode:   http.StatusOK,
+			expUpdate: true,
+			checkValue: func(t *testing.T, u tgbotapi.Update) {
+				require.NotNil(t, u.CallbackQuery)
+				require.Equal(t, "currentlist_12i45", u.CallbackQuery.Data)
+				require.Equal(t, 1453, u.CallbackQuery.Message.MessageID)
+			},
+		},
+		{
+			name:    "wrong path",
+			method:  http.MethodPost,
+			target:  "/wrong",
+			body:    "message_update.json",
+			expCode: http.StatusForbidden,
+		},
+		{
+			name:    "wrong header",
+			method:  http.MethodPost,
+			target:  "/" + testSecret,
+			header:  "wrong",
+			body:    "message_update.json",
+			expCode: http.StatusForbidden,
+		},
+		{
+			name:    "not post",
+			method:  http.MethodGet,
+			target:  "/" + testSecret,
+			expCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := webhook.NewHandler(testSecret, 1)
+
+			var body []byte
+			if test.body != "" {
+				body = loadUpdate(t, test.body)
+			}
+			req := httptest.NewRequest(test.method, test.target, bytes.NewReader(body))
+			if test.header != "" {
+				req.Header.Set(webhook.SecretHeader, test.header)
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			require.Equal(t, test.expCode, rec.Code)
+			if !test.expUpdate {
+				require.Len(t, h.Updates(), 0)
+				return
+			}
+			require.Len(t, h.Updates(), 1)
+			test.checkValue(t, <-h.Updates())
+		})
+	}
+}
+
+func TestHandlerBadBody(t *testing.T) {
+	h := webhook.NewHandler(testSecret, 1)
+
+	req := httptest.NewRequest(http.MethodPost, "/"+testSecret, bytes.NewBufferString("{bad json"))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Len(t, h.Updates(), 0)
+}
+
+func TestHandlerWithoutSecret(t *testing.T) {
+	h := webhook.NewHandler("", 1)
+
+	for _, target := range []string{"/", "/any"} {
+		req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(loadUpdate(t, "message_update.json")))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	}
+	require.Len(t, h.Updates(), 0)
+}
+
+// fakeSetter keeps the params of the last request
+type fakeSetter struct {
+	values url.Values
+	params map[string]string
+}
+
+func (f *fakeSetter) MakeRequest(endpoint string, params url.Values) (tgbotapi.APIResponse, error) {
+	f.values = params
+	return tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func (f *fakeSetter) UploadFile(endpoint string, params map[string]string, fieldname string, file interface{}) (tgbotapi.APIResponse, error) {
+	f.params = params
+	return tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func TestSet(t *testing.T) {
+	link := webhook.URL("https://bot.example.com", testSecret)
+
+	setter := &fakeSetter{}
+	require.NoError(t, webhook.Set(setter, tgbotapi.NewWebhook(link), testSecret))
+	require.Equal(t, link, setter.values.Get("url"))
+	require.Equal(t, testSecret, setter.values.Get("secret_token"))
+
+	// the secret is sent with the certificate too
+	require.NoError(t, webhook.Set(setter, tgbotapi.NewWebhookWithCert(link, "cert.pem"), testSecret))
+	require.Equal(t, link, setter.params["url"])
+	require.Equal(t, testSecret, setter.params["secret_token"])
+
+	secret := webhook.NewSecret()
+	require.Len(t, secret, 32)
+	require.NotEqual(t, secret, webhook.NewSecret())
+}
+
+func TestURL(t *testing.T) {
+	require.Equal(t, "https://bot.example.com/s3cr3t", webhook.URL("https://bot.example.com", testSecret))
+	require.Equal(t, "https://bot.example.com/s3cr3t", webhook.URL("https://bot.example.com/", testSecret))
+	require.Equal(t, "https://bot.example.com", webhook.URL("https://bot.example.com", ""))
+}